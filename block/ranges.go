@@ -109,3 +109,28 @@ func (r Ranges) MergedBuckets(maxBucketSize uint64) (out Ranges) {
 	}
 	return out
 }
+
+// MergedBucketsByCost merges contiguous ranges into buckets of at most
+// `maxBucketSize` blocks whose summed `cost` stays under `maxBucketCost`. A
+// range costing more than `maxBucketCost` gets a bucket of its own.
+func (r Ranges) MergedBucketsByCost(maxBucketSize uint64, maxBucketCost float64, cost func(*Range) float64) (out Ranges) {
+	for i := 0; i < len(r); i++ {
+		bucket := NewRange(r[i].StartBlock, r[i].ExclusiveEndBlock)
+		bucketCost := cost(r[i])
+
+		for ; i+1 < len(r); i++ {
+			next := r[i+1]
+			if bucket.ExclusiveEndBlock != next.StartBlock || next.ExclusiveEndBlock-bucket.StartBlock > maxBucketSize {
+				break
+			}
+			nextCost := cost(next)
+			if bucketCost+nextCost > maxBucketCost {
+				break
+			}
+			bucket.ExclusiveEndBlock = next.ExclusiveEndBlock
+			bucketCost += nextCost
+		}
+		out = append(out, bucket)
+	}
+	return out
+}
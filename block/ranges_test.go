@@ -51,3 +51,37 @@ func TestRangeMergedBuckets(t *testing.T) {
 		ParseRanges("1-2,2-3,3-4,4-5,10-12,13-14").MergedBuckets(3).String(),
 	)
 }
+
+func TestRangeMergedBucketsByCost(t *testing.T) {
+	// blocks cost 1 before block 30, 10 after
+	cost := func(r *Range) float64 {
+		var out float64
+		for blk := r.StartBlock; blk < r.ExclusiveEndBlock; blk++ {
+			if blk < 30 {
+				out += 1
+			} else {
+				out += 10
+			}
+		}
+		return out
+	}
+
+	assert.Equal(t,
+		ParseRanges("0-30,30-40,40-50,50-60").String(),
+		ParseRanges("0-10,10-20,20-30,30-40,40-50,50-60").MergedBucketsByCost(40, 30, cost).String(),
+	)
+	assert.Equal(t,
+		ParseRanges("0-20,20-30,30-40,40-50").String(),
+		ParseRanges("0-10,10-20,20-30,30-40,40-50").MergedBucketsByCost(20, 30, cost).String(),
+		"bounded by size",
+	)
+	assert.Equal(t,
+		ParseRanges("0-20,30-50").String(),
+		ParseRanges("0-10,10-20,30-40,40-50").MergedBucketsByCost(40, 200, cost).String(),
+		"not contiguous",
+	)
+	assert.Equal(t,
+		ParseRanges("0-50").String(),
+		ParseRanges("0-10,10-20,20-30,30-40,40-50").MergedBucketsByCost(100, 1000, cost).String(),
+	)
+}
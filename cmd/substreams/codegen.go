@@ -26,12 +26,20 @@ var codegenCmd = &cobra.Command{
 		Generate a Rust trait and boilerplate code from your 'substreams.yaml' for nicer development.
 		The manifest is optional as it will try to find a file named 'substreams.yaml' in current working directory if nothing entered.
 		You may enter a directory that contains a 'substreams.yaml' file in place of '<manifest_file>'.
+
+		With '--lang go', the modules are written in Go and compiled with TinyGo instead: the 'substreams'
+		package implementing the host interface and the modules' entrypoints are generated next to the
+		manifest, along with a 'lib.go' file holding the modules' functions if it does not exist yet.
+		Protobuf messages are referred to through the 'go_package' option of their file, which must name a package
+		of the project's Go module: a new 'go.mod' takes its module path from it.
 	`),
 	RunE: runCodeGen,
 	Args: cobra.RangeArgs(0, 1),
 }
 
 func init() {
+	codegenCmd.Flags().String("lang", "rust", "Language the modules are written in, either 'rust' or 'go'")
+
 	alphaCmd.AddCommand(codegenCmd)
 }
 
@@ -64,6 +72,26 @@ func runCodeGen(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("reading manifest %q: %w", manifestPath, err)
 	}
 
+	lang := mustGetString(cmd, "lang")
+	if lang != "rust" && lang != "go" {
+		return fmt.Errorf("invalid language %q, must be either 'rust' or 'go'", lang)
+	}
+
+	if lang == "go" {
+		projectDir := workingDir
+		if devSubstreamsCodegenGenerateTo != "" {
+			projectDir, err = filepath.Abs(devSubstreamsCodegenGenerateTo)
+			if err != nil {
+				return fmt.Errorf("generate to folder %q should be able to be made absolute: %w", devSubstreamsCodegenGenerateTo, err)
+			}
+		}
+
+		if err := codegen.NewGoGenerator(manif, protoDefinitions, projectDir).Generate(); err != nil {
+			return fmt.Errorf("generating code: %w", err)
+		}
+		return nil
+	}
+
 	srcDir := path.Join(workingDir, "src")
 	if devSubstreamsCodegenGenerateTo != "" {
 		srcDir, err = filepath.Abs(devSubstreamsCodegenGenerateTo)
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli"
+	"github.com/streamingfast/dstore"
+
+	"github.com/streamingfast/substreams/manifest"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <old_package> <new_package>",
+	Short: "Show the modules whose cache is invalidated between two versions of a package",
+	Long: cli.Dedent(`
+		Show the modules whose cache is invalidated between two versions of a package, that is the modules added,
+		removed, or whose hash changed. A module's hash changes when its code, inputs, initial block or params
+		change, and when one of its ancestors' hash changes.
+
+		With '--state-store-url', the cache data lost is estimated from the size of the files cached, in that
+		store, for the old hash of each removed, changed or invalidated module.
+	`),
+	RunE:         runDiff,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.Flags().String("state-store-url", "", "URL of the state store holding the modules' cache, used to estimate the cache data lost")
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	oldPkg, err := manifest.NewReader(args[0]).Read()
+	if err != nil {
+		return fmt.Errorf("read manifest %q: %w", args[0], err)
+	}
+	newPkg, err := manifest.NewReader(args[1]).Read()
+	if err != nil {
+		return fmt.Errorf("read manifest %q: %w", args[1], err)
+	}
+
+	diffs, err := manifest.DiffPackages(oldPkg, newPkg)
+	if err != nil {
+		return err
+	}
+
+	if len(diffs) == 0 {
+		fmt.Println("No module cache is invalidated.")
+		return nil
+	}
+
+	var stateStore dstore.Store
+	if stateStoreURL := mustGetString(cmd, "state-store-url"); stateStoreURL != "" {
+		stateStore, err = dstore.NewStore(stateStoreURL, "", "", false)
+		if err != nil {
+			return fmt.Errorf("creating state store: %w", err)
+		}
+	}
+
+	var totalFiles int
+	var totalSize int64
+	for _, diff := range diffs {
+		switch diff.Change {
+		case manifest.ModuleAdded:
+			fmt.Printf("+ %s (%s)\n", diff.Name, diff.NewHash)
+			continue
+		case manifest.ModuleRemoved:
+			fmt.Printf("- %s (%s)\n", diff.Name, diff.OldHash)
+		case manifest.ModuleChanged:
+			fmt.Printf("~ %s (%s -> %s)\n", diff.Name, diff.OldHash, diff.NewHash)
+			fmt.Printf("    changed: %s\n", strings.Join(diff.Reasons, ", "))
+		case manifest.ModuleInvalidated:
+			fmt.Printf("! %s (%s -> %s)\n", diff.Name, diff.OldHash, diff.NewHash)
+			fmt.Printf("    invalidated by: %s\n", strings.Join(diff.ChangedAncestors, ", "))
+		}
+
+		if stateStore == nil {
+			fmt.Printf("    cache lost: from block %d\n", diff.OldModule.InitialBlock)
+			continue
+		}
+
+		files, size, err := cachedDataSize(cmd.Context(), stateStore, diff.OldHash)
+		if err != nil {
+			return fmt.Errorf("module %q: %w", diff.Name, err)
+		}
+		totalFiles += files
+		totalSize += size
+		fmt.Printf("    cache lost: %d files, %s\n", files, humanize.Bytes(uint64(size)))
+	}
+
+	if stateStore != nil {
+		fmt.Println("")
+		fmt.Printf("Total cache lost: %d files, %s\n", totalFiles, humanize.Bytes(uint64(totalSize)))
+	}
+
+	return nil
+}
+
+// cachedDataSize returns the number and total size of the files cached in `stateStore` for
+// the module with hash `moduleHash`, its outputs and states.
+func cachedDataSize(ctx context.Context, stateStore dstore.Store, moduleHash string) (files int, size int64, err error) {
+	err = stateStore.Walk(ctx, moduleHash+"/", func(filename string) error {
+		attr, err := stateStore.ObjectAttributes(ctx, filename)
+		if err != nil {
+			return fmt.Errorf("getting attributes of %q: %w", filename, err)
+		}
+		files++
+		size += attr.Size
+		return nil
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("walking cached files: %w", err)
+	}
+	return files, size, nil
+}
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"encoding/hex"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/streamingfast/cli"
@@ -61,6 +63,22 @@ func runInfo(cmd *cobra.Command, args []string) error {
 		fmt.Println("Doc: " + strings.Replace(doc, "\n", "\n  ", -1))
 	}
 
+	for _, signature := range pkg.Signatures {
+		fmt.Println("Signed by:", hex.EncodeToString(signature.PublicKey))
+	}
+
+	if provenance := pkg.PackageMeta[0].Provenance; provenance != nil {
+		fmt.Println("Manifest hash:", provenance.ManifestSha256)
+		var tools []string
+		for tool := range provenance.ToolchainVersions {
+			tools = append(tools, tool)
+		}
+		sort.Strings(tools)
+		for _, tool := range tools {
+			fmt.Printf("Built with %s: %s\n", tool, provenance.ToolchainVersions[tool])
+		}
+	}
+
 	hashes := manifest.NewModuleHashes()
 
 	fmt.Println("Modules:")
@@ -77,13 +95,33 @@ func runInfo(cmd *cobra.Command, args []string) error {
 			fmt.Println("Kind: store")
 			fmt.Println("Value Type:", v.KindStore.ValueType)
 			fmt.Println("Update Policy:", v.KindStore.UpdatePolicy)
+		case *pbsubstreams.Module_KindBlocks_:
+			fmt.Println("Kind: blocks")
+			fmt.Println("Output Type:", module.Output.Type)
+			if len(v.KindBlocks.FieldMask) != 0 {
+				fmt.Println("Field Mask:", strings.Join(v.KindBlocks.FieldMask, ", "))
+			}
+			if v.KindBlocks.Filter != "" {
+				fmt.Println("Filter:", v.KindBlocks.Filter)
+			}
 		default:
 			fmt.Println("Kind: Unknown")
 		}
 
-		hashes.HashModule(pkg.Modules, module, graph)
+		if len(module.Inputs) != 0 {
+			if params := module.Inputs[0].GetParams(); params != nil {
+				printParamsInfo(params)
+			}
+		}
+
+		if _, err := hashes.HashModule(pkg.Modules, module, graph); err != nil {
+			return fmt.Errorf("hashing module %q: %w", module.Name, err)
+		}
 
 		fmt.Println("Hash:", hashes.Get(module.Name))
+		if len(module.CodeHash) != 0 {
+			fmt.Println("Code hash:", hex.EncodeToString(module.CodeHash))
+		}
 		moduleMeta := pkg.ModuleMeta[modIdx]
 		if moduleMeta != nil && moduleMeta.Doc != "" {
 			fmt.Println("Doc: " + strings.Replace(moduleMeta.Doc, "\n", "\n  ", -1))
@@ -93,3 +131,20 @@ func runInfo(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func printParamsInfo(params *pbsubstreams.Module_Input_Params) {
+	paramsType := params.Type
+	if paramsType == "" {
+		paramsType = "string"
+	}
+	fmt.Println("Params Type:", paramsType)
+	if params.Required {
+		fmt.Println("Params Required: true")
+	}
+	if params.Value != "" {
+		fmt.Printf("Params Default: %q\n", params.Value)
+	}
+	if params.Doc != "" {
+		fmt.Println("Params Doc: " + strings.Replace(params.Doc, "\n", "\n  ", -1))
+	}
+}
@@ -21,6 +21,8 @@ import (
 	"github.com/streamingfast/eth-go"
 	"github.com/streamingfast/substreams/codegen"
 	"github.com/streamingfast/substreams/codegen/templates"
+	"github.com/streamingfast/substreams/manifest"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 )
 
 // Some developers centric environment overidde to make it faster to iterate on `substreams init` command
@@ -29,16 +31,31 @@ var (
 	devInitProjectName             = os.Getenv("SUBSTREAMS_DEV_INIT_PROJECT_NAME")
 	devInitProtocol                = os.Getenv("SUBSTREAMS_DEV_INIT_PROTOCOL")
 	devInitEthereumTrackedContract = os.Getenv("SUBSTREAMS_DEV_INIT_ETHEREUM_TRACKED_CONTRACT")
+	devInitTemplate                = os.Getenv("SUBSTREAMS_DEV_INIT_TEMPLATE")
+	devInitSourceType              = os.Getenv("SUBSTREAMS_DEV_INIT_SOURCE_TYPE")
 )
 
-var errInitUnsupportedChain = errors.New("unsupported chain")
-
 var initCmd = &cobra.Command{
 	Use:   "init [<path>]",
 	Short: "Initialize a new, working Substreams project from scratch.",
 	Long: cli.Dedent(`
 		Initialize a new, working Substreams project from scratch. The path parameter is optional,
 		with your current working directory being the default value.
+
+		Ethereum contracts can be given directly with '--contract', repeated for each contract to
+		track. Their ABIs are fetched from Etherscan unless local ABI JSON files are given with
+		'--abi-file', one for each '--contract' in the same order, for example:
+
+		  substreams alpha init --contract 0xbc4c... --abi-file ./bayc.json --contract 0xa0b8... --abi-file ./usdc.json
+
+		Each contract gets a store counting its events. With several contracts, their events are
+		prefixed by the contract's name, derived from its ABI file name.
+
+		For chains other than Ethereum, the project is created from a chain-agnostic template,
+		parameterized by the type of the chain's source block. Besides the built-in templates,
+		your own templates can be offered with '--template-dir' (a directory holding a
+		'template.yaml' file, or directories of such templates) and '--template-package' (a
+		package built from a manifest listing template directories in its 'templates' section).
 	`),
 	RunE:         runSubstreamsInitE,
 	Args:         cobra.RangeArgs(0, 1),
@@ -46,6 +63,11 @@ var initCmd = &cobra.Command{
 }
 
 func init() {
+	initCmd.Flags().StringArray("contract", nil, "Ethereum contract address to track, can be repeated. Skips the protocol and contract prompts")
+	initCmd.Flags().StringArray("abi-file", nil, "Local ABI JSON file of the matching --contract, can be repeated. When provided, ABIs are not fetched from Etherscan")
+	initCmd.Flags().StringArray("template-dir", nil, "Directory of a project template, or holding project templates in its sub-directories, can be repeated")
+	initCmd.Flags().StringArray("template-package", nil, "Package (local '.spkg' file or URL) shipping project templates, can be repeated")
+
 	alphaCmd.AddCommand(initCmd)
 }
 
@@ -71,42 +93,64 @@ func runSubstreamsInitE(cmd *cobra.Command, args []string) error {
 
 	absoluteProjectDir := path.Join(absoluteWorkingDir, projectName)
 
-	protocol, err := promptProtocol()
-	if err != nil {
-		return fmt.Errorf("running protocol prompt: %w", err)
+	abiFiles := mustGetStringArray(cmd, "abi-file")
+	contractAddresses := mustGetStringArray(cmd, "contract")
+	if len(abiFiles) > 0 && len(abiFiles) != len(contractAddresses) {
+		return fmt.Errorf("each --abi-file must be matched by a --contract, got %d ABI files for %d contracts", len(abiFiles), len(contractAddresses))
 	}
 
-	switch protocol {
-	case codegen.ProtocolEthereum:
-		wantsABI, err := promptTrackContract()
+	protocol := codegen.ProtocolEthereum
+	if len(contractAddresses) == 0 {
+		protocol, err = promptProtocol()
 		if err != nil {
-			return fmt.Errorf("running ABI prompt: %w", err)
+			return fmt.Errorf("running protocol prompt: %w", err)
 		}
+	}
 
-		// Default 'Bored Ape Yacht Club' contract.
-		// Used in 'github.com/streamingfast/substreams-template'
-		contract := eth.MustNewAddress("bc4ca0eda7647a8ab7c2061c2e118a18a936f13d")
+	switch protocol {
+	case codegen.ProtocolEthereum:
+		var contracts []*templates.EthereumContract
+		if len(contractAddresses) > 0 {
+			contracts, err = ethereumContractsFromFlags(cmd.Context(), contractAddresses, abiFiles)
+			if err != nil {
+				return err
+			}
+		} else {
+			wantsABI, err := promptTrackContract()
+			if err != nil {
+				return fmt.Errorf("running ABI prompt: %w", err)
+			}
+
+			// Default 'Bored Ape Yacht Club' contract.
+			// Used in 'github.com/streamingfast/substreams-template'
+			contract := eth.MustNewAddress("bc4ca0eda7647a8ab7c2061c2e118a18a936f13d")
+
+			if wantsABI {
+				contract, err = promptEthereumVerifiedContract()
+				if err != nil {
+					return fmt.Errorf("running contract prompt: %w", err)
+				}
+			}
 
-		if wantsABI {
-			contract, err = promptEthereumVerifiedContract()
+			// Get contract abiContent & parse
+			abiContent, abi, err := GetContractABI(cmd.Context(), contract)
 			if err != nil {
-				return fmt.Errorf("running contract prompt: %w", err)
+				return fmt.Errorf("getting contract ABI: %w", err)
 			}
-		}
 
-		// Get contract abiContent & parse
-		abiContent, abi, err := GetContractABI(cmd.Context(), contract)
-		if err != nil {
-			return fmt.Errorf("getting contract ABI: %w", err)
+			contracts = append(contracts, &templates.EthereumContract{
+				Name:       "contract",
+				Address:    contract,
+				ABI:        abi,
+				ABIContent: abiContent,
+			})
 		}
 
 		project, err := templates.NewEthereumProject(
 			projectName,
 			moduleName,
 			templates.EthereumChainsByID["ethereum_mainnet"],
-			contract,
-			abi,
-			abiContent,
+			contracts...,
 		)
 		if err != nil {
 			return fmt.Errorf("new ethereum project: %w", err)
@@ -117,19 +161,33 @@ func runSubstreamsInitE(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("render ethereum project: %w", err)
 		}
 
+		fmt.Println("Generating Protobug Rust code")
+		if err := protogenSubstreams(absoluteProjectDir); err != nil {
+			return fmt.Errorf("protobug generation: %w", err)
+		}
+
 	case codegen.ProtocolOther:
-		fmt.Println()
-		fmt.Println("We haven't added any templates for your selected chain quite yet...")
-		fmt.Println()
-		fmt.Println("Come join us in discord at https://discord.gg/u8amUbGBgF and suggest templates/chains you want to see!")
-		fmt.Println()
+		registry, err := newTemplateRegistry(cmd)
+		if err != nil {
+			return fmt.Errorf("loading project templates: %w", err)
+		}
 
-		return errInitUnsupportedChain
-	}
+		template, err := promptTemplate(registry)
+		if err != nil {
+			return fmt.Errorf("running template prompt: %w", err)
+		}
+
+		sourceType, err := promptSourceType()
+		if err != nil {
+			return fmt.Errorf("running source type prompt: %w", err)
+		}
 
-	fmt.Println("Generating Protobug Rust code")
-	if err := protogenSubstreams(absoluteProjectDir); err != nil {
-		return fmt.Errorf("protobug generation: %w", err)
+		project := templates.NewTemplateProject(template, projectName, moduleName, sourceType)
+
+		fmt.Println("Writing project files")
+		if err := renderProjectFilesIn(project, absoluteProjectDir); err != nil {
+			return fmt.Errorf("render %q project: %w", template.Name, err)
+		}
 	}
 
 	fmt.Printf("Project %q initialized at %q\n", projectName, absoluteWorkingDir)
@@ -137,6 +195,96 @@ func runSubstreamsInitE(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// ethereumContractsFromFlags returns the contracts given with `--contract`, their ABI being
+// read from the matching `--abi-file` when provided, otherwise fetched from Etherscan. With
+// several contracts, each one is named after its ABI file, or numbered when fetched.
+func ethereumContractsFromFlags(ctx context.Context, addresses []string, abiFiles []string) (out []*templates.EthereumContract, err error) {
+	names := map[string]bool{}
+	for i, rawAddress := range addresses {
+		address, err := eth.NewAddress(rawAddress)
+		if err != nil {
+			return nil, fmt.Errorf("invalid contract address %q: %w", rawAddress, err)
+		}
+
+		contract := &templates.EthereumContract{Name: "contract", Address: address}
+		if len(addresses) > 1 {
+			contract.Name = fmt.Sprintf("contract%d", i+1)
+		}
+
+		if len(abiFiles) > 0 {
+			content, err := os.ReadFile(abiFiles[i])
+			if err != nil {
+				return nil, fmt.Errorf("reading ABI file: %w", err)
+			}
+
+			contract.ABIContent = string(content)
+			contract.ABI, err = eth.ParseABIFromBytes(content)
+			if err != nil {
+				return nil, fmt.Errorf("parsing ABI file %q: %w", abiFiles[i], err)
+			}
+
+			if len(addresses) > 1 {
+				contract.Name = abiFileToContractName(abiFiles[i])
+				if names[contract.Name] {
+					return nil, fmt.Errorf("contract name %q derived from ABI file %q is already used by another contract, rename the file", contract.Name, abiFiles[i])
+				}
+				names[contract.Name] = true
+			}
+		} else {
+			contract.ABIContent, contract.ABI, err = GetContractABI(ctx, address)
+			if err != nil {
+				return nil, fmt.Errorf("getting contract %q ABI: %w", address.Pretty(), err)
+			}
+		}
+
+		out = append(out, contract)
+	}
+
+	return out, nil
+}
+
+var nonContractNameCharRegexp = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// abiFileToContractName turns an ABI file name like 'path/to/Bored-Ape.abi.json' into 'bored_ape'.
+func abiFileToContractName(abiFile string) string {
+	name := strings.ToLower(filepath.Base(abiFile))
+	name = strings.TrimSuffix(name, ".json")
+	name = strings.TrimSuffix(name, ".abi")
+	name = strings.Trim(nonContractNameCharRegexp.ReplaceAllString(name, "_"), "_")
+
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "contract_" + name
+	}
+
+	return name
+}
+
+func newTemplateRegistry(cmd *cobra.Command) (*templates.TemplateRegistry, error) {
+	registry, err := templates.NewTemplateRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dir := range mustGetStringArray(cmd, "template-dir") {
+		if err := registry.AddDirectory(dir); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, packagePath := range mustGetStringArray(cmd, "template-package") {
+		pkg, err := manifest.NewReader(packagePath).Read()
+		if err != nil {
+			return nil, fmt.Errorf("reading package %q: %w", packagePath, err)
+		}
+
+		if err := registry.AddPackage(pkg); err != nil {
+			return nil, fmt.Errorf("package %q: %w", packagePath, err)
+		}
+	}
+
+	return registry, nil
+}
+
 func protogenSubstreams(absoluteProjectDir string) error {
 	cmd := exec.Command("substreams", "protogen", `--exclude-paths="sf/substreams,google`)
 	cmd.Dir = absoluteProjectDir
@@ -274,6 +422,74 @@ func promptProtocol() (codegen.Protocol, error) {
 	return protocol, nil
 }
 
+func promptTemplate(registry *templates.TemplateRegistry) (*pbsubstreams.ProjectTemplate, error) {
+	if devInitTemplate != "" {
+		template, found := registry.Find(devInitTemplate)
+		if !found {
+			panic(fmt.Errorf("unknown template %q", devInitTemplate))
+		}
+
+		return template, nil
+	}
+
+	choice := promptui.Select{
+		Label: "Select template",
+		Items: registry.Templates(),
+		Templates: &promptui.SelectTemplates{
+			Active:   `{{ "▸" | cyan }} {{ .Name | cyan }} {{ .Description | faint }}`,
+			Inactive: `  {{ .Name }} {{ .Description | faint }}`,
+			Selected: `{{ "Template:" | faint }} {{ .Name }}`,
+		},
+		HideHelp: true,
+	}
+
+	index, _, err := choice.Run()
+	if err != nil {
+		if errors.Is(err, promptui.ErrInterrupt) {
+			// We received Ctrl-C, users wants to abort, nothing else to do, quit immediately
+			os.Exit(1)
+		}
+
+		return nil, fmt.Errorf("running template prompt: %w", err)
+	}
+
+	return registry.Templates()[index], nil
+}
+
+var protoTypeRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*(\.[a-zA-Z_][a-zA-Z0-9_]*)+$`)
+
+func promptSourceType() (string, error) {
+	if devInitSourceType != "" {
+		return devInitSourceType, nil
+	}
+
+	choice := promptui.SelectWithAdd{
+		Label:    "Select the chain's source block type",
+		Items:    templates.SourceBlockTypes,
+		AddLabel: "Other (enter its fully qualified Protobuf type)",
+		Validate: func(input string) error {
+			if !protoTypeRegexp.MatchString(input) {
+				return fmt.Errorf("invalid type: must be a fully qualified Protobuf message name like 'sf.near.type.v1.Block'")
+			}
+
+			return nil
+		},
+		HideHelp: true,
+	}
+
+	_, sourceType, err := choice.Run()
+	if err != nil {
+		if errors.Is(err, promptui.ErrInterrupt) {
+			// We received Ctrl-C, users wants to abort, nothing else to do, quit immediately
+			os.Exit(1)
+		}
+
+		return "", fmt.Errorf("running source type prompt: %w", err)
+	}
+
+	return sourceType, nil
+}
+
 type promptOptions struct {
 	Validate        promptui.ValidateFunc
 	IsConfirm       bool
@@ -9,13 +9,24 @@ import (
 	"path/filepath"
 
 	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli"
 	"github.com/streamingfast/substreams/manifest"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/tools"
 	"google.golang.org/protobuf/proto"
 )
 
 var inspectCmd = &cobra.Command{
-	Use:          "inspect <package>",
-	Short:        "Display low-level package structure",
+	Use:   "inspect <package>",
+	Short: "Display low-level package structure",
+	Long: cli.Dedent(`
+		Display low-level package structure.
+
+		With '--verify-reproducible', the package is instead rebuilt from the local manifest set with
+		'--manifest' (by default, the 'substreams.yaml' file of the current directory) and compared with
+		'<package>', ignoring signatures. The parts of the package that differ are listed when the rebuilt
+		package is not byte-identical.
+	`),
 	RunE:         runInspect,
 	Args:         cobra.ExactArgs(1),
 	SilenceUsage: true,
@@ -23,6 +34,8 @@ var inspectCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(inspectCmd)
+	inspectCmd.Flags().Bool("verify-reproducible", false, "Rebuild the package from its manifest and verify that it is byte-identical to <package>")
+	inspectCmd.Flags().String("manifest", "", "Manifest to rebuild the package from with '--verify-reproducible', defaults to the 'substreams.yaml' file of the current directory")
 }
 
 func runInspect(cmd *cobra.Command, args []string) error {
@@ -38,6 +51,10 @@ func runInspect(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("processing module graph %w", err)
 	}
 
+	if mustGetBool(cmd, "verify-reproducible") {
+		return verifyReproducible(pkg, mustGetString(cmd, "manifest"))
+	}
+
 	filename := filepath.Join(os.TempDir(), "package.spkg")
 
 	cnt, err := proto.Marshal(pkg)
@@ -56,3 +73,38 @@ func runInspect(cmd *cobra.Command, args []string) error {
 	c.Stderr = os.Stderr
 	return c.Run()
 }
+
+func verifyReproducible(pkg *pbsubstreams.Package, manifestPathRaw string) error {
+	manifestPath, err := tools.ResolveManifestFile(manifestPathRaw)
+	if err != nil {
+		return fmt.Errorf("resolving manifest: %w", err)
+	}
+
+	rebuilt, err := buildPackage(manifestPath)
+	if err != nil {
+		return fmt.Errorf("rebuilding package: %w", err)
+	}
+
+	pkg = proto.Clone(pkg).(*pbsubstreams.Package)
+	pkg.Signatures = nil
+
+	expected, err := manifest.MarshalPackage(pkg)
+	if err != nil {
+		return err
+	}
+	actual, err := manifest.MarshalPackage(rebuilt)
+	if err != nil {
+		return err
+	}
+
+	if bytes.Equal(expected, actual) {
+		fmt.Printf("Package is reproducible from %q.\n", manifestPath)
+		return nil
+	}
+
+	fmt.Printf("Package rebuilt from %q differs:\n", manifestPath)
+	for _, difference := range manifest.PackageDifferences(pkg, rebuilt) {
+		fmt.Println("  - " + difference)
+	}
+	return fmt.Errorf("package is not reproducible")
+}
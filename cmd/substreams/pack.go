@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 
@@ -13,7 +14,8 @@ import (
 	"github.com/streamingfast/cli"
 	"github.com/streamingfast/substreams/manifest"
 	"go.uber.org/zap"
-	"google.golang.org/protobuf/proto"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 )
 
 var packCmd = &cobra.Command{
@@ -23,6 +25,22 @@ var packCmd = &cobra.Command{
 		Build an .spkg out of a .yaml manifest. The manifest is optional as it will try to find a file named
 		'substreams.yaml' in current working directory if nothing entered. You may enter a directory that contains a
 		'substreams.yaml' file in place of '<manifest_file>'.
+
+		Imports written as 'name@version' are resolved through the registry set in the SUBSTREAMS_REGISTRY
+		environment variable and recorded in a 'substreams.lock' file next to the manifest. The content of
+		each imported package is verified against the hash recorded in the lockfile. Packing is the only command
+		writing the lockfile, other commands reading the imports as recorded. With '--frozen-lockfile', packing
+		leaves the lockfile untouched and fails on imports missing from it.
+
+		Packing identical sources gives byte-identical packages. The provenance of the build, that is the hashes of
+		the manifest and binaries and the versions of substreams and of the project's toolchain, is recorded in the
+		package's metadata. Use 'substreams inspect <package> --verify-reproducible' to check a package against
+		its sources.
+
+		With '--sign <key_file>', an ed25519 signature over the bytes of the package as written is attached to it,
+		verified by readers configured with a trust list of public keys through the SUBSTREAMS_TRUSTED_KEYS
+		environment variable.
+		The key file holds a PEM encoded PKCS #8 private key, as created by 'openssl genpkey -algorithm ed25519'.
 	`),
 	RunE:         runPack,
 	Args:         cobra.RangeArgs(0, 1),
@@ -39,6 +57,8 @@ func init() {
 		replaced by "-") and "<version>" is "package.version" value. You can use "{version}" which resolves
 		to "package.version".
 	`))
+	packCmd.Flags().StringArray("sign", nil, "Path to an ed25519 private key (PEM encoded PKCS #8) signing the package, can be repeated to sign with several keys")
+	packCmd.Flags().Bool("frozen-lockfile", false, "Leave the 'substreams.lock' file untouched, failing if an import written as 'name@version' is not recorded in it")
 }
 
 func runPack(cmd *cobra.Command, args []string) error {
@@ -51,19 +71,17 @@ func runPack(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("resolving manifest: %w", err)
 	}
-	manifestReader := manifest.NewReader(manifestPath)
-
-	if !manifestReader.IsLocalManifest() {
-		return fmt.Errorf(`"pack" can only be use to pack local manifest file`)
+	var readerOptions []manifest.Options
+	if !mustGetBool(cmd, "frozen-lockfile") {
+		readerOptions = append(readerOptions, manifest.WithLockfileUpdate())
+		if registry := os.Getenv(manifest.RegistryEnvVar); registry != "" {
+			readerOptions = append(readerOptions, manifest.WithRegistry(registry))
+		}
 	}
 
-	pkg, err := manifestReader.Read()
+	pkg, err := buildPackage(manifestPath, readerOptions...)
 	if err != nil {
-		return fmt.Errorf("reading manifest %q: %w", manifestPath, err)
-	}
-
-	if _, err = manifest.NewModuleGraph(pkg.Modules.Modules); err != nil {
-		return fmt.Errorf("processing module graph %w", err)
+		return err
 	}
 
 	originalOutputFile := maybeGetString(cmd, "output-file")
@@ -80,9 +98,19 @@ func runPack(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("create output directories: %w", err)
 	}
 
-	cnt, err := proto.Marshal(pkg)
+	cnt, err := manifest.MarshalPackage(pkg)
 	if err != nil {
-		return fmt.Errorf("marshalling package: %w", err)
+		return err
+	}
+
+	for _, keyFile := range mustGetStringArray(cmd, "sign") {
+		key, err := manifest.LoadSigningKey(keyFile)
+		if err != nil {
+			return err
+		}
+		if cnt, err = manifest.SignPackage(cnt, key); err != nil {
+			return fmt.Errorf("signing package: %w", err)
+		}
 	}
 
 	if err := ioutil.WriteFile(resolvedOutputFile, cnt, 0644); err != nil {
@@ -102,3 +130,56 @@ func resolveOutputFile(input string, bindings map[string]string) string {
 
 	return input
 }
+
+// buildPackage reads the local manifest at `manifestPath` into a package, recording the
+// provenance of the build in its metadata.
+func buildPackage(manifestPath string, readerOptions ...manifest.Options) (*pbsubstreams.Package, error) {
+	manifestReader := manifest.NewReader(manifestPath, readerOptions...)
+
+	if !manifestReader.IsLocalManifest() {
+		return nil, fmt.Errorf(`"pack" can only be use to pack local manifest file`)
+	}
+
+	pkg, err := manifestReader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest %q: %w", manifestPath, err)
+	}
+
+	if _, err = manifest.NewModuleGraph(pkg.Modules.Modules); err != nil {
+		return nil, fmt.Errorf("processing module graph %w", err)
+	}
+
+	provenance, err := manifest.NewBuildProvenance(manifestPath, pkg, toolchainVersions(filepath.Dir(manifestPath)))
+	if err != nil {
+		return nil, err
+	}
+	pkg.PackageMeta[0].Provenance = provenance
+
+	return pkg, nil
+}
+
+// toolchainVersions returns the version of substreams, and of the toolchain building the
+// project's binaries found next to the manifest in `projectDir`, when installed.
+func toolchainVersions(projectDir string) map[string]string {
+	out := map[string]string{
+		"substreams": rootCmd.Version,
+	}
+
+	var commands [][]string
+	if _, err := os.Stat(filepath.Join(projectDir, "Cargo.toml")); err == nil {
+		commands = append(commands, []string{"rustc", "--version"}, []string{"cargo", "--version"})
+	}
+	if _, err := os.Stat(filepath.Join(projectDir, "go.mod")); err == nil {
+		commands = append(commands, []string{"tinygo", "version"})
+	}
+
+	for _, command := range commands {
+		cnt, err := exec.Command(command[0], command[1:]...).Output()
+		if err != nil {
+			zlog.Debug("unable to get toolchain version", zap.String("tool", command[0]), zap.Error(err))
+			continue
+		}
+		out[command[0]] = strings.TrimSpace(string(cnt))
+	}
+	return out
+}
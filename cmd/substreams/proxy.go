@@ -46,8 +46,12 @@ func (cs *ConnectServer) Blocks(
 		StartCursor:                         req.Msg.StartCursor,
 		FinalBlocksOnly:                     req.Msg.FinalBlocksOnly,
 		OutputModule:                        req.Msg.OutputModule,
+		ExtraOutputModules:                  req.Msg.ExtraOutputModules,
 		Modules:                             req.Msg.Modules,
 		DebugInitialStoreSnapshotForModules: req.Msg.DebugInitialStoreSnapshotForModules,
+		OutputFilter:                        req.Msg.OutputFilter,
+		OutputFilterProtoFiles:              req.Msg.OutputFilterProtoFiles,
+		OutputBatching:                      req.Msg.OutputBatching,
 	}
 
 	if cs.Manifest != "" {
@@ -34,6 +34,9 @@ func init() {
 	runCmd.Flags().StringSlice("debug-modules-initial-snapshot", nil, "List of 'store' modules from which to print the initial data snapshot (Unavailable in Production Mode)")
 	runCmd.Flags().StringSlice("debug-modules-output", nil, "List of modules from which to print outputs, deltas and logs (Unavailable in Production Mode)")
 	runCmd.Flags().Bool("production-mode", false, "Enable Production Mode, with high-speed parallel processing")
+	runCmd.Flags().StringSlice("extra-output-modules", nil, "List of additional 'map' modules streamed along the output module, available in Production Mode too")
+	runCmd.Flags().String("output-filter", "", "A 'jq' expression evaluated by the server against the output module's output, blocks for which it yields 'null' or 'false' are not sent. Ex: '.transfers | length > 0'")
+	runCmd.Flags().Uint64("output-batch-blocks", 0, "When non-zero, cached outputs streamed in Production Mode are received in batches of up to this number of blocks")
 	runCmd.Flags().StringSliceP("params", "p", nil, "Set a parames for parameterizable modules. Can be specified multiple times. Ex: -p module1=valA -p module2=valX&valY")
 	runCmd.Flags().String("test-file", "", "runs a test file")
 	runCmd.Flags().Bool("test-verbose", false, "print out all the results")
@@ -107,6 +110,7 @@ func runRun(cmd *cobra.Command, args []string) error {
 	}
 
 	debugModulesInitialSnapshot := mustGetStringSlice(cmd, "debug-modules-initial-snapshot")
+	extraOutputModules := mustGetStringSlice(cmd, "extra-output-modules")
 
 	graph, err := manifest.NewModuleGraph(pkg.Modules.Modules)
 	if err != nil {
@@ -115,6 +119,10 @@ func runRun(cmd *cobra.Command, args []string) error {
 
 	outputModule := args[0]
 
+	if err := manifest.ValidateRequiredParams(pkg, append([]string{outputModule}, extraOutputModules...)...); err != nil {
+		return err
+	}
+
 	startBlock, readFromModule, err := readStartBlockFlag(cmd, "start-block")
 	if err != nil {
 		return fmt.Errorf("stop block: %w", err)
@@ -153,10 +161,20 @@ func runRun(cmd *cobra.Command, args []string) error {
 		FinalBlocksOnly:                     mustGetBool(cmd, "final-blocks-only"),
 		Modules:                             pkg.Modules,
 		OutputModule:                        outputModule,
+		ExtraOutputModules:                  extraOutputModules,
 		ProductionMode:                      productionMode,
 		DebugInitialStoreSnapshotForModules: debugModulesInitialSnapshot,
 	}
 
+	if outputFilter := mustGetString(cmd, "output-filter"); outputFilter != "" {
+		req.OutputFilter = outputFilter
+		req.OutputFilterProtoFiles = pkg.ProtoFiles
+	}
+
+	if outputBatchBlocks := mustGetUint64(cmd, "output-batch-blocks"); outputBatchBlocks != 0 {
+		req.OutputBatching = &pbsubstreamsrpc.OutputBatching{MaxBlocks: uint32(outputBatchBlocks)}
+	}
+
 	if err := req.Validate(); err != nil {
 		return fmt.Errorf("validate request: %w", err)
 	}
@@ -164,6 +182,7 @@ func runRun(cmd *cobra.Command, args []string) error {
 	if toPrint == nil {
 		toPrint = []string{outputModule}
 	}
+	toPrint = append(toPrint, extraOutputModules...)
 
 	ui := tui.New(req, pkg, toPrint)
 	if err := ui.Init(outputMode); err != nil {
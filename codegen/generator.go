@@ -10,6 +10,7 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/iancoleman/strcase"
 	"github.com/jhump/protoreflect/desc"
 	"github.com/streamingfast/substreams/manifest"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
@@ -250,6 +251,18 @@ func (e *Engine) GetEngine() *Engine {
 	return e
 }
 
+// CodeModules returns the manifest's modules that are implemented in Rust code, modules of
+// kind 'blocks' are applied by the server and have no code to generate.
+func (e *Engine) CodeModules() (out []*manifest.Module) {
+	for _, module := range e.Manifest.Modules {
+		if module.Kind == manifest.ModuleKindBlocks {
+			continue
+		}
+		out = append(out, module)
+	}
+	return
+}
+
 func (e *Engine) MustModule(moduleName string) *manifest.Module {
 	for _, module := range e.Manifest.Modules {
 		if module.Name == moduleName {
@@ -281,7 +294,7 @@ func (e *Engine) FunctionSignature(module *manifest.Module) (*FunctionSignature,
 }
 
 func (e *Engine) mapFunctionSignature(module *manifest.Module) (*FunctionSignature, error) {
-	inputs, err := e.ModuleArgument(module.Inputs)
+	inputs, err := e.ModuleArgument(module)
 	if err != nil {
 		return nil, fmt.Errorf("generating must module intputs: %w", err)
 	}
@@ -297,7 +310,7 @@ func (e *Engine) mapFunctionSignature(module *manifest.Module) (*FunctionSignatu
 }
 
 func (e *Engine) storeFunctionSignature(module *manifest.Module) (*FunctionSignature, error) {
-	arguments, err := e.ModuleArgument(module.Inputs)
+	arguments, err := e.ModuleArgument(module)
 	if err != nil {
 		return nil, fmt.Errorf("generating MustModule intputs: %w", err)
 	}
@@ -307,9 +320,9 @@ func (e *Engine) storeFunctionSignature(module *manifest.Module) (*FunctionSigna
 	return fn, nil
 }
 
-func (e *Engine) ModuleArgument(inputs []*manifest.Input) (Arguments, error) {
+func (e *Engine) ModuleArgument(module *manifest.Module) (Arguments, error) {
 	var out Arguments
-	for _, input := range inputs {
+	for _, input := range module.Inputs {
 		switch {
 		case input.IsMap():
 			inputType, err := e.moduleOutputForName(input.Map)
@@ -335,7 +348,18 @@ func (e *Engine) ModuleArgument(inputs []*manifest.Input) (Arguments, error) {
 
 			out = append(out, NewArgument(name, inputType, input))
 		case input.IsParams():
-			inputType := strings.Trim(input.Params, " ")
+			paramsType, err := manifest.ParseParamsType(strings.Trim(input.Params, " "))
+			if err != nil {
+				return nil, fmt.Errorf("module %q: %w", module.Name, err)
+			}
+
+			inputType := "string"
+			switch {
+			case paramsType.IsProto():
+				inputType = mustTransformProtoType(paramsType.ProtoMessage, e.Manifest)
+			case paramsType.IsQuery():
+				inputType = "crate::generated::substreams::" + paramsStructName(module)
+			}
 			out = append(out, NewArgument("params", inputType, input))
 		default:
 			return nil, fmt.Errorf("unknown MustModule kind: %T", input)
@@ -446,6 +470,104 @@ func (e *Engine) ReadableStoreDeclaration(name string, store *manifest.Module, i
 
 }
 
+// ParamsDeclaration returns the declaration of the module's `params` argument, parsed
+// according to its declared type.
+func (e *Engine) ParamsDeclaration(argument *Argument) string {
+	name := argument.Name
+	raw := fmt.Sprintf("std::mem::ManuallyDrop::new(unsafe { String::from_raw_parts(%s_ptr, %s_len, %s_len) }).to_string()", name, name, name)
+
+	paramsType, err := manifest.ParseParamsType(strings.Trim(argument.ModuleInput.Params, " "))
+	if err != nil {
+		panic(fmt.Errorf("params argument: %w", err))
+	}
+
+	switch {
+	case paramsType.IsProto():
+		return fmt.Sprintf("let %s: %s = crate::generated::substreams::decode_proto_params(&%s);", name, argument.Type, raw)
+	case paramsType.IsQuery():
+		return fmt.Sprintf("let %s: %s = %s::parse(&%s);", name, argument.Type, argument.Type, raw)
+	}
+	return fmt.Sprintf("let %s: %s = %s;", name, argument.Type, raw)
+}
+
+// ParamsStruct is the Rust struct generated for a module's `query:` typed params.
+type ParamsStruct struct {
+	Name   string
+	Fields []*ParamsStructField
+}
+
+type ParamsStructField struct {
+	Name string
+	Type string
+	// Parse is the Rust expression turning the `value` string into the field's type.
+	Parse string
+}
+
+// paramsFieldRustTypes are the Rust types of the fields of `query:` typed params, for each
+// of `manifest.ParamsValueTypes`.
+var paramsFieldRustTypes = map[string]string{
+	"string":  "String",
+	"bool":    "bool",
+	"int64":   "i64",
+	"uint64":  "u64",
+	"float64": "f64",
+	"address": "String",
+	"list":    "Vec<String>",
+	"json":    "String",
+}
+
+// ParamsStructs returns the structs of the modules with `query:` typed params.
+func (e *Engine) ParamsStructs() (out []*ParamsStruct) {
+	for _, module := range e.CodeModules() {
+		for _, input := range module.Inputs {
+			if !input.IsParams() {
+				continue
+			}
+			paramsType, err := manifest.ParseParamsType(strings.Trim(input.Params, " "))
+			if err != nil || !paramsType.IsQuery() {
+				continue
+			}
+
+			paramsStruct := &ParamsStruct{Name: paramsStructName(module)}
+			for _, field := range paramsType.Fields {
+				var parse string
+				switch field.Type {
+				case "string", "address", "json":
+					parse = "value.to_string()"
+				case "list":
+					parse = "value.split(',').map(|item| item.trim().to_string()).collect()"
+				default:
+					parse = fmt.Sprintf("value.parse().expect(\"params field '%s' must be a valid %s\")", field.Name, field.Type)
+				}
+				paramsStruct.Fields = append(paramsStruct.Fields, &ParamsStructField{
+					Name:  field.Name,
+					Type:  paramsFieldRustTypes[field.Type],
+					Parse: parse,
+				})
+			}
+			out = append(out, paramsStruct)
+		}
+	}
+	return
+}
+
+// HasProtoParams returns whether a module has `proto:` typed params, requiring the
+// generated decoding function.
+func (e *Engine) HasProtoParams() bool {
+	for _, module := range e.CodeModules() {
+		for _, input := range module.Inputs {
+			if input.IsParams() && strings.HasPrefix(strings.Trim(input.Params, " "), "proto:") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func paramsStructName(module *manifest.Module) string {
+	return strcase.ToCamel(module.Name) + "Params"
+}
+
 func mustTransformProtoType(t string, manif *manifest.Manifest) string {
 	t = strings.TrimPrefix(t, "proto:")
 
@@ -1,12 +1,15 @@
 package codegen
 
 import (
+	"bytes"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/streamingfast/substreams/manifest"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -197,3 +200,54 @@ func TestGenerate_GenerateSubstreams(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, string(expectedMod), string(out))
 }
+
+func TestGenerate_TypedParams(t *testing.T) {
+	manif := &manifest.Manifest{
+		Binaries: map[string]manifest.Binary{
+			"default": {ProtoPackageMapping: map[string]string{"my.types.v1": "pb::my_types_v1"}},
+		},
+		Modules: []*manifest.Module{
+			{
+				Name:   "map_query",
+				Kind:   manifest.ModuleKindMap,
+				Inputs: []*manifest.Input{{Params: "query:min=uint64&name=string&tags=list"}, {Source: "my.types.v1.Block"}},
+				Output: manifest.StreamOutput{Type: "proto:my.types.v1.Tests"},
+			},
+			{
+				Name:   "map_proto",
+				Kind:   manifest.ModuleKindMap,
+				Inputs: []*manifest.Input{{Params: "proto:my.types.v1.Filter"}, {Source: "my.types.v1.Block"}},
+				Output: manifest.StreamOutput{Type: "proto:my.types.v1.Tests"},
+			},
+			{
+				Name:   "map_string",
+				Kind:   manifest.ModuleKindMap,
+				Inputs: []*manifest.Input{{Params: "string"}, {Source: "my.types.v1.Block"}},
+				Output: manifest.StreamOutput{Type: "proto:my.types.v1.Tests"},
+			},
+		},
+	}
+	engine := &Engine{Manifest: manif}
+	utils["getEngine"] = engine.GetEngine
+
+	render := func(tpl string) string {
+		buf := new(bytes.Buffer)
+		require.NoError(t, generate("test", tpl, engine, "", WithTestWriter(buf)))
+		return buf.String()
+	}
+
+	substreams := render(tplSubstreams)
+	assert.Contains(t, substreams, "params: crate::generated::substreams::MapQueryParams,")
+	assert.Contains(t, substreams, "params: pb::my_types_v1::Filter,")
+	assert.Contains(t, substreams, "params: String,")
+	assert.Contains(t, substreams, "pub struct MapQueryParams {\n    pub min: u64,\n    pub name: String,\n    pub tags: Vec<String>,\n}")
+	assert.Contains(t, substreams, `"min" => out.min = value.parse().expect("params field 'min' must be a valid uint64"),`)
+	assert.Contains(t, substreams, `"name" => out.name = value.to_string(),`)
+	assert.Contains(t, substreams, `"tags" => out.tags = value.split(',').map(|item| item.trim().to_string()).collect(),`)
+	assert.Contains(t, substreams, "pub fn decode_proto_params<T: prost::Message + Default>(params: &str) -> T {")
+
+	externs := render(tplExterns)
+	assert.Contains(t, externs, "let params: crate::generated::substreams::MapQueryParams = crate::generated::substreams::MapQueryParams::parse(&std::mem::ManuallyDrop::new(unsafe { String::from_raw_parts(params_ptr, params_len, params_len) }).to_string());")
+	assert.Contains(t, externs, "let params: pb::my_types_v1::Filter = crate::generated::substreams::decode_proto_params(&std::mem::ManuallyDrop::new(unsafe { String::from_raw_parts(params_ptr, params_len, params_len) }).to_string());")
+	assert.Contains(t, externs, "let params: String = std::mem::ManuallyDrop::new(unsafe { String::from_raw_parts(params_ptr, params_len, params_len) }).to_string();")
+}
@@ -0,0 +1,521 @@
+package codegen
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/jhump/protoreflect/desc"
+	"golang.org/x/mod/modfile"
+
+	"github.com/streamingfast/substreams/manifest"
+)
+
+//go:embed templates/generator/golang/substreams.gotmpl
+var tplGoSubstreams string
+
+//go:embed templates/generator/golang/lib.gotmpl
+var tplGoLib string
+
+//go:embed templates/generator/golang/gomod.gotmpl
+var tplGoMod string
+
+//go:embed templates/generator/golang/sdk_substreams.gotmpl
+var tplGoSDKSubstreams string
+
+//go:embed templates/generator/golang/sdk_store.gotmpl
+var tplGoSDKStore string
+
+//go:embed templates/generator/golang/sdk_wire.gotmpl
+var tplGoSDKWire string
+
+// goSDKPackage is the directory, relative to the project, of the generated package
+// implementing the host interface.
+const goSDKPackage = "substreams"
+
+// GoGenerator generates, for modules written in Go and compiled with TinyGo, the package
+// implementing the host interface, the modules' entrypoints and stubs of their functions.
+type GoGenerator struct {
+	manifest         *manifest.Manifest
+	protoDefinitions []*desc.FileDescriptor
+	projectPath      string
+}
+
+func NewGoGenerator(manifest *manifest.Manifest, protoDefinitions []*desc.FileDescriptor, projectPath string) *GoGenerator {
+	return &GoGenerator{
+		manifest:         manifest,
+		protoDefinitions: protoDefinitions,
+		projectPath:      projectPath,
+	}
+}
+
+func (g *GoGenerator) Generate() error {
+	if err := os.MkdirAll(filepath.Join(g.projectPath, goSDKPackage), os.ModePerm); err != nil {
+		return fmt.Errorf("creating %s directory: %w", goSDKPackage, err)
+	}
+	fmt.Printf("Generating files in %q\n", g.projectPath)
+
+	goModFilePath := filepath.Join(g.projectPath, "go.mod")
+	if _, err := os.Stat(goModFilePath); errors.Is(err, os.ErrNotExist) {
+		modulePath := defaultGoModulePath(g.manifest, g.protoDefinitions)
+		if err := generate("go.mod", tplGoMod, modulePath, goModFilePath); err != nil {
+			return fmt.Errorf("generating go.mod: %w", err)
+		}
+		fmt.Println("Go module go.mod generated")
+	}
+
+	content, err := os.ReadFile(goModFilePath)
+	if err != nil {
+		return fmt.Errorf("reading go.mod: %w", err)
+	}
+	modulePath := modfile.ModulePath(content)
+	if modulePath == "" {
+		return fmt.Errorf("no module path found in %q", goModFilePath)
+	}
+
+	engine, err := NewGoEngine(g.manifest, g.protoDefinitions, modulePath)
+	if err != nil {
+		return err
+	}
+
+	for filename, sdk := range map[string]string{"substreams.go": tplGoSDKSubstreams, "store.go": tplGoSDKStore, "wire.go": tplGoSDKWire} {
+		if err := os.WriteFile(filepath.Join(g.projectPath, goSDKPackage, filename), []byte(sdk), 0644); err != nil {
+			return fmt.Errorf("writing %s/%s: %w", goSDKPackage, filename, err)
+		}
+	}
+	fmt.Println("Host interface package generated")
+
+	if err := generateGo("substreams", tplGoSubstreams, engine, filepath.Join(g.projectPath, "substreams_generated.go")); err != nil {
+		return fmt.Errorf("generating substreams_generated.go: %w", err)
+	}
+	fmt.Println("Modules entrypoints generated")
+
+	libFilePath := filepath.Join(g.projectPath, "lib.go")
+	if _, err := os.Stat(libFilePath); errors.Is(err, os.ErrNotExist) {
+		fmt.Printf("Generating lib.go\n")
+		if err := generateGo("lib", tplGoLib, engine, libFilePath); err != nil {
+			return fmt.Errorf("generating lib.go: %w", err)
+		}
+	} else {
+		fmt.Printf("Skipping existing lib.go\n")
+	}
+
+	return nil
+}
+
+// defaultGoModulePath returns the module path of a new project: the root of the Go packages
+// of the project's protobuf files, for the modules to import them from the project, or the
+// package name when they define none.
+func defaultGoModulePath(manif *manifest.Manifest, protoDefinitions []*desc.FileDescriptor) string {
+	roots := map[string]bool{}
+	for _, definition := range protoDefinitions {
+		if importPath := goImportPath(definition); importPath != "" && !isRemoteGoImport(importPath) {
+			roots[strings.Split(importPath, "/")[0]] = true
+		}
+	}
+	if len(roots) == 1 {
+		for root := range roots {
+			return root
+		}
+	}
+	return strings.ReplaceAll(manif.Package.Name, "_", "-")
+}
+
+// goImportPath is the import path of the Go package of a protobuf file, from its 'go_package' option.
+func goImportPath(definition *desc.FileDescriptor) string {
+	importPath := definition.GetFileOptions().GetGoPackage()
+	if i := strings.Index(importPath, ";"); i != -1 {
+		importPath = importPath[:i]
+	}
+	return importPath
+}
+
+// isRemoteGoImport tells if `importPath` belongs to a module fetched from a domain, rather
+// than to the project.
+func isRemoteGoImport(importPath string) bool {
+	return strings.Contains(strings.Split(importPath, "/")[0], ".")
+}
+
+// generateGo renders the template like `generate` does, formatting the resulting Go code.
+func generateGo(name, tpl string, data any, outputFile string) error {
+	buf := new(bytes.Buffer)
+	if err := generate(name, tpl, data, "", WithTestWriter(buf)); err != nil {
+		return err
+	}
+
+	content, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("formatting %q: %w", name, err)
+	}
+
+	return os.WriteFile(outputFile, content, 0644)
+}
+
+// goType is the Go type of a protobuf message.
+type goType struct {
+	importPath string
+	name       string
+}
+
+// GoEngine resolves the Go signatures of the manifest's modules.
+type GoEngine struct {
+	Manifest *manifest.Manifest
+
+	modulePath string
+	protoTypes map[string]goType
+	imports    map[string]string // import path to package name
+	Modules    []*GoModule
+}
+
+type GoModule struct {
+	Name     string
+	FuncName string
+	Kind     string
+
+	Arguments []*GoArgument
+
+	// OutputType is the Go type of a 'map' module's output.
+	OutputType string
+
+	// StoreType is the Go type of a 'store' module's store.
+	StoreType string
+}
+
+// GoArgument is an input of a module, received by its entrypoint as `WASMParams` and passed
+// to the module's function as `Name` after being decoded by `Decode`.
+type GoArgument struct {
+	Name       string
+	Type       string
+	WASMParams []string
+	Decode     string
+}
+
+func NewGoEngine(manif *manifest.Manifest, protoDefinitions []*desc.FileDescriptor, modulePath string) (*GoEngine, error) {
+	e := &GoEngine{
+		Manifest:   manif,
+		modulePath: modulePath,
+		protoTypes: map[string]goType{},
+		imports:    map[string]string{},
+	}
+
+	for _, definition := range protoDefinitions {
+		importPath := goImportPath(definition)
+		if importPath == "" {
+			continue
+		}
+
+		var addMessages func(prefix string, messages []*desc.MessageDescriptor)
+		addMessages = func(prefix string, messages []*desc.MessageDescriptor) {
+			for _, message := range messages {
+				name := prefix + strcase.ToCamel(message.GetName())
+				e.protoTypes[message.GetFullyQualifiedName()] = goType{importPath: importPath, name: name}
+				addMessages(name+"_", message.GetNestedMessageTypes())
+			}
+		}
+		addMessages("", definition.GetMessageTypes())
+	}
+
+	for _, module := range manif.Modules {
+		if module.Kind == manifest.ModuleKindBlocks {
+			continue
+		}
+
+		goModule, err := e.newGoModule(module)
+		if err != nil {
+			return nil, fmt.Errorf("module %q: %w", module.Name, err)
+		}
+		e.Modules = append(e.Modules, goModule)
+	}
+
+	return e, nil
+}
+
+func (e *GoEngine) newGoModule(module *manifest.Module) (*GoModule, error) {
+	out := &GoModule{
+		Name:     module.Name,
+		FuncName: strcase.ToCamel(module.Name),
+		Kind:     module.Kind,
+	}
+
+	for _, input := range module.Inputs {
+		var arg *GoArgument
+		switch {
+		case input.IsSource():
+			name := strcase.ToLowerCamel(input.Source[strings.LastIndex(input.Source, ".")+1:])
+			if input.Source == "sf.substreams.v1.Clock" {
+				arg = e.newArgument(name, "*substreams.Clock", "substreams.DecodeClock(%[1]sPtr, %[1]sLen)")
+				break
+			}
+
+			t, err := e.messageType(input.Source)
+			if err != nil {
+				return nil, err
+			}
+			arg = e.newArgument(name, "*"+t, fmt.Sprintf("substreams.DecodeInput(%q, %%[1]sPtr, %%[1]sLen, &%s{})", input.Source, t))
+		case input.IsMap():
+			outputType := e.mustModule(input.Map).Output.Type
+			if !strings.HasPrefix(outputType, "proto:") {
+				return nil, fmt.Errorf("input %q has unsupported output type %q", input.Map, outputType)
+			}
+
+			t, err := e.messageType(strings.TrimPrefix(outputType, "proto:"))
+			if err != nil {
+				return nil, err
+			}
+			arg = e.newArgument(strcase.ToLowerCamel(input.Map), "*"+t, fmt.Sprintf("substreams.DecodeInput(%q, %%[1]sPtr, %%[1]sLen, &%s{})", input.Map, t))
+		case input.IsStore() && input.Mode == "deltas":
+			arg = e.newArgument(strcase.ToLowerCamel(input.Store)+"Deltas", "substreams.Deltas", fmt.Sprintf("substreams.DecodeDeltas(%q, %%[1]sPtr, %%[1]sLen)", input.Store))
+		case input.IsStore():
+			readerType, newReader, err := e.readableStoreType(e.mustModule(input.Store))
+			if err != nil {
+				return nil, fmt.Errorf("input %q: %w", input.Store, err)
+			}
+			name := strcase.ToLowerCamel(input.Store)
+			arg = &GoArgument{
+				Name:       name,
+				Type:       "*" + readerType,
+				WASMParams: []string{name + "Index int32"},
+				Decode:     fmt.Sprintf(newReader, name+"Index"),
+			}
+		case input.IsParams():
+			arg = e.newArgument("params", "string", "string(substreams.Input(%[1]sPtr, %[1]sLen))")
+		default:
+			return nil, fmt.Errorf("unknown input %v", input)
+		}
+		out.Arguments = append(out.Arguments, arg)
+	}
+
+	switch module.Kind {
+	case manifest.ModuleKindMap:
+		if !strings.HasPrefix(module.Output.Type, "proto:") {
+			return nil, fmt.Errorf("unsupported output type %q", module.Output.Type)
+		}
+		t, err := e.messageType(strings.TrimPrefix(module.Output.Type, "proto:"))
+		if err != nil {
+			return nil, err
+		}
+		out.OutputType = "*" + t
+	case manifest.ModuleKindStore:
+		t, err := e.writableStoreType(module)
+		if err != nil {
+			return nil, err
+		}
+		out.StoreType = t
+	}
+
+	return out, nil
+}
+
+// newArgument returns an argument received as a pointer and a length, `decode` is formatted
+// with the name of the argument.
+func (e *GoEngine) newArgument(name, t, decode string) *GoArgument {
+	return &GoArgument{
+		Name:       name,
+		Type:       t,
+		WASMParams: []string{name + "Ptr int32", name + "Len int32"},
+		Decode:     fmt.Sprintf(decode, name),
+	}
+}
+
+func (e *GoEngine) mustModule(name string) *manifest.Module {
+	for _, module := range e.Manifest.Modules {
+		if module.Name == name {
+			return module
+		}
+	}
+	panic(fmt.Sprintf("module %q not found", name))
+}
+
+// messageType returns the qualified Go type of the protobuf message `fullName`, importing its package.
+func (e *GoEngine) messageType(fullName string) (string, error) {
+	t, found := e.protoTypes[fullName]
+	if !found {
+		return "", fmt.Errorf("no Go type found for message %q, its file must be part of the manifest's protobuf files and define a 'go_package' option", fullName)
+	}
+	if !isRemoteGoImport(t.importPath) && !strings.HasPrefix(t.importPath, e.modulePath+"/") {
+		return "", fmt.Errorf("the Go package %q of message %q is not part of module %q, its file's 'go_package' option must name a package of the module", t.importPath, fullName, e.modulePath)
+	}
+	return e.importPackage(t.importPath) + "." + t.name, nil
+}
+
+func (e *GoEngine) importPackage(importPath string) string {
+	if name, found := e.imports[importPath]; found {
+		return name
+	}
+
+	name := path.Base(importPath)
+	name = strings.Map(func(r rune) rune {
+		if r == '-' || r == '.' {
+			return '_'
+		}
+		return r
+	}, name)
+
+	taken := func(candidate string) bool {
+		if candidate == "substreams" || candidate == "errors" {
+			return true
+		}
+		for _, existing := range e.imports {
+			if existing == candidate {
+				return true
+			}
+		}
+		return false
+	}
+	candidate := name
+	for i := 2; taken(candidate); i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+
+	e.imports[importPath] = candidate
+	return candidate
+}
+
+// Imports returns the protobuf packages used by the modules' signatures, with the package
+// name they are referred by.
+func (e *GoEngine) Imports() (out []string) {
+	for importPath, name := range e.imports {
+		if path.Base(importPath) == name {
+			out = append(out, fmt.Sprintf("%q", importPath))
+		} else {
+			out = append(out, fmt.Sprintf("%s %q", name, importPath))
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// SDKImport is the import path of the generated package implementing the host interface.
+func (e *GoEngine) SDKImport() string {
+	return e.modulePath + "/" + goSDKPackage
+}
+
+// UsesSDK tells if the modules' signatures refer to the generated package.
+func (e *GoEngine) UsesSDK() bool {
+	for _, module := range e.Modules {
+		if module.StoreType != "" {
+			return true
+		}
+		for _, arg := range module.Arguments {
+			if strings.Contains(arg.Type, "substreams.") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var goStoreValueTypes = map[string]string{
+	"bytes":                            "Raw",
+	manifest.OutputValueTypeString:     "String",
+	manifest.OutputValueTypeInt64:      "Int64",
+	manifest.OutputValueTypeFloat64:    "Float64",
+	manifest.OutputValueTypeBigInt:     "BigInt",
+	manifest.OutputValueTypeBigDecimal: "BigDecimal",
+	manifest.OutputValueTypeBigFloat:   "BigDecimal",
+}
+
+var goStorePolicies = map[string]string{
+	manifest.UpdatePolicySet:            "Set",
+	manifest.UpdatePolicySetIfNotExists: "SetIfNotExists",
+	manifest.UpdatePolicyAdd:            "Add",
+	manifest.UpdatePolicyMin:            "Min",
+	manifest.UpdatePolicyMax:            "Max",
+}
+
+// writableStoreType returns the type of the store written by a 'store' module, matching
+// its update policy and value type.
+func (e *GoEngine) writableStoreType(module *manifest.Module) (string, error) {
+	if module.UpdatePolicy == manifest.UpdatePolicyAppend {
+		return "*substreams.StoreAppend", nil
+	}
+
+	policy, found := goStorePolicies[module.UpdatePolicy]
+	if !found {
+		return "", fmt.Errorf("unsupported update policy %q", module.UpdatePolicy)
+	}
+
+	if strings.HasPrefix(module.ValueType, "proto:") {
+		if policy != "Set" && policy != "SetIfNotExists" {
+			return "", fmt.Errorf("update policy %q does not support value type %q", module.UpdatePolicy, module.ValueType)
+		}
+		t, err := e.messageType(strings.TrimPrefix(module.ValueType, "proto:"))
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("*substreams.Store%sProto[*%s]", policy, t), nil
+	}
+
+	valueType, found := goStoreValueTypes[module.ValueType]
+	if !found {
+		return "", fmt.Errorf("unsupported value type %q", module.ValueType)
+	}
+	if (policy == "Add" || policy == "Min" || policy == "Max") && (valueType == "Raw" || valueType == "String") {
+		return "", fmt.Errorf("update policy %q does not support value type %q", module.UpdatePolicy, module.ValueType)
+	}
+
+	return fmt.Sprintf("*substreams.Store%s%s", policy, valueType), nil
+}
+
+// readableStoreType returns the type reading a store given in `get` mode and the
+// expression creating it, formatted with the variable holding the store's index.
+func (e *GoEngine) readableStoreType(store *manifest.Module) (string, string, error) {
+	if store.UpdatePolicy == manifest.UpdatePolicyAppend {
+		return "substreams.StoreGetRaw", "substreams.NewStoreGetRaw(%s)", nil
+	}
+
+	if strings.HasPrefix(store.ValueType, "proto:") {
+		t, err := e.messageType(strings.TrimPrefix(store.ValueType, "proto:"))
+		if err != nil {
+			return "", "", err
+		}
+		return fmt.Sprintf("substreams.StoreGetProto[*%s]", t), fmt.Sprintf("substreams.NewStoreGetProto(%%s, func() *%s { return &%s{} })", t, t), nil
+	}
+
+	valueType, found := goStoreValueTypes[store.ValueType]
+	if !found {
+		return "", "", fmt.Errorf("unsupported value type %q", store.ValueType)
+	}
+	return "substreams.StoreGet" + valueType, "substreams.NewStoreGet" + valueType + "(%s)", nil
+}
+
+// WASMParams returns the parameters of the module's entrypoint.
+func (m *GoModule) WASMParams() string {
+	var params []string
+	for _, arg := range m.Arguments {
+		params = append(params, arg.WASMParams...)
+	}
+	return strings.Join(params, ", ")
+}
+
+// Parameters returns the parameters of the module's function.
+func (m *GoModule) Parameters() string {
+	var params []string
+	for _, arg := range m.Arguments {
+		params = append(params, arg.Name+" "+arg.Type)
+	}
+	if m.StoreType != "" {
+		params = append(params, "store "+m.StoreType)
+	}
+	return strings.Join(params, ", ")
+}
+
+// CallArguments returns the arguments the entrypoint calls the module's function with.
+func (m *GoModule) CallArguments() string {
+	var args []string
+	for _, arg := range m.Arguments {
+		args = append(args, arg.Name)
+	}
+	if m.StoreType != "" {
+		args = append(args, "&"+strings.TrimPrefix(m.StoreType, "*")+"{}")
+	}
+	return strings.Join(args, ", ")
+}
@@ -0,0 +1,165 @@
+package codegen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/mod/semver"
+
+	"github.com/streamingfast/substreams/manifest"
+)
+
+const goSampleProject = "./templates/golang"
+
+func TestGoGenerator_SampleProjectUpToDate(t *testing.T) {
+	projectDir := t.TempDir()
+	copyFile(t, filepath.Join(goSampleProject, "go.mod"), filepath.Join(projectDir, "go.mod"))
+	copyFile(t, filepath.Join(goSampleProject, "lib.go"), filepath.Join(projectDir, "lib.go"))
+
+	manif, protoDefinitions := readGoSampleManifest(t)
+	require.NoError(t, NewGoGenerator(manif, protoDefinitions, projectDir).Generate())
+
+	for _, file := range []string{"substreams_generated.go", "substreams/substreams.go", "substreams/store.go", "substreams/wire.go", "lib.go"} {
+		assert.Equal(t, fileContent(t, filepath.Join(goSampleProject, file)), fileContent(t, filepath.Join(projectDir, file)), "file %q differs from the generated one, run 'make codegen' in %q", file, goSampleProject)
+	}
+}
+
+func TestGoGenerator_NewProject(t *testing.T) {
+	projectDir := t.TempDir()
+
+	manif, protoDefinitions := readGoSampleManifest(t)
+	require.NoError(t, NewGoGenerator(manif, protoDefinitions, projectDir).Generate())
+
+	// The module path is the one of the protobuf files' Go package
+	assert.Equal(t, "module substreams-block-stats\n\ngo 1.21\n", fileContent(t, filepath.Join(projectDir, "go.mod")))
+	assert.Equal(t, `package main
+
+import (
+	"errors"
+
+	"substreams-block-stats/pb"
+	"substreams-block-stats/substreams"
+)
+
+func MapBlockStats(clock *substreams.Clock) (*pb.BlockStats, error) {
+	return nil, errors.New("map_block_stats is not implemented")
+}
+
+func StoreBlockCount(params string, mapBlockStats *pb.BlockStats, store *substreams.StoreAddInt64) error {
+	return errors.New("store_block_count is not implemented")
+}
+
+func MapBlocksSeen(params string, mapBlockStats *pb.BlockStats, storeBlockCount *substreams.StoreGetInt64, storeBlockCountDeltas substreams.Deltas) (*pb.BlockStats, error) {
+	return nil, errors.New("map_blocks_seen is not implemented")
+}
+`, fileContent(t, filepath.Join(projectDir, "lib.go")))
+}
+
+func TestGoGenerator_NewProjectCompiles(t *testing.T) {
+	goVersion, err := exec.Command("go", "env", "GOVERSION").Output()
+	if err != nil || semver.Compare("v"+strings.TrimPrefix(strings.TrimSpace(string(goVersion)), "go"), "v1.24") < 0 {
+		t.Skip("go 1.24 or later is required to build WASM modules exporting functions")
+	}
+
+	projectDir := t.TempDir()
+	manif, protoDefinitions := readGoSampleManifest(t)
+	require.NoError(t, NewGoGenerator(manif, protoDefinitions, projectDir).Generate())
+	// The Go types of the protobuf files are generated by protoc
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, "pb"), os.ModePerm))
+	copyFile(t, filepath.Join(goSampleProject, "pb", "stats.go"), filepath.Join(projectDir, "pb", "stats.go"))
+
+	// TinyGo builds the modules run by the WASM runtime, the Go toolchain checks they compile
+	cmd := exec.Command("go", "build", "-buildmode=c-shared", "-o", filepath.Join(t.TempDir(), "module.wasm"), ".")
+	cmd.Dir = projectDir
+	cmd.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm", "GOFLAGS=-mod=mod", "GOWORK=off")
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, "Command %q in %q failed\n%s", cmd, projectDir, string(output))
+}
+
+func TestGoGenerator_ModuleMismatch(t *testing.T) {
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "go.mod"), []byte("module acme\n\ngo 1.21\n"), 0644))
+
+	manif, protoDefinitions := readGoSampleManifest(t)
+	err := NewGoGenerator(manif, protoDefinitions, projectDir).Generate()
+	require.ErrorContains(t, err, `the Go package "substreams-block-stats/pb" of message "block_stats.v1.BlockStats" is not part of module "acme"`)
+}
+
+func TestGoEngine_StoreTypes(t *testing.T) {
+	engine := &GoEngine{
+		modulePath: "acme",
+		protoTypes: map[string]goType{"acme.v1.Token": {importPath: "acme/pb", name: "Token"}},
+		imports:    map[string]string{},
+	}
+
+	tests := []struct {
+		updatePolicy string
+		valueType    string
+		writer       string
+		reader       string
+		expectError  bool
+	}{
+		{manifest.UpdatePolicySet, "bytes", "*substreams.StoreSetRaw", "substreams.StoreGetRaw", false},
+		{manifest.UpdatePolicySet, "proto:acme.v1.Token", "*substreams.StoreSetProto[*pb.Token]", "substreams.StoreGetProto[*pb.Token]", false},
+		{manifest.UpdatePolicySetIfNotExists, "string", "*substreams.StoreSetIfNotExistsString", "substreams.StoreGetString", false},
+		{manifest.UpdatePolicyAdd, "bigint", "*substreams.StoreAddBigInt", "substreams.StoreGetBigInt", false},
+		{manifest.UpdatePolicyMin, "float64", "*substreams.StoreMinFloat64", "substreams.StoreGetFloat64", false},
+		{manifest.UpdatePolicyMax, "bigdecimal", "*substreams.StoreMaxBigDecimal", "substreams.StoreGetBigDecimal", false},
+		{manifest.UpdatePolicyAppend, "bytes", "*substreams.StoreAppend", "substreams.StoreGetRaw", false},
+		{manifest.UpdatePolicyAdd, "string", "", "", true},
+		{manifest.UpdatePolicyMax, "proto:acme.v1.Token", "", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.updatePolicy+"/"+test.valueType, func(t *testing.T) {
+			module := &manifest.Module{Name: "store", Kind: manifest.ModuleKindStore, UpdatePolicy: test.updatePolicy, ValueType: test.valueType}
+
+			writer, err := engine.writableStoreType(module)
+			if test.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.writer, writer)
+
+			reader, _, err := engine.readableStoreType(module)
+			require.NoError(t, err)
+			assert.Equal(t, test.reader, reader)
+		})
+	}
+}
+
+func readGoSampleManifest(t *testing.T) (*manifest.Manifest, []*desc.FileDescriptor) {
+	t.Helper()
+
+	manifestPath := filepath.Join(goSampleProject, "substreams.yaml")
+
+	var protoDefinitions []*desc.FileDescriptor
+	_, err := manifest.NewReader(manifestPath, manifest.SkipSourceCodeReader(), manifest.WithCollectProtoDefinitions(func(pd []*desc.FileDescriptor) {
+		protoDefinitions = pd
+	})).Read()
+	require.NoError(t, err)
+
+	manif, err := manifest.LoadManifestFile(manifestPath)
+	require.NoError(t, err)
+
+	return manif, protoDefinitions
+}
+
+func copyFile(t *testing.T, from, to string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(to, []byte(fileContent(t, from)), 0644))
+}
+
+func fileContent(t *testing.T, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(content)
+}
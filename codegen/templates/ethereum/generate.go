@@ -20,13 +20,18 @@ func main() {
 
 	chain := templates.EthereumChainsByID["ethereum_mainnet"]
 
-	project, err := templates.NewEthereumProject("substreams-init-test", "substreams_init_test", chain, eth.MustNewAddress("0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d"), abi, string(abiContent))
+	project, err := templates.NewEthereumProject("substreams-init-test", "substreams_init_test", chain, &templates.EthereumContract{
+		Name:       "contract",
+		Address:    eth.MustNewAddress("0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d"),
+		ABI:        abi,
+		ABIContent: string(abiContent),
+	})
 	cli.NoError(err, "Unable to create Ethereum project")
 
 	files, err := project.Render()
 	cli.NoError(err, "Unable to render Ethereum project")
 
-	for _, fileToWrite := range []string{"proto/contract.proto", "src/lib.rs", "Cargo.toml", "substreams.yaml"} {
+	for _, fileToWrite := range []string{"proto/contract.proto", "src/lib.rs", "src/abi/mod.rs", "build.rs", "Cargo.toml", "substreams.yaml"} {
 		content, found := files[fileToWrite]
 		cli.Ensure(found, "The file %q is not rendered by Ethereum project", fileToWrite)
 
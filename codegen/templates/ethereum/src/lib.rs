@@ -2,6 +2,7 @@ mod abi;
 mod pb;
 use hex_literal::hex;
 use pb::contract::v1 as contract;
+use substreams::store::{StoreAdd, StoreAddInt64, StoreNew};
 use substreams::Hex;
 use substreams_ethereum::pb::eth::v2 as eth;
 use substreams_ethereum::Event;
@@ -105,3 +106,19 @@ fn map_events(blk: eth::Block) -> Result<contract::Events, substreams::errors::E
             .collect(),
     })
 }
+
+#[substreams::handlers::store]
+fn store_contract_event_counts(events: contract::Events, store: StoreAddInt64) {
+    if !events.approvals.is_empty() {
+        store.add(0, "Approval", events.approvals.len() as i64);
+    }
+    if !events.approval_for_alls.is_empty() {
+        store.add(0, "ApprovalForAll", events.approval_for_alls.len() as i64);
+    }
+    if !events.ownership_transferreds.is_empty() {
+        store.add(0, "OwnershipTransferred", events.ownership_transferreds.len() as i64);
+    }
+    if !events.transfers.is_empty() {
+        store.add(0, "Transfer", events.transfers.len() as i64);
+    }
+}
@@ -5,6 +5,7 @@ import (
 	"embed"
 	"fmt"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -18,7 +19,7 @@ import (
 
 //go:embed ethereum/proto
 //go:embed ethereum/src
-//go:embed ethereum/build.rs
+//go:embed ethereum/build.rs.gotmpl
 //go:embed ethereum/Cargo.lock
 //go:embed ethereum/Cargo.toml.gotmpl
 //go:embed ethereum/Makefile
@@ -26,42 +27,98 @@ import (
 //go:embed ethereum/rust-toolchain.toml
 var ethereumProject embed.FS
 
+// EthereumContract is a contract tracked by an Ethereum project, identified in the project
+// by its name which must be a valid Rust and Protobuf identifier in snake case.
+type EthereumContract struct {
+	Name       string
+	Address    eth.Address
+	ABI        *eth.ABI
+	ABIContent string
+}
+
 type EthereumProject struct {
-	name            string
-	moduleName      string
-	chain           *EthereumChain
-	contractAddress eth.Address
-	events          []codegenEvent
-	abiContent      string
+	name       string
+	moduleName string
+	chain      *EthereumChain
+	contracts  []*ethereumContractModel
+	events     []codegenEvent
+}
+
+type ethereumContractModel struct {
+	Name    string
+	Address eth.Address
+	// TrackedContract is the name of the Rust constant holding the contract's address
+	TrackedContract string
+	// StoreModuleName is the name of the store counting the contract's events
+	StoreModuleName string
+	Events          []codegenEvent
+
+	abiContent string
 }
 
-func NewEthereumProject(name string, moduleName string, chain *EthereumChain, address eth.Address, abi *eth.ABI, abiContent string) (*EthereumProject, error) {
-	// We only have one templated file so far, so we can build own model correctly
-	events, err := buildEventModels(abi)
-	if err != nil {
-		return nil, fmt.Errorf("build ABI event models: %w", err)
+// NewEthereumProject creates a project extracting the events of `contracts`. With a single
+// contract, it should be named "contract", events of several contracts are prefixed by
+// their contract's name.
+func NewEthereumProject(name string, moduleName string, chain *EthereumChain, contracts ...*EthereumContract) (*EthereumProject, error) {
+	if len(contracts) == 0 {
+		return nil, fmt.Errorf("at least one contract is required")
+	}
+
+	project := &EthereumProject{
+		name:       name,
+		moduleName: moduleName,
+		chain:      chain,
+	}
+
+	seen := map[string]bool{}
+	for _, contract := range contracts {
+		if !ethereumContractNameRegexp.MatchString(contract.Name) {
+			return nil, fmt.Errorf("invalid contract name %q: must match %s", contract.Name, ethereumContractNameRegexp)
+		}
+		if seen[contract.Name] {
+			return nil, fmt.Errorf("contract name %q is used more than once", contract.Name)
+		}
+		seen[contract.Name] = true
+
+		model := &ethereumContractModel{
+			Name:            contract.Name,
+			Address:         contract.Address,
+			TrackedContract: "TRACKED_CONTRACT",
+			StoreModuleName: "store_" + contract.Name + "_event_counts",
+			abiContent:      contract.ABIContent,
+		}
+
+		prefix := ""
+		if len(contracts) > 1 {
+			prefix = strcase.ToCamel(contract.Name)
+			model.TrackedContract = strings.ToUpper(contract.Name) + "_TRACKED_CONTRACT"
+		}
+
+		events, err := buildEventModels(contract.ABI, contract.Name, prefix, model.TrackedContract)
+		if err != nil {
+			return nil, fmt.Errorf("build ABI event models of contract %q: %w", contract.Name, err)
+		}
+		model.Events = events
+
+		project.contracts = append(project.contracts, model)
+		project.events = append(project.events, events...)
 	}
 
-	return &EthereumProject{
-		name:            name,
-		moduleName:      moduleName,
-		chain:           chain,
-		contractAddress: address,
-		events:          events,
-		abiContent:      abiContent,
-	}, nil
+	return project, nil
 }
 
+var ethereumContractNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
 func (p *EthereumProject) Render() (map[string][]byte, error) {
 	entries := map[string][]byte{}
 
 	for _, ethereumProjectEntry := range []string{
 		"proto/contract.proto.gotmpl",
-		"src/abi/mod.rs",
+		"src/abi/mod.rs.gotmpl",
 		"src/pb/contract.v1.rs",
 		"src/pb/mod.rs",
 		"src/lib.rs.gotmpl",
-		"build.rs",
+		"build.rs.gotmpl",
 		"Cargo.lock",
 		"Cargo.toml.gotmpl",
 		"Makefile",
@@ -86,7 +143,7 @@ func (p *EthereumProject) Render() (map[string][]byte, error) {
 				"name":       p.name,
 				"moduleName": p.moduleName,
 				"chain":      p.chain,
-				"address":    p.contractAddress,
+				"contracts":  p.contracts,
 				"events":     p.events,
 			}
 
@@ -104,12 +161,16 @@ func (p *EthereumProject) Render() (map[string][]byte, error) {
 		entries[finalFileName] = content
 	}
 
-	entries["abi/contract.abi.json"] = []byte(p.abiContent)
+	for _, contract := range p.contracts {
+		entries["abi/"+contract.Name+".abi.json"] = []byte(contract.abiContent)
+	}
 
 	return entries, nil
 }
 
-func buildEventModels(abi *eth.ABI) (out []codegenEvent, err error) {
+// buildEventModels builds the models of the events of the contract `contractName`, their
+// Protobuf messages and fields being prefixed by `prefix`.
+func buildEventModels(abi *eth.ABI, contractName string, prefix string, trackedContract string) (out []codegenEvent, err error) {
 	pluralizer := pluralize.NewClient()
 
 	names := keys(abi.LogEventsByNameMap)
@@ -126,17 +187,20 @@ func buildEventModels(abi *eth.ABI) (out []codegenEvent, err error) {
 				rustABIStructName = name + strconv.FormatUint(uint64(i), 10)
 			}
 
-			protoFieldName := strcase.ToSnake(pluralizer.Plural(rustABIStructName))
+			protoMessageName := prefix + rustABIStructName
+			protoFieldName := strcase.ToSnake(pluralizer.Plural(protoMessageName))
 
 			codegenEvent := codegenEvent{
 				Rust: &rustEventModel{
+					ABIModuleName:              contractName,
 					ABIStructName:              rustABIStructName,
-					ProtoMessageName:           rustABIStructName,
+					TrackedContract:            trackedContract,
+					ProtoMessageName:           protoMessageName,
 					ProtoOutputModuleFieldName: protoFieldName,
 				},
 
 				Proto: &protoEventModel{
-					MessageName:           rustABIStructName,
+					MessageName:           protoMessageName,
 					OutputModuleFieldName: protoFieldName,
 				},
 			}
@@ -162,7 +226,10 @@ type codegenEvent struct {
 }
 
 type rustEventModel struct {
+	// ABIModuleName is the name of the module generated from the contract's ABI
+	ABIModuleName              string
 	ABIStructName              string
+	TrackedContract            string
 	ProtoMessageName           string
 	ProtoOutputModuleFieldName string
 	ProtoFieldABIConversionMap map[string]string
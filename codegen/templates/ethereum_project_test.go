@@ -20,7 +20,12 @@ func TestEnsureOurProjectCompiles(t *testing.T) {
 	abi, err := eth.ParseABIFromBytes(abiContent)
 	require.NoError(t, err)
 
-	project, err := NewEthereumProject("substreams-tests", "substreams_tests", EthereumChainsByID["ethereum_mainnet"], eth.MustNewAddress("0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d"), abi, string(abiContent))
+	project, err := NewEthereumProject("substreams-tests", "substreams_tests", EthereumChainsByID["ethereum_mainnet"], &EthereumContract{
+		Name:       "contract",
+		Address:    eth.MustNewAddress("0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d"),
+		ABI:        abi,
+		ABIContent: string(abiContent),
+	})
 	require.NoError(t, err)
 
 	files, err := project.Render()
@@ -84,7 +89,12 @@ func TestNewEthereumTemplateProject(t *testing.T) {
 
 			chain := EthereumChainsByID["ethereum_mainnet"]
 
-			project, err := NewEthereumProject("substreams-init-test", "substreams_init_test", chain, eth.MustNewAddress("0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d"), abi, string(tt.args.abi))
+			project, err := NewEthereumProject("substreams-init-test", "substreams_init_test", chain, &EthereumContract{
+				Name:       "contract",
+				Address:    eth.MustNewAddress(tt.args.address),
+				ABI:        abi,
+				ABIContent: string(tt.args.abi),
+			})
 			require.NoError(t, err)
 
 			got, err := project.Render()
@@ -111,6 +121,52 @@ func TestNewEthereumTemplateProject(t *testing.T) {
 	}
 }
 
+func TestNewEthereumTemplateProject_MultipleContracts(t *testing.T) {
+	baycABIContent := fileContent(t, "ethereum/abi/contract.abi.json")
+	baycABI, err := eth.ParseABIFromBytes(baycABIContent)
+	require.NoError(t, err)
+
+	usdcABIContent := fileContent(t, "testdata/erc20.abi.json")
+	usdcABI, err := eth.ParseABIFromBytes(usdcABIContent)
+	require.NoError(t, err)
+
+	bayc := &EthereumContract{Name: "bayc", Address: eth.MustNewAddress("0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d"), ABI: baycABI, ABIContent: string(baycABIContent)}
+	usdc := &EthereumContract{Name: "usdc", Address: eth.MustNewAddress("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"), ABI: usdcABI, ABIContent: string(usdcABIContent)}
+
+	project, err := NewEthereumProject("substreams-init-test", "substreams_init_test", EthereumChainsByID["ethereum_mainnet"], bayc, usdc)
+	require.NoError(t, err)
+
+	got, err := project.Render()
+	require.NoError(t, err)
+
+	assert.Equal(t, baycABIContent, got["abi/bayc.abi.json"])
+	assert.Equal(t, usdcABIContent, got["abi/usdc.abi.json"])
+	assert.NotContains(t, got, "abi/contract.abi.json")
+
+	assert.Equal(t, "pub mod bayc;\npub mod usdc;\n", string(got["src/abi/mod.rs"]))
+	assert.Contains(t, string(got["build.rs"]), `Abigen::new("Bayc", "abi/bayc.abi.json")?`)
+	assert.Contains(t, string(got["build.rs"]), `Abigen::new("Usdc", "abi/usdc.abi.json")?`)
+
+	proto := string(got["proto/contract.proto"])
+	assert.Contains(t, proto, "repeated BaycTransfer bayc_transfers = 4;")
+	assert.Contains(t, proto, "repeated UsdcTransfer usdc_transfers = 6;")
+	assert.Contains(t, proto, "message UsdcApproval {")
+
+	lib := string(got["src/lib.rs"])
+	assert.Contains(t, lib, `const BAYC_TRACKED_CONTRACT: [u8; 20] = hex!("bc4ca0eda7647a8ab7c2061c2e118a18a936f13d");`)
+	assert.Contains(t, lib, `const USDC_TRACKED_CONTRACT: [u8; 20] = hex!("a0b86991c6218b36c1d19d4a2e9eb0ce3606eb48");`)
+	assert.Contains(t, lib, "abi::usdc::events::Transfer::match_and_decode(log)")
+	assert.Contains(t, lib, "fn store_usdc_event_counts(events: contract::Events, store: StoreAddInt64) {")
+	assert.Contains(t, lib, `store.add(0, "Transfer", events.usdc_transfers.len() as i64);`)
+
+	manifest := string(got["substreams.yaml"])
+	assert.Contains(t, manifest, "  - name: store_bayc_event_counts\n")
+	assert.Contains(t, manifest, "  - name: store_usdc_event_counts\n")
+
+	_, err = NewEthereumProject("substreams-init-test", "substreams_init_test", EthereumChainsByID["ethereum_mainnet"], bayc, bayc)
+	require.Error(t, err)
+}
+
 func fileContent(t *testing.T, path string) []byte {
 	content, err := os.ReadFile(path)
 	require.NoError(t, err)
@@ -0,0 +1,31 @@
+mod pb;
+use pb::block_stats::v1::BlockStats;
+use substreams::pb::substreams::Clock;
+use substreams::store::{StoreAdd, StoreAddInt64, StoreNew};
+
+/// The source block is received undecoded so that this module works with any chain, only
+/// its size is used along with the block's clock.
+#[no_mangle]
+pub extern "C" fn map_block_stats(
+    _block_ptr: *mut u8,
+    block_len: usize,
+    clock_ptr: *mut u8,
+    clock_len: usize,
+) {
+    substreams::register_panic_hook();
+
+    let clock: Clock = substreams::proto::decode_ptr(clock_ptr, clock_len).unwrap();
+
+    substreams::output(BlockStats {
+        id: clock.id,
+        number: clock.number,
+        timestamp: clock.timestamp.map(|t| t.seconds).unwrap_or_default(),
+        size_bytes: block_len as u64,
+    });
+}
+
+#[substreams::handlers::store]
+fn store_block_stats(stats: BlockStats, store: StoreAddInt64) {
+    store.add(0, "blocks", 1);
+    store.add(0, "bytes", stats.size_bytes as i64);
+}
@@ -0,0 +1,15 @@
+// @generated
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct BlockStats {
+    #[prost(string, tag="1")]
+    pub id: ::prost::alloc::string::String,
+    #[prost(uint64, tag="2")]
+    pub number: u64,
+    /// Timestamp of the block, in seconds since the epoch
+    #[prost(int64, tag="3")]
+    pub timestamp: i64,
+    /// Size of the encoded source block, in bytes
+    #[prost(uint64, tag="4")]
+    pub size_bytes: u64,
+}
+// @@protoc_insertion_point(module)
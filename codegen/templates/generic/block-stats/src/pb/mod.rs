@@ -0,0 +1,8 @@
+// @generated
+pub mod block_stats {
+    // @@protoc_insertion_point(attribute:block_stats.v1)
+    pub mod v1 {
+        include!("block_stats.v1.rs");
+        // @@protoc_insertion_point(block_stats.v1)
+    }
+}
@@ -0,0 +1,3 @@
+module substreams-block-stats
+
+go 1.21
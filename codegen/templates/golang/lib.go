@@ -0,0 +1,34 @@
+package main
+
+import (
+	"substreams-block-stats/pb"
+	"substreams-block-stats/substreams"
+)
+
+// MapBlockStats extracts the stats of the block out of its clock.
+func MapBlockStats(clock *substreams.Clock) (*pb.BlockStats, error) {
+	return &pb.BlockStats{
+		Id:        clock.Id,
+		Number:    clock.Number,
+		Timestamp: clock.Timestamp.Unix(),
+	}, nil
+}
+
+// StoreBlockCount counts the blocks under the key given in `params`.
+func StoreBlockCount(params string, mapBlockStats *pb.BlockStats, store *substreams.StoreAddInt64) error {
+	store.Add(mapBlockStats.Number, params, 1)
+	return nil
+}
+
+// MapBlocksSeen completes the stats of the block with the number of blocks seen so far.
+func MapBlocksSeen(params string, mapBlockStats *pb.BlockStats, storeBlockCount *substreams.StoreGetInt64, storeBlockCountDeltas substreams.Deltas) (*pb.BlockStats, error) {
+	count, found := storeBlockCount.GetLast(params)
+	if !found {
+		substreams.Logf("no count found under %q", params)
+		return mapBlockStats, nil
+	}
+
+	substreams.Logf("%d changes to the block count", len(storeBlockCountDeltas))
+	mapBlockStats.BlocksSeen = uint64(count)
+	return mapBlockStats, nil
+}
@@ -0,0 +1,93 @@
+// Package pb holds the Go types of `proto/stats.proto`. Projects usually generate them with
+// `protoc-gen-go` and `protoc-gen-go-vtproto`, this sample hand-writes the `MarshalVT` and
+// `UnmarshalVT` methods it needs to stay free of dependencies.
+package pb
+
+import (
+	"errors"
+	"fmt"
+)
+
+type BlockStats struct {
+	Id         string
+	Number     uint64
+	Timestamp  int64
+	BlocksSeen uint64
+}
+
+func (m *BlockStats) MarshalVT() ([]byte, error) {
+	var out []byte
+	if m.Id != "" {
+		out = appendVarint(append(out, 1<<3|2), uint64(len(m.Id)))
+		out = append(out, m.Id...)
+	}
+	if m.Number != 0 {
+		out = appendVarint(append(out, 2<<3), m.Number)
+	}
+	if m.Timestamp != 0 {
+		out = appendVarint(append(out, 3<<3), uint64(m.Timestamp))
+	}
+	if m.BlocksSeen != 0 {
+		out = appendVarint(append(out, 4<<3), m.BlocksSeen)
+	}
+	return out, nil
+}
+
+func (m *BlockStats) UnmarshalVT(data []byte) error {
+	*m = BlockStats{}
+	for len(data) > 0 {
+		tag, n := consumeVarint(data)
+		if n == 0 {
+			return errTruncated
+		}
+		data = data[n:]
+
+		switch tag {
+		case 1<<3 | 2:
+			length, n := consumeVarint(data)
+			if n == 0 || uint64(len(data)-n) < length {
+				return errTruncated
+			}
+			m.Id = string(data[n : n+int(length)])
+			data = data[n+int(length):]
+		case 2 << 3, 3 << 3, 4 << 3:
+			value, n := consumeVarint(data)
+			if n == 0 {
+				return errTruncated
+			}
+			data = data[n:]
+			switch tag >> 3 {
+			case 2:
+				m.Number = value
+			case 3:
+				m.Timestamp = int64(value)
+			case 4:
+				m.BlocksSeen = value
+			}
+		default:
+			return fmt.Errorf("unexpected tag %d", tag)
+		}
+	}
+	return nil
+}
+
+var errTruncated = errors.New("truncated message")
+
+func appendVarint(out []byte, value uint64) []byte {
+	for value >= 0x80 {
+		out = append(out, byte(value)|0x80)
+		value >>= 7
+	}
+	return append(out, byte(value))
+}
+
+func consumeVarint(data []byte) (uint64, int) {
+	var value uint64
+	for i := 0; i < len(data) && i < 10; i++ {
+		value |= uint64(data[i]&0x7f) << (7 * i)
+		if data[i] < 0x80 {
+			return value, i + 1
+		}
+	}
+	return 0, 0
+}
@@ -0,0 +1,66 @@
+package templates
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/wasm"
+)
+
+func TestEnsureOurGoProjectRuns(t *testing.T) {
+	if _, err := exec.LookPath("tinygo"); err != nil {
+		// CI installs it, for the modules to be run at least there
+		if os.Getenv("CI") != "" {
+			t.Fatal("tinygo is required to build the Go project")
+		}
+		t.Skip("tinygo is required to build the Go project")
+	}
+
+	projectDir, err := filepath.Abs("./golang")
+	require.NoError(t, err)
+
+	wasmFile := filepath.Join(t.TempDir(), "block_stats.wasm")
+	cmd := exec.Command("tinygo", "build", "-o", wasmFile, "-target", "wasm-unknown", "-scheduler", "none", "-gc", "leaking", "-no-debug", ".")
+	cmd.Dir = projectDir
+
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, "Command %q in %q failed with state %s\n%s", cmd, projectDir, cmd.ProcessState, string(output))
+
+	runtime := wasm.NewRuntime(nil, 0)
+	module, err := runtime.NewModule(fileContent(t, wasmFile))
+	require.NoError(t, err)
+
+	instance, err := runtime.NewInstance(context.Background(), module, "map_block_stats", "map_block_stats")
+	require.NoError(t, err)
+
+	clock := &pbsubstreams.Clock{Id: "abc", Number: 42, Timestamp: timestamppb.New(timestamppb.Now().AsTime().Truncate(1e9))}
+	clockBytes, err := proto.Marshal(clock)
+	require.NoError(t, err)
+
+	input := wasm.NewSourceInput(wasm.ClockType)
+	input.SetValue(clockBytes)
+
+	call, err := instance.NewCall(clock, []wasm.Argument{input})
+	require.NoError(t, err)
+	require.NoError(t, call.Execute())
+
+	// BlockStats{id = 1, number = 2, timestamp = 3}
+	var expected []byte
+	expected = protowire.AppendTag(expected, 1, protowire.BytesType)
+	expected = protowire.AppendString(expected, "abc")
+	expected = protowire.AppendTag(expected, 2, protowire.VarintType)
+	expected = protowire.AppendVarint(expected, 42)
+	expected = protowire.AppendTag(expected, 3, protowire.VarintType)
+	expected = protowire.AppendVarint(expected, uint64(clock.Timestamp.Seconds))
+	assert.Equal(t, expected, call.Output())
+}
@@ -1,6 +1,10 @@
 package templates
 
-import "text/template"
+import (
+	"text/template"
+
+	"github.com/iancoleman/strcase"
+)
 
 type Project interface {
 	Render() (map[string][]byte, error)
@@ -16,4 +20,5 @@ var ProjectGeneratorFuncs = template.FuncMap{
 	"add": func(left int, right int) int {
 		return left + right
 	},
+	"camel": strcase.ToCamel,
 }
@@ -0,0 +1,198 @@
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"go.uber.org/zap"
+
+	"github.com/streamingfast/substreams/manifest"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+//go:embed generic
+var genericTemplates embed.FS
+
+// SourceBlockTypes are the source block types of the chains commonly served by Substreams,
+// offered to parameterize the project templates.
+var SourceBlockTypes = []string{
+	"sf.ethereum.type.v2.Block",
+	"sf.near.type.v1.Block",
+	"sf.solana.type.v1.Block",
+	"sf.antelope.type.v1.Block",
+	"sf.arweave.type.v1.Block",
+}
+
+// TemplateRegistry holds the project templates offered by `substreams alpha init`: the built-in
+// chain-agnostic ones, and the ones discovered in local directories and packages.
+type TemplateRegistry struct {
+	templates []*pbsubstreams.ProjectTemplate
+}
+
+func NewTemplateRegistry() (*TemplateRegistry, error) {
+	r := &TemplateRegistry{}
+
+	entries, err := genericTemplates.ReadDir("generic")
+	if err != nil {
+		return nil, fmt.Errorf("embed read generic templates: %w", err)
+	}
+
+	for _, entry := range entries {
+		templateFS, err := fs.Sub(genericTemplates, "generic/"+entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("embed generic template %q: %w", entry.Name(), err)
+		}
+
+		template, err := manifest.LoadProjectTemplateFS(templateFS)
+		if err != nil {
+			return nil, fmt.Errorf("embed load generic template %q: %w", entry.Name(), err)
+		}
+		r.Add(template)
+	}
+
+	return r, nil
+}
+
+// Add registers `template`, replacing a previously registered template with the same name.
+func (r *TemplateRegistry) Add(template *pbsubstreams.ProjectTemplate) {
+	for i, existing := range r.templates {
+		if existing.Name == template.Name {
+			zlog.Debug("replacing project template", zap.String("name", template.Name))
+			r.templates[i] = template
+			return
+		}
+	}
+
+	r.templates = append(r.templates, template)
+}
+
+// AddDirectory registers the template in `dir` if it has a `template.yaml` file, otherwise
+// the templates found in its sub-directories.
+func (r *TemplateRegistry) AddDirectory(dir string) error {
+	if _, err := os.Stat(filepath.Join(dir, manifest.ProjectTemplateDescriptorFile)); err == nil {
+		template, err := manifest.LoadProjectTemplate(dir)
+		if err != nil {
+			return fmt.Errorf("load template %q: %w", dir, err)
+		}
+		r.Add(template)
+		return nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("read templates directory %q: %w", dir, err)
+	}
+
+	found := false
+	for _, entry := range entries {
+		templateDir := filepath.Join(dir, entry.Name())
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(templateDir, manifest.ProjectTemplateDescriptorFile)); err != nil {
+			continue
+		}
+
+		template, err := manifest.LoadProjectTemplate(templateDir)
+		if err != nil {
+			return fmt.Errorf("load template %q: %w", templateDir, err)
+		}
+		r.Add(template)
+		found = true
+	}
+
+	if !found {
+		return fmt.Errorf("no project template found in %q", dir)
+	}
+	return nil
+}
+
+// AddPackage registers the project templates shipped with `pkg`.
+func (r *TemplateRegistry) AddPackage(pkg *pbsubstreams.Package) error {
+	if len(pkg.ProjectTemplates) == 0 {
+		return fmt.Errorf("package has no project templates")
+	}
+
+	for _, template := range pkg.ProjectTemplates {
+		r.Add(template)
+	}
+	return nil
+}
+
+func (r *TemplateRegistry) Templates() []*pbsubstreams.ProjectTemplate {
+	return r.templates
+}
+
+func (r *TemplateRegistry) Find(name string) (*pbsubstreams.ProjectTemplate, bool) {
+	for _, template := range r.templates {
+		if template.Name == name {
+			return template, true
+		}
+	}
+	return nil, false
+}
+
+// TemplateProject is a project created from a project template, parameterized by the type
+// of the chain's source block.
+type TemplateProject struct {
+	template   *pbsubstreams.ProjectTemplate
+	name       string
+	moduleName string
+	sourceType string
+}
+
+func NewTemplateProject(template *pbsubstreams.ProjectTemplate, name string, moduleName string, sourceType string) *TemplateProject {
+	return &TemplateProject{
+		template:   template,
+		name:       name,
+		moduleName: moduleName,
+		sourceType: sourceType,
+	}
+}
+
+func (p *TemplateProject) Render() (map[string][]byte, error) {
+	entries := map[string][]byte{}
+
+	model := map[string]any{
+		"name":       p.name,
+		"moduleName": p.moduleName,
+		"sourceType": p.sourceType,
+	}
+
+	for _, file := range p.template.Files {
+		if !fs.ValidPath(file.Path) {
+			return nil, fmt.Errorf("invalid entry path %q: must be relative to the project and not contain '..'", file.Path)
+		}
+
+		finalFileName := file.Path
+		content := file.Content
+		zlog.Debug("reading template project entry", zap.String("template", p.template.Name), zap.String("filename", finalFileName))
+
+		if strings.HasSuffix(finalFileName, ".gotmpl") {
+			tmpl, err := template.New(finalFileName).Funcs(ProjectGeneratorFuncs).Parse(string(content))
+			if err != nil {
+				return nil, fmt.Errorf("parse entry template %q: %w", finalFileName, err)
+			}
+
+			zlog.Debug("rendering templated file", zap.String("filename", finalFileName), zap.Any("model", model))
+
+			buffer := bytes.NewBuffer(make([]byte, 0, uint64(float64(len(content))*1.10)))
+			if err := tmpl.Execute(buffer, model); err != nil {
+				return nil, fmt.Errorf("render entry template %q: %w", finalFileName, err)
+			}
+
+			finalFileName = strings.TrimSuffix(finalFileName, ".gotmpl")
+			content = buffer.Bytes()
+		}
+
+		entries[finalFileName] = content
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,91 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+func TestTemplateRegistry(t *testing.T) {
+	registry, err := NewTemplateRegistry()
+	require.NoError(t, err)
+
+	_, found := registry.Find("block-stats")
+	require.True(t, found)
+
+	orgDir := t.TempDir()
+	writeTemplate(t, filepath.Join(orgDir, "org-stats"), "org-stats", map[string]string{"README.md": "stats"})
+	writeTemplate(t, filepath.Join(orgDir, "block-stats"), "block-stats", map[string]string{"README.md": "replaced"})
+	require.NoError(t, os.WriteFile(filepath.Join(orgDir, "notes.txt"), []byte("not a template"), 0644))
+	require.NoError(t, registry.AddDirectory(orgDir))
+
+	singleDir := filepath.Join(t.TempDir(), "single")
+	writeTemplate(t, singleDir, "single", map[string]string{"nested/file.txt": "nested"})
+	require.NoError(t, registry.AddDirectory(singleDir))
+
+	require.Error(t, registry.AddDirectory(t.TempDir()))
+
+	require.NoError(t, registry.AddPackage(&pbsubstreams.Package{
+		ProjectTemplates: []*pbsubstreams.ProjectTemplate{{Name: "from-package"}},
+	}))
+	require.Error(t, registry.AddPackage(&pbsubstreams.Package{}))
+
+	var names []string
+	for _, template := range registry.Templates() {
+		names = append(names, template.Name)
+	}
+	assert.Equal(t, []string{"block-stats", "org-stats", "single", "from-package"}, names)
+
+	replaced, _ := registry.Find("block-stats")
+	assert.Equal(t, []*pbsubstreams.ProjectTemplateFile{{Path: "README.md", Content: []byte("replaced")}}, replaced.Files)
+
+	single, _ := registry.Find("single")
+	assert.Equal(t, []*pbsubstreams.ProjectTemplateFile{{Path: "nested/file.txt", Content: []byte("nested")}}, single.Files)
+}
+
+func TestTemplateProject_Render(t *testing.T) {
+	registry, err := NewTemplateRegistry()
+	require.NoError(t, err)
+
+	template, found := registry.Find("block-stats")
+	require.True(t, found)
+
+	files, err := NewTemplateProject(template, "near-stats", "near_stats", "sf.near.type.v1.Block").Render()
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{
+		"Cargo.toml",
+		"Makefile",
+		"proto/block_stats.proto",
+		"rust-toolchain.toml",
+		"src/lib.rs",
+		"src/pb/block_stats.v1.rs",
+		"src/pb/mod.rs",
+		"substreams.yaml",
+	}, keys(files))
+	assert.Contains(t, string(files["substreams.yaml"]), "name: near_stats\n")
+	assert.Contains(t, string(files["substreams.yaml"]), "- source: sf.near.type.v1.Block\n")
+	assert.Contains(t, string(files["Cargo.toml"]), `name = "near-stats"`)
+
+	_, err = NewTemplateProject(&pbsubstreams.ProjectTemplate{
+		Name:  "escaping",
+		Files: []*pbsubstreams.ProjectTemplateFile{{Path: "../outside.txt"}},
+	}, "near-stats", "near_stats", "sf.near.type.v1.Block").Render()
+	require.Error(t, err)
+}
+
+func writeTemplate(t *testing.T, dir string, name string, files map[string]string) {
+	t.Helper()
+
+	files["template.yaml"] = "name: " + name + "\ndescription: test template\n"
+	for file, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
@@ -0,0 +1,197 @@
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+type ModuleChangeKind string
+
+const (
+	ModuleAdded   ModuleChangeKind = "added"
+	ModuleRemoved ModuleChangeKind = "removed"
+	ModuleChanged ModuleChangeKind = "changed"
+
+	// ModuleInvalidated is a module that did not change itself, but whose hash changed
+	// because one of its ancestors did.
+	ModuleInvalidated ModuleChangeKind = "invalidated"
+)
+
+// ModuleDiff is the change of a module between two versions of a package. The hashes of
+// an added module (resp. removed module) have no old hash (resp. new hash).
+type ModuleDiff struct {
+	Name   string
+	Change ModuleChangeKind
+
+	// Reasons lists what changed in a `ModuleChanged` module: `kind`, `code`, `inputs`,
+	// `initial block` or `params`, or `other` when its hash changed for none of these
+	// reasons nor because of a changed ancestor (e.g. its ancestors listed in another order).
+	Reasons []string
+
+	// ChangedAncestors lists the changed ancestors causing the invalidation of a
+	// `ModuleInvalidated` module.
+	ChangedAncestors []string
+
+	OldHash string
+	NewHash string
+
+	// OldModule is the module in the old package, nil for an added module
+	OldModule *pbsubstreams.Module
+}
+
+// DiffPackages compares the modules of `oldPkg` and `newPkg`, returning the modules that
+// were added, removed, or whose hash changed. Modules of the new package come first,
+// ancestors before their descendants, followed by the removed ones.
+func DiffPackages(oldPkg, newPkg *pbsubstreams.Package) ([]*ModuleDiff, error) {
+	oldGraph, err := NewModuleGraph(oldPkg.Modules.Modules)
+	if err != nil {
+		return nil, fmt.Errorf("old package: %w", err)
+	}
+	newGraph, err := NewModuleGraph(newPkg.Modules.Modules)
+	if err != nil {
+		return nil, fmt.Errorf("new package: %w", err)
+	}
+
+	oldHashes := NewModuleHashes()
+	oldModules := map[string]*pbsubstreams.Module{}
+	for _, module := range oldPkg.Modules.Modules {
+		if _, err := oldHashes.HashModule(oldPkg.Modules, module, oldGraph); err != nil {
+			return nil, fmt.Errorf("old package: hashing module %q: %w", module.Name, err)
+		}
+		oldModules[module.Name] = module
+	}
+
+	sorted, ok := newGraph.TopologicalSort()
+	if !ok {
+		return nil, fmt.Errorf("new package: modules graph has a cycle")
+	}
+
+	var out []*ModuleDiff
+	changed := map[string]bool{}
+	newHashes := NewModuleHashes()
+	// Graph edges go from a module to its parents, walking the topological order backward
+	// visits ancestors first.
+	for i := len(sorted) - 1; i >= 0; i-- {
+		module := sorted[i]
+		if _, err := newHashes.HashModule(newPkg.Modules, module, newGraph); err != nil {
+			return nil, fmt.Errorf("new package: hashing module %q: %w", module.Name, err)
+		}
+
+		diff := &ModuleDiff{
+			Name:    module.Name,
+			NewHash: newHashes.Get(module.Name),
+		}
+
+		oldModule, found := oldModules[module.Name]
+		if !found {
+			diff.Change = ModuleAdded
+			changed[module.Name] = true
+			out = append(out, diff)
+			continue
+		}
+
+		diff.OldModule = oldModule
+		diff.OldHash = oldHashes.Get(module.Name)
+		if diff.OldHash == diff.NewHash {
+			continue
+		}
+
+		diff.Reasons = moduleChangeReasons(oldPkg.Modules, oldModule, newPkg.Modules, module)
+		if len(diff.Reasons) != 0 {
+			diff.Change = ModuleChanged
+			changed[module.Name] = true
+			out = append(out, diff)
+			continue
+		}
+
+		ancestors, _ := newGraph.AncestorsOf(module.Name)
+		for _, ancestor := range ancestors {
+			if changed[ancestor.Name] {
+				diff.ChangedAncestors = append(diff.ChangedAncestors, ancestor.Name)
+			}
+		}
+		if len(diff.ChangedAncestors) == 0 {
+			diff.Change = ModuleChanged
+			diff.Reasons = []string{"other"}
+			changed[module.Name] = true
+			out = append(out, diff)
+			continue
+		}
+
+		diff.Change = ModuleInvalidated
+		out = append(out, diff)
+	}
+
+	for _, module := range oldPkg.Modules.Modules {
+		if _, found := newGraph.ModuleIndexFromName(module.Name); found {
+			continue
+		}
+
+		out = append(out, &ModuleDiff{
+			Name:      module.Name,
+			Change:    ModuleRemoved,
+			OldHash:   oldHashes.Get(module.Name),
+			OldModule: module,
+		})
+	}
+
+	return out, nil
+}
+
+func moduleChangeReasons(oldModules *pbsubstreams.Modules, oldModule *pbsubstreams.Module, newModules *pbsubstreams.Modules, newModule *pbsubstreams.Module) (out []string) {
+	oldBlocks, newBlocks := oldModule.GetKindBlocks(), newModule.GetKindBlocks()
+	if !sameModuleKind(oldModule, newModule) {
+		out = append(out, "kind")
+	} else if oldBlocks != nil {
+		if !proto.Equal(oldBlocks, newBlocks) {
+			out = append(out, "code")
+		}
+	} else {
+		oldBinary, newBinary := oldModules.Binaries[oldModule.BinaryIndex], newModules.Binaries[newModule.BinaryIndex]
+		if len(oldModule.CodeHash) != 0 && len(newModule.CodeHash) != 0 {
+			if oldBinary.Type != newBinary.Type || !bytes.Equal(oldModule.CodeHash, newModule.CodeHash) {
+				out = append(out, "code")
+			}
+		} else if oldBinary.Type != newBinary.Type || !bytes.Equal(oldBinary.Content, newBinary.Content) || len(oldModule.CodeHash) != len(newModule.CodeHash) {
+			out = append(out, "code")
+		}
+	}
+
+	if oldModule.InitialBlock != newModule.InitialBlock {
+		out = append(out, "initial block")
+	}
+
+	inputsChanged := len(oldModule.Inputs) != len(newModule.Inputs)
+	paramsChanged := false
+	for i := 0; !inputsChanged && i < len(oldModule.Inputs); i++ {
+		oldParams, newParams := oldModule.Inputs[i].GetParams(), newModule.Inputs[i].GetParams()
+		if oldParams != nil && newParams != nil {
+			paramsChanged = paramsChanged || oldParams.Value != newParams.Value
+			continue
+		}
+		inputsChanged = !proto.Equal(oldModule.Inputs[i], newModule.Inputs[i])
+	}
+	if inputsChanged {
+		out = append(out, "inputs")
+	} else if paramsChanged {
+		out = append(out, "params")
+	}
+
+	return out
+}
+
+func sameModuleKind(a, b *pbsubstreams.Module) bool {
+	switch a.Kind.(type) {
+	case *pbsubstreams.Module_KindMap_:
+		return b.GetKindMap() != nil
+	case *pbsubstreams.Module_KindStore_:
+		return b.GetKindStore() != nil
+	case *pbsubstreams.Module_KindBlocks_:
+		return b.GetKindBlocks() != nil
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+func TestDiffPackages(t *testing.T) {
+	mapModule := func(name string, inputs ...*pbsubstreams.Module_Input) *pbsubstreams.Module {
+		return &pbsubstreams.Module{
+			Name:         name,
+			InitialBlock: 10,
+			Kind:         &pbsubstreams.Module_KindMap_{KindMap: &pbsubstreams.Module_KindMap{OutputType: "proto:test.Output"}},
+			Inputs:       inputs,
+		}
+	}
+	source := &pbsubstreams.Module_Input{Input: &pbsubstreams.Module_Input_Source_{Source: &pbsubstreams.Module_Input_Source{Type: "sf.test.Block"}}}
+	params := func(value string) *pbsubstreams.Module_Input {
+		return &pbsubstreams.Module_Input{Input: &pbsubstreams.Module_Input_Params_{Params: &pbsubstreams.Module_Input_Params{Value: value}}}
+	}
+	mapInput := func(name string) *pbsubstreams.Module_Input {
+		return &pbsubstreams.Module_Input{Input: &pbsubstreams.Module_Input_Map_{Map: &pbsubstreams.Module_Input_Map{ModuleName: name}}}
+	}
+
+	oldPkg := &pbsubstreams.Package{
+		Modules: &pbsubstreams.Modules{
+			Binaries: []*pbsubstreams.Binary{{Type: "wasm/rust-v1", Content: []byte("code")}},
+			Modules: []*pbsubstreams.Module{
+				mapModule("map_a", params("a=1"), source),
+				mapModule("map_b", mapInput("map_a")),
+				mapModule("map_c", mapInput("map_b")),
+				mapModule("map_unchanged", source),
+				mapModule("map_removed", source),
+			},
+		},
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		diffs, err := DiffPackages(oldPkg, oldPkg)
+		require.NoError(t, err)
+		assert.Empty(t, diffs)
+	})
+
+	t.Run("params change", func(t *testing.T) {
+		newPkg := proto.Clone(oldPkg).(*pbsubstreams.Package)
+		newPkg.Modules.Modules[0].Inputs[0] = params("a=2")
+		newPkg.Modules.Modules[2].InitialBlock = 20
+		newPkg.Modules.Modules = append(newPkg.Modules.Modules[:4], mapModule("map_added", mapInput("map_c")))
+
+		diffs, err := DiffPackages(oldPkg, newPkg)
+		require.NoError(t, err)
+
+		var summary [][]string
+		for _, diff := range diffs {
+			line := []string{diff.Name, string(diff.Change)}
+			line = append(line, diff.Reasons...)
+			line = append(line, diff.ChangedAncestors...)
+			summary = append(summary, line)
+		}
+		assert.Equal(t, [][]string{
+			{"map_a", "changed", "params"},
+			{"map_b", "invalidated", "map_a"},
+			{"map_c", "changed", "initial block"},
+			{"map_added", "added"},
+			{"map_removed", "removed"},
+		}, summary)
+
+		assert.Empty(t, diffs[3].OldHash)
+		assert.Empty(t, diffs[4].NewHash)
+		assert.NotEqual(t, diffs[1].OldHash, diffs[1].NewHash)
+	})
+
+	t.Run("unexplained change", func(t *testing.T) {
+		pkg := func(modules ...*pbsubstreams.Module) *pbsubstreams.Package {
+			return &pbsubstreams.Package{
+				Modules: &pbsubstreams.Modules{
+					Binaries: []*pbsubstreams.Binary{{Type: "wasm/rust-v1", Content: []byte("code")}},
+					Modules:  modules,
+				},
+			}
+		}
+		// The hash of map_z covers the hashes of its ancestors in the order of the package
+		oldPkg := pkg(mapModule("map_x", source), mapModule("map_y", source), mapModule("map_z", mapInput("map_x"), mapInput("map_y")))
+		newPkg := pkg(mapModule("map_y", source), mapModule("map_x", source), mapModule("map_z", mapInput("map_x"), mapInput("map_y")))
+
+		diffs, err := DiffPackages(oldPkg, newPkg)
+		require.NoError(t, err)
+		require.Len(t, diffs, 1)
+		assert.Equal(t, "map_z", diffs[0].Name)
+		assert.Equal(t, ModuleChanged, diffs[0].Change)
+		assert.Equal(t, []string{"other"}, diffs[0].Reasons)
+		assert.Empty(t, diffs[0].ChangedAncestors)
+	})
+
+	t.Run("code change", func(t *testing.T) {
+		newPkg := proto.Clone(oldPkg).(*pbsubstreams.Package)
+		newPkg.Modules.Binaries[0].Content = []byte("new code")
+
+		diffs, err := DiffPackages(oldPkg, newPkg)
+		require.NoError(t, err)
+		require.Len(t, diffs, 5)
+		for _, diff := range diffs {
+			assert.Equal(t, ModuleChanged, diff.Change, diff.Name)
+			assert.Equal(t, []string{"code"}, diff.Reasons, diff.Name)
+		}
+	})
+}
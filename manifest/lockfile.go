@@ -0,0 +1,89 @@
+package manifest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LockfileName is the file, next to the manifest, recording how its `name@version` imports
+// were resolved.
+const LockfileName = "substreams.lock"
+
+const lockfileHeader = "# Generated by substreams when resolving `name@version` imports, do not edit.\n"
+
+type Lockfile struct {
+	Imports []*LockedImport `yaml:"imports"`
+}
+
+// LockedImport is the resolution of a `name@constraint` import: the version picked in the
+// registry, the URL of its `.spkg` file and the SHA-256 hash of its content.
+type LockedImport struct {
+	Name       string `yaml:"name"`
+	Constraint string `yaml:"constraint"`
+	Version    string `yaml:"version"`
+	URL        string `yaml:"url"`
+	SHA256     string `yaml:"sha256"`
+}
+
+// LoadLockfile reads the lockfile at `path`, an empty one if it does not exist.
+func LoadLockfile(path string) (*Lockfile, error) {
+	cnt, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &Lockfile{}, nil
+		}
+		return nil, fmt.Errorf("reading lockfile %q: %w", path, err)
+	}
+
+	out := &Lockfile{}
+	decoder := yaml.NewDecoder(bytes.NewReader(cnt))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(out); err != nil {
+		return nil, fmt.Errorf("decoding lockfile %q: %w", path, err)
+	}
+	return out, nil
+}
+
+func (l *Lockfile) Find(name string, constraint string) *LockedImport {
+	for _, locked := range l.Imports {
+		if locked.Name == name && locked.Constraint == constraint {
+			return locked
+		}
+	}
+	return nil
+}
+
+// Set adds `locked`, replacing the entry resolving the same import.
+func (l *Lockfile) Set(locked *LockedImport) {
+	for i, existing := range l.Imports {
+		if existing.Name == locked.Name && existing.Constraint == locked.Constraint {
+			l.Imports[i] = locked
+			return
+		}
+	}
+	l.Imports = append(l.Imports, locked)
+}
+
+func (l *Lockfile) Save(path string) error {
+	sort.Slice(l.Imports, func(i, j int) bool {
+		if l.Imports[i].Name != l.Imports[j].Name {
+			return l.Imports[i].Name < l.Imports[j].Name
+		}
+		return l.Imports[i].Constraint < l.Imports[j].Constraint
+	})
+
+	cnt, err := yaml.Marshal(l)
+	if err != nil {
+		return fmt.Errorf("encoding lockfile: %w", err)
+	}
+
+	if err := os.WriteFile(path, append([]byte(lockfileHeader), cnt...), 0644); err != nil {
+		return fmt.Errorf("writing lockfile %q: %w", path, err)
+	}
+	return nil
+}
@@ -24,8 +24,9 @@ func init() {
 }
 
 const (
-	ModuleKindStore = "store"
-	ModuleKindMap   = "map"
+	ModuleKindStore  = "store"
+	ModuleKindMap    = "map"
+	ModuleKindBlocks = "blocks"
 )
 
 // Manifest is a YAML structure used to create a Package and its list
@@ -37,15 +38,49 @@ type Manifest struct {
 	Imports     mapSlice          `yaml:"imports"`
 	Binaries    map[string]Binary `yaml:"binaries"`
 	Modules     []*Module         `yaml:"modules"`
-	Params      map[string]string `yaml:"params"`
+	Params      map[string]*Param `yaml:"params"`
 
 	Network string `yaml:"network"`
 	Sink    *Sink  `yaml:"sink"`
 
+	// Templates are directories of project templates, see `LoadProjectTemplate`.
+	Templates []string `yaml:"templates"`
+
 	Graph   *ModuleGraph `yaml:"-"`
 	Workdir string       `yaml:"-"`
 }
 
+// Param declares a module's parameter in the top-level `params` mapping, either with its
+// default value alone, or with a mapping holding its `type`, `default`, `required` and `doc`.
+// The attributes left out keep the ones declared by the module's package, when imported.
+type Param struct {
+	Type     string `yaml:"type"`
+	Default  string `yaml:"default"`
+	Required *bool  `yaml:"required"`
+	Doc      string `yaml:"doc"`
+}
+
+func (p *Param) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind == yaml.ScalarNode {
+		p.Default = n.Value
+		return nil
+	}
+	if n.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: expected a value or a mapping with 'type', 'default', 'required' and 'doc'", n.Line)
+	}
+
+	for i := 0; i < len(n.Content); i += 2 {
+		switch key := n.Content[i].Value; key {
+		case "type", "default", "required", "doc":
+		default:
+			return fmt.Errorf("line %d: unknown param field %q, expected one of: type, default, required, doc", n.Content[i].Line, key)
+		}
+	}
+
+	type rawParam Param
+	return n.Decode((*rawParam)(p))
+}
+
 type Sink struct {
 	Type   string      `yaml:"type"`
 	Module string      `yaml:"module"`
@@ -84,6 +119,10 @@ type Module struct {
 	ValueType    string `yaml:"valueType"`
 	Binary       string `yaml:"binary"`
 
+	// For 'blocks'
+	FieldMask []string `yaml:"fieldMask"`
+	Filter    string   `yaml:"filter"`
+
 	Inputs []*Input     `yaml:"inputs"`
 	Output StreamOutput `yaml:"output"`
 }
@@ -104,6 +143,10 @@ type Binary struct {
 	Content             []byte            `yaml:"-"`
 	Entrypoint          string            `yaml:"entrypoint"`
 	ProtoPackageMapping map[string]string `yaml:"protoPackageMapping"`
+
+	// HashReachableCode makes the hash of each module using the binary cover only the code
+	// reachable from its entrypoint instead of the whole binary.
+	HashReachableCode bool `yaml:"hashReachableCode"`
 }
 
 type StreamOutput struct {
@@ -159,8 +202,8 @@ func (i *Input) parse() error {
 		return nil
 	}
 	if i.IsParams() {
-		if i.Params != "string" {
-			return fmt.Errorf("input 'params': 'string' is the only acceptable value here; specify the parameter's value under the top-level 'params' mapping")
+		if _, err := ParseParamsType(i.Params); err != nil {
+			return fmt.Errorf("input 'params': %w; specify the parameter's value under the top-level 'params' mapping", err)
 		}
 		return nil
 	}
@@ -231,6 +274,34 @@ func validateStoreBuilder(module *Module) error {
 	return nil
 }
 
+func validateBlocksTransform(module *Module) error {
+	if len(module.Inputs) != 1 || !module.Inputs[0].IsSource() {
+		return errors.New("kind 'blocks' requires exactly one 'source' input")
+	}
+	if module.UpdatePolicy != "" || module.ValueType != "" {
+		return errors.New("'updatePolicy' and 'valueType' are not supported for kind 'blocks'")
+	}
+	if module.Binary != "" {
+		return errors.New("'binary' is not supported for kind 'blocks', the transform is applied by the server")
+	}
+
+	sourceType := "proto:" + module.Inputs[0].Source
+	if module.Output.Type == "" {
+		module.Output.Type = sourceType
+	}
+	if module.Output.Type != sourceType {
+		return fmt.Errorf("'output.type' must be the source type %q for kind 'blocks', got %q", sourceType, module.Output.Type)
+	}
+
+	for _, path := range module.FieldMask {
+		if path == "" || strings.HasPrefix(path, ".") || strings.HasSuffix(path, ".") || strings.Contains(path, "..") {
+			return fmt.Errorf("invalid 'fieldMask' path %q", path)
+		}
+	}
+
+	return nil
+}
+
 func (m *Module) String() string {
 	return m.Name
 }
@@ -257,6 +328,28 @@ func (m *Module) ToProtoWASM(codeIndex uint32) (*pbsubstreams.Module, error) {
 	return out, nil
 }
 
+// ToProtoBlocks converts a module of kind 'blocks' to its Protobuf representation. Such
+// modules have no code attached to them, the transform being applied by the server.
+func (m *Module) ToProtoBlocks() (*pbsubstreams.Module, error) {
+	out := &pbsubstreams.Module{
+		Name: m.Name,
+	}
+
+	out.InitialBlock = UNSET
+	if m.InitialBlock != nil {
+		out.InitialBlock = *m.InitialBlock
+	}
+
+	m.setOutputToProto(out)
+	m.setKindToProto(out)
+	err := m.setInputsToProto(out)
+	if err != nil {
+		return nil, fmt.Errorf("setting input for module, %s: %w", m.Name, err)
+	}
+
+	return out, nil
+}
+
 func (m *Module) setInputsToProto(pbModule *pbsubstreams.Module) error {
 	for i, input := range m.Inputs {
 		if input.Source != "" {
@@ -311,10 +404,16 @@ func (m *Module) setInputsToProto(pbModule *pbsubstreams.Module) error {
 				return fmt.Errorf("input.params must be the first input")
 			}
 
+			paramsType, err := ParseParamsType(input.Params)
+			if err != nil {
+				return err
+			}
+
 			pbInput := &pbsubstreams.Module_Input{
 				Input: &pbsubstreams.Module_Input_Params_{
 					Params: &pbsubstreams.Module_Input_Params{
 						Value: "",
+						Type:  paramsType.String(),
 					},
 				},
 			}
@@ -356,6 +455,13 @@ func (m *Module) setKindToProto(pbModule *pbsubstreams.Module) {
 				OutputType: m.Output.Type,
 			},
 		}
+	case ModuleKindBlocks:
+		pbModule.Kind = &pbsubstreams.Module_KindBlocks_{
+			KindBlocks: &pbsubstreams.Module_KindBlocks{
+				FieldMask: m.FieldMask,
+				Filter:    m.Filter,
+			},
+		}
 	case ModuleKindStore:
 		var updatePolicy pbsubstreams.Module_KindStore_UpdatePolicy
 		switch m.UpdatePolicy {
@@ -51,6 +51,8 @@ func generateMermaidGraph(mods *pbsubstreams.Modules) string {
 			str.WriteString(fmt.Sprintf("  %s[map: %s];\n", s.Name, s.Name))
 		case *pbsubstreams.Module_KindStore_:
 			str.WriteString(fmt.Sprintf("  %s[store: %s];\n", s.Name, s.Name))
+		case *pbsubstreams.Module_KindBlocks_:
+			str.WriteString(fmt.Sprintf("  %s[blocks: %s];\n", s.Name, s.Name))
 		}
 
 		for _, in := range s.Inputs {
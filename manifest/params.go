@@ -1,15 +1,301 @@
 package manifest
 
 import (
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/dynamic"
 	"github.com/schollz/closestmatch"
 
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 )
 
+// ParamsValueTypes are the types of the params values, used as the whole type of a
+// module's params or as the type of the fields of a `query:` params type:
+//
+//   - `string`: free-form text.
+//   - `bool`: `true` or `false`.
+//   - `int64`, `uint64` and `float64`: numbers in decimal notation.
+//   - `address`: 20 bytes in hex, optionally prefixed by `0x`.
+//   - `list`: comma-separated values, none of them empty.
+//   - `json`: a JSON document.
+var ParamsValueTypes = []string{"string", "bool", "int64", "uint64", "float64", "address", "list", "json"}
+
+var paramsFieldNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+var paramsAddressRegexp = regexp.MustCompile(`^(0x)?[0-9a-fA-F]{40}$`)
+
+// ParamsType is the declared type of a module's `params` input, one of:
+//
+//   - one of `ParamsValueTypes`, `string` being the default: the value is validated, and
+//     handed as-is to the module.
+//   - `proto:<message>`: the value is given as the JSON representation of the message,
+//     and handed to the module in its binary form, hex-encoded.
+//   - `query:<field>=<type>&...`: the value is a query string like `min=10&name=abc` where
+//     each field is optional, appears at most once and has one of `ParamsValueTypes`. The
+//     values of the fields cannot contain `&`.
+type ParamsType struct {
+	ProtoMessage string
+	Fields       []*ParamsField
+	Scalar       string
+}
+
+type ParamsField struct {
+	Name string
+	Type string
+}
+
+func ParseParamsType(in string) (*ParamsType, error) {
+	switch {
+	case in == "" || in == "string":
+		return &ParamsType{}, nil
+
+	case isParamsValueType(in):
+		return &ParamsType{Scalar: in}, nil
+
+	case strings.HasPrefix(in, "proto:"):
+		msg := strings.TrimPrefix(in, "proto:")
+		if msg == "" {
+			return nil, fmt.Errorf("invalid params type %q: missing protobuf message name", in)
+		}
+		return &ParamsType{ProtoMessage: msg}, nil
+
+	case strings.HasPrefix(in, "query:"):
+		t := &ParamsType{}
+		seen := map[string]bool{}
+		for _, part := range strings.Split(strings.TrimPrefix(in, "query:"), "&") {
+			name, fieldType, found := strings.Cut(part, "=")
+			if !found {
+				return nil, fmt.Errorf("invalid params type %q: field %q must be of the format <name>=<type>", in, part)
+			}
+			if !paramsFieldNameRegexp.MatchString(name) {
+				return nil, fmt.Errorf("invalid params type %q: field name %q must match %s", in, name, paramsFieldNameRegexp.String())
+			}
+			if !isParamsValueType(fieldType) {
+				return nil, fmt.Errorf("invalid params type %q: field %q has unknown type %q, must be one of: %s", in, name, fieldType, strings.Join(ParamsValueTypes, ", "))
+			}
+			if seen[name] {
+				return nil, fmt.Errorf("invalid params type %q: duplicate field %q", in, name)
+			}
+			seen[name] = true
+			t.Fields = append(t.Fields, &ParamsField{Name: name, Type: fieldType})
+		}
+		sort.Slice(t.Fields, func(i, j int) bool { return t.Fields[i].Name < t.Fields[j].Name })
+		return t, nil
+	}
+
+	return nil, fmt.Errorf("invalid params type %q: must be one of %s, 'proto:<message>' or 'query:<field>=<type>&...'", in, "'"+strings.Join(ParamsValueTypes, "', '")+"'")
+}
+
+func isParamsValueType(in string) bool {
+	for _, t := range ParamsValueTypes {
+		if t == in {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *ParamsType) IsString() bool {
+	return t.ProtoMessage == "" && len(t.Fields) == 0 && t.Scalar == ""
+}
+
+func (t *ParamsType) IsProto() bool {
+	return t.ProtoMessage != ""
+}
+
+func (t *ParamsType) IsQuery() bool {
+	return len(t.Fields) != 0
+}
+
+// String returns the canonical form of the type, as stored in the package. Free-form
+// text is represented by the empty string.
+func (t *ParamsType) String() string {
+	switch {
+	case t.IsProto():
+		return "proto:" + t.ProtoMessage
+	case t.IsQuery():
+		var fields []string
+		for _, f := range t.Fields {
+			fields = append(fields, f.Name+"="+f.Type)
+		}
+		return "query:" + strings.Join(fields, "&")
+	}
+	return t.Scalar
+}
+
+// Encode validates `value` against the type and returns the form handed to the module.
+func (t *ParamsType) Encode(value string, protoFiles []*desc.FileDescriptor) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	switch {
+	case t.IsProto():
+		var msgDesc *desc.MessageDescriptor
+		for _, file := range protoFiles {
+			if msgDesc = file.FindMessage(t.ProtoMessage); msgDesc != nil {
+				break
+			}
+		}
+		if msgDesc == nil {
+			return "", fmt.Errorf("could not find protobuf message type %q in bundled protobuf descriptors", t.ProtoMessage)
+		}
+
+		msg := dynamic.NewMessageFactoryWithDefaults().NewDynamicMessage(msgDesc)
+		if err := msg.UnmarshalJSON([]byte(value)); err != nil {
+			return "", fmt.Errorf("decoding json into %q: %w", t.ProtoMessage, err)
+		}
+		cnt, err := msg.Marshal()
+		if err != nil {
+			return "", fmt.Errorf("encoding %q: %w", t.ProtoMessage, err)
+		}
+		return hex.EncodeToString(cnt), nil
+
+	case t.IsQuery():
+		seen := map[string]bool{}
+		for _, pair := range strings.Split(value, "&") {
+			if pair == "" {
+				continue
+			}
+			name, fieldValue, found := strings.Cut(pair, "=")
+			if !found {
+				return "", fmt.Errorf("field %q must be of the format <name>=<value>", pair)
+			}
+			if seen[name] {
+				return "", fmt.Errorf("field %q given more than once", name)
+			}
+			seen[name] = true
+
+			field := t.field(name)
+			if field == nil {
+				return "", fmt.Errorf("unknown field %q, expected one of: %s", name, strings.Join(t.fieldNames(), ", "))
+			}
+			if err := validateParamsValue(field.Type, fieldValue); err != nil {
+				return "", fmt.Errorf("field %q: %w", name, err)
+			}
+		}
+		return value, nil
+
+	case t.Scalar != "":
+		if err := validateParamsValue(t.Scalar, value); err != nil {
+			return "", err
+		}
+	}
+
+	return value, nil
+}
+
+func (t *ParamsType) field(name string) *ParamsField {
+	for _, f := range t.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+func (t *ParamsType) fieldNames() (out []string) {
+	for _, f := range t.Fields {
+		out = append(out, f.Name)
+	}
+	return
+}
+
+// validateParamsValue checks that `value` is of `valueType`, one of `ParamsValueTypes`. The
+// numbers and booleans accepted are the ones parsed by the generated Rust code.
+func validateParamsValue(valueType string, value string) (err error) {
+	switch valueType {
+	case "bool":
+		if value != "true" && value != "false" {
+			return fmt.Errorf("invalid bool %q, must be 'true' or 'false'", value)
+		}
+	case "int64":
+		_, err = strconv.ParseInt(value, 10, 64)
+	case "uint64":
+		_, err = strconv.ParseUint(value, 10, 64)
+	case "float64":
+		_, err = strconv.ParseFloat(value, 64)
+	case "address":
+		if !paramsAddressRegexp.MatchString(value) {
+			return fmt.Errorf("invalid address %q, must be 20 bytes in hex, optionally prefixed by '0x'", value)
+		}
+	case "list":
+		for i, item := range strings.Split(value, ",") {
+			if strings.TrimSpace(item) == "" {
+				return fmt.Errorf("invalid list %q: item #%d is empty", value, i+1)
+			}
+		}
+	case "json":
+		if !json.Valid([]byte(value)) {
+			return fmt.Errorf("invalid json %q", value)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s %q", valueType, value)
+	}
+	return nil
+}
+
+// setParamsValue validates `value` against the declared type of the `params` input of `mod`
+// and stores its encoded form.
+func setParamsValue(mod *pbsubstreams.Module, value string, protoFiles func() ([]*desc.FileDescriptor, error)) error {
+	if len(mod.Inputs) == 0 {
+		return fmt.Errorf("missing 'params' module input")
+	}
+	p := mod.Inputs[0].GetParams()
+	if p == nil {
+		return fmt.Errorf("first module input is not 'params'")
+	}
+
+	paramsType, err := ParseParamsType(p.Type)
+	if err != nil {
+		return err
+	}
+
+	var files []*desc.FileDescriptor
+	if paramsType.IsProto() {
+		if files, err = protoFiles(); err != nil {
+			return err
+		}
+	}
+
+	encoded, err := paramsType.Encode(value, files)
+	if err != nil {
+		return fmt.Errorf("invalid value for params type %q: %w", p.Type, err)
+	}
+	p.Value = encoded
+	return nil
+}
+
+// packageProtoFiles lazily builds the file descriptors of `pkg`, only needed to encode
+// proto-typed params.
+func packageProtoFiles(pkg *pbsubstreams.Package) func() ([]*desc.FileDescriptor, error) {
+	var files []*desc.FileDescriptor
+	return func() ([]*desc.FileDescriptor, error) {
+		if files != nil {
+			return files, nil
+		}
+		out, err := desc.CreateFileDescriptors(pkg.ProtoFiles)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create file descriptors: %w", err)
+		}
+		files = make([]*desc.FileDescriptor, 0, len(out))
+		for _, file := range out {
+			files = append(files, file)
+		}
+		return files, nil
+	}
+}
+
 func ApplyParams(paramsString []string, pkg *pbsubstreams.Package) error {
+	protoFiles := packageProtoFiles(pkg)
 	for _, param := range paramsString {
 		parts := strings.SplitN(param, "=", 2)
 		if len(parts) != 2 {
@@ -20,14 +306,9 @@ func ApplyParams(paramsString []string, pkg *pbsubstreams.Package) error {
 		for _, mod := range pkg.Modules.Modules {
 			closest = append(closest, mod.Name)
 			if mod.Name == parts[0] {
-				if len(mod.Inputs) == 0 {
-					return fmt.Errorf("param for module %q: missing 'params' module input", mod.Name)
+				if err := setParamsValue(mod, parts[1], protoFiles); err != nil {
+					return fmt.Errorf("param for module %q: %w", mod.Name, err)
 				}
-				p := mod.Inputs[0].GetParams()
-				if p == nil {
-					return fmt.Errorf("param for module %q: first module input is not 'params'", mod.Name)
-				}
-				p.Value = parts[1]
 				found = true
 			}
 		}
@@ -38,3 +319,33 @@ func ApplyParams(paramsString []string, pkg *pbsubstreams.Package) error {
 	}
 	return nil
 }
+
+// ValidateRequiredParams checks that the required params of `outputModules` and of the
+// modules they depend on have a value, once the manifest's defaults and the values given
+// by the user are applied.
+func ValidateRequiredParams(pkg *pbsubstreams.Package, outputModules ...string) error {
+	graph, err := NewModuleGraph(pkg.Modules.Modules)
+	if err != nil {
+		return fmt.Errorf("creating module graph: %w", err)
+	}
+
+	seen := map[string]bool{}
+	for _, outputModule := range outputModules {
+		modules, err := graph.ModulesDownTo(outputModule)
+		if err != nil {
+			return err
+		}
+
+		for _, mod := range modules {
+			if seen[mod.Name] || len(mod.Inputs) == 0 {
+				continue
+			}
+			seen[mod.Name] = true
+
+			if p := mod.Inputs[0].GetParams(); p != nil && p.Required && p.Value == "" {
+				return fmt.Errorf("module %q: missing value for required params, provide it with '-p %s=<value>'", mod.Name, mod.Name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+package manifest
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+func TestParseParamsType(t *testing.T) {
+	tests := []struct {
+		in          string
+		expected    string
+		expectedErr string
+	}{
+		{"string", "", ""},
+		{"proto:sf.substreams.v1.Clock", "proto:sf.substreams.v1.Clock", ""},
+		{"query:min=uint64&address=string", "query:address=string&min=uint64", ""},
+		{"query:enabled=bool&delta=int64&ratio=float64", "query:delta=int64&enabled=bool&ratio=float64", ""},
+		{"proto:", "", `invalid params type "proto:": missing protobuf message name`},
+		{"query:min", "", `invalid params type "query:min": field "min" must be of the format <name>=<type>`},
+		{"query:Min=uint64", "", `invalid params type "query:Min=uint64": field name "Min" must match ^[a-z][a-z0-9_]{0,63}$`},
+		{"query:min=u32", "", `invalid params type "query:min=u32": field "min" has unknown type "u32", must be one of: string, bool, int64, uint64, float64, address, list, json`},
+		{"query:to=address&tags=list", "query:tags=list&to=address", ""},
+		{"query:min=uint64&min=int64", "", `invalid params type "query:min=uint64&min=int64": duplicate field "min"`},
+		{"int64", "int64", ""},
+		{"bool", "bool", ""},
+		{"address", "address", ""},
+		{"list", "list", ""},
+		{"json", "json", ""},
+		{"bytes", "", `invalid params type "bytes": must be one of 'string', 'bool', 'int64', 'uint64', 'float64', 'address', 'list', 'json', 'proto:<message>' or 'query:<field>=<type>&...'`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			paramsType, err := ParseParamsType(test.in)
+			if test.expectedErr != "" {
+				require.EqualError(t, err, test.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, paramsType.String())
+		})
+	}
+}
+
+func TestParamsType_Encode_Query(t *testing.T) {
+	paramsType, err := ParseParamsType("query:min=uint64&delta=int64&enabled=bool&ratio=float64&name=string")
+	require.NoError(t, err)
+
+	tests := []struct {
+		value       string
+		expectedErr string
+	}{
+		{"", ""},
+		{"min=10&name=a b&enabled=true", ""},
+		{"delta=-3&ratio=0.5&name=", ""},
+		{"min=-1", `field "min": invalid uint64 "-1"`},
+		{"enabled=1", `field "enabled": invalid bool "1", must be 'true' or 'false'`},
+		{"min=1&min=2", `field "min" given more than once`},
+		{"max=1", `unknown field "max", expected one of: delta, enabled, min, name, ratio`},
+		{"min", `field "min" must be of the format <name>=<value>`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			encoded, err := paramsType.Encode(test.value, nil)
+			if test.expectedErr != "" {
+				require.EqualError(t, err, test.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.value, encoded)
+		})
+	}
+}
+
+func TestParamsType_Encode_Scalar(t *testing.T) {
+	tests := []struct {
+		paramsType  string
+		value       string
+		expectedErr string
+	}{
+		{"int64", "-42", ""},
+		{"int64", "4.2", `invalid int64 "4.2"`},
+		{"bool", "yes", `invalid bool "yes", must be 'true' or 'false'`},
+		{"address", "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", ""},
+		{"address", "A0B86991C6218B36C1D19D4A2E9EB0CE3606EB48", ""},
+		{"address", "0xa0b8", `invalid address "0xa0b8", must be 20 bytes in hex, optionally prefixed by '0x'`},
+		{"list", "a,b, c", ""},
+		{"list", "a,,c", `invalid list "a,,c": item #2 is empty`},
+		{"json", `{"a": [1, 2]}`, ""},
+		{"json", `{"a":`, `invalid json "{\"a\":"`},
+		{"int64", "", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.paramsType+"/"+test.value, func(t *testing.T) {
+			paramsType, err := ParseParamsType(test.paramsType)
+			require.NoError(t, err)
+
+			encoded, err := paramsType.Encode(test.value, nil)
+			if test.expectedErr != "" {
+				require.EqualError(t, err, test.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.value, encoded)
+		})
+	}
+}
+
+func TestParam_UnmarshalYAML(t *testing.T) {
+	var params map[string]*Param
+	require.NoError(t, yaml.Unmarshal([]byte(`
+map_a: some value
+map_b:
+  type: int64
+  default: 10
+  doc: Minimum value
+map_c:
+  type: address
+  required: true
+`), &params))
+
+	assert.Equal(t, &Param{Default: "some value"}, params["map_a"])
+	required := true
+	assert.Equal(t, &Param{Type: "int64", Default: "10", Doc: "Minimum value"}, params["map_b"])
+	assert.Equal(t, &Param{Type: "address", Required: &required}, params["map_c"])
+
+	err := yaml.Unmarshal([]byte("map_a:\n  typ: int\n"), &params)
+	assert.EqualError(t, err, `line 2: unknown param field "typ", expected one of: type, default, required, doc`)
+}
+
+func TestValidateRequiredParams(t *testing.T) {
+	requiredInput := paramsInput("int64")
+	requiredInput.GetParams().Required = true
+
+	pkg := &pbsubstreams.Package{
+		Modules: &pbsubstreams.Modules{
+			Modules: []*pbsubstreams.Module{
+				{Name: "map_a", Kind: &pbsubstreams.Module_KindMap_{KindMap: &pbsubstreams.Module_KindMap{}}, Inputs: []*pbsubstreams.Module_Input{requiredInput, sourceInput()}},
+				{Name: "map_b", Kind: &pbsubstreams.Module_KindMap_{KindMap: &pbsubstreams.Module_KindMap{}}, Inputs: []*pbsubstreams.Module_Input{mapInput("map_a")}},
+				{Name: "map_c", Kind: &pbsubstreams.Module_KindMap_{KindMap: &pbsubstreams.Module_KindMap{}}, Inputs: []*pbsubstreams.Module_Input{sourceInput()}},
+			},
+		},
+	}
+
+	require.NoError(t, ValidateRequiredParams(pkg, "map_c"))
+	require.EqualError(t, ValidateRequiredParams(pkg, "map_c", "map_b"), `module "map_a": missing value for required params, provide it with '-p map_a=<value>'`)
+
+	require.NoError(t, ApplyParams([]string{"map_a=10"}, pkg))
+	require.NoError(t, ValidateRequiredParams(pkg, "map_b"))
+}
+
+func TestApplyParams(t *testing.T) {
+	newPkg := func() *pbsubstreams.Package {
+		return &pbsubstreams.Package{
+			ProtoFiles: readSystemProtoDescriptors(t),
+			Modules: &pbsubstreams.Modules{
+				Modules: []*pbsubstreams.Module{
+					{Name: "map_text", Inputs: []*pbsubstreams.Module_Input{paramsInput("")}},
+					{Name: "map_query", Inputs: []*pbsubstreams.Module_Input{paramsInput("query:min=uint64")}},
+					{Name: "map_proto", Inputs: []*pbsubstreams.Module_Input{paramsInput("proto:sf.substreams.v1.Clock")}},
+					{Name: "map_none"},
+				},
+			},
+		}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		pkg := newPkg()
+		require.NoError(t, ApplyParams([]string{"map_text=any=thing", "map_query=min=10", `map_proto={"id":"abc","number":"12"}`}, pkg))
+
+		assert.Equal(t, "any=thing", pkg.Modules.Modules[0].Inputs[0].GetParams().Value)
+		assert.Equal(t, "min=10", pkg.Modules.Modules[1].Inputs[0].GetParams().Value)
+
+		cnt, err := hex.DecodeString(pkg.Modules.Modules[2].Inputs[0].GetParams().Value)
+		require.NoError(t, err)
+		clock := &pbsubstreams.Clock{}
+		require.NoError(t, proto.Unmarshal(cnt, clock))
+		assert.Equal(t, "abc", clock.Id)
+		assert.Equal(t, uint64(12), clock.Number)
+	})
+
+	tests := []struct {
+		param       string
+		expectedErr string
+	}{
+		{"map_query=min=abc", `param for module "map_query": invalid value for params type "query:min=uint64": field "min": invalid uint64 "abc"`},
+		{"map_proto=min=10", `param for module "map_proto": invalid value for params type "proto:sf.substreams.v1.Clock": decoding json into "sf.substreams.v1.Clock"`},
+		{"map_none=10", `param for module "map_none": missing 'params' module input`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.param, func(t *testing.T) {
+			err := ApplyParams([]string{test.param}, newPkg())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.expectedErr)
+		})
+	}
+}
+
+func paramsInput(paramsType string) *pbsubstreams.Module_Input {
+	return &pbsubstreams.Module_Input{
+		Input: &pbsubstreams.Module_Input_Params_{Params: &pbsubstreams.Module_Input_Params{Type: paramsType}},
+	}
+}
+
+func sourceInput() *pbsubstreams.Module_Input {
+	return &pbsubstreams.Module_Input{
+		Input: &pbsubstreams.Module_Input_Source_{Source: &pbsubstreams.Module_Input_Source{Type: "sf.substreams.v1.Clock"}},
+	}
+}
+
+func mapInput(moduleName string) *pbsubstreams.Module_Input {
+	return &pbsubstreams.Module_Input{
+		Input: &pbsubstreams.Module_Input_Map_{Map: &pbsubstreams.Module_Input_Map{ModuleName: moduleName}},
+	}
+}
@@ -2,6 +2,7 @@ package manifest
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/jhump/protoreflect/desc"
 
@@ -50,7 +51,19 @@ func loadProtobufs(pkg *pbsubstreams.Package, manif *Manifest) ([]*desc.FileDesc
 		}
 	}
 
-	customFiles, err := parser.ParseFiles(manif.Protobuf.Files...)
+	// Absolute file names are made relative to the import paths when possible, so that the
+	// descriptors embedded in the package do not depend on where the sources are checked out.
+	fileNames := make([]string, len(manif.Protobuf.Files))
+	for i, file := range manif.Protobuf.Files {
+		fileNames[i] = file
+		if filepath.IsAbs(file) {
+			if resolved, err := protoparse.ResolveFilenames(importPaths, file); err == nil {
+				fileNames[i] = resolved[0]
+			}
+		}
+	}
+
+	customFiles, err := parser.ParseFiles(fileNames...)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing proto files %q (import paths: %q): %w", manif.Protobuf.Files, importPaths, err)
 	}
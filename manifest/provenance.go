@@ -0,0 +1,137 @@
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+// NewBuildProvenance returns the provenance of `pkg`, built from the manifest at
+// `manifestPath` with the given toolchain versions.
+func NewBuildProvenance(manifestPath string, pkg *pbsubstreams.Package, toolchainVersions map[string]string) (*pbsubstreams.BuildProvenance, error) {
+	cnt, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest %q: %w", manifestPath, err)
+	}
+
+	out := &pbsubstreams.BuildProvenance{
+		ManifestSha256:    sha256Hex(cnt),
+		ToolchainVersions: toolchainVersions,
+	}
+	for _, binary := range pkg.Modules.Binaries {
+		out.BinarySha256 = append(out.BinarySha256, sha256Hex(binary.Content))
+	}
+	return out, nil
+}
+
+// MarshalPackage encodes `pkg` deterministically, so that identical packages always give
+// identical bytes.
+func MarshalPackage(pkg *pbsubstreams.Package) ([]byte, error) {
+	cnt, err := proto.MarshalOptions{Deterministic: true}.Marshal(pkg)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling package: %w", err)
+	}
+	return cnt, nil
+}
+
+// PackageDifferences lists the parts of the package that differ between `a` and `b`,
+// ignoring their signatures.
+func PackageDifferences(a, b *pbsubstreams.Package) (out []string) {
+	differs := func(name string, x, y proto.Message) {
+		if !proto.Equal(x, y) {
+			out = append(out, name)
+		}
+	}
+
+	if a.Version != b.Version {
+		out = append(out, "version")
+	}
+	if a.Network != b.Network {
+		out = append(out, "network")
+	}
+	if len(a.PackageMeta) != len(b.PackageMeta) {
+		out = append(out, "package metadata")
+	} else {
+		for i := range a.PackageMeta {
+			metaA := proto.Clone(a.PackageMeta[i]).(*pbsubstreams.PackageMetadata)
+			metaB := proto.Clone(b.PackageMeta[i]).(*pbsubstreams.PackageMetadata)
+			metaA.Provenance, metaB.Provenance = nil, nil
+			differs(fmt.Sprintf("package metadata %q", a.PackageMeta[i].Name), metaA, metaB)
+
+			provenanceA, provenanceB := a.PackageMeta[i].Provenance, b.PackageMeta[i].Provenance
+			if provenanceA.GetManifestSha256() != provenanceB.GetManifestSha256() {
+				out = append(out, fmt.Sprintf("manifest hash of package %q", a.PackageMeta[i].Name))
+			}
+			for _, tool := range toolchainNames(provenanceA, provenanceB) {
+				if provenanceA.GetToolchainVersions()[tool] != provenanceB.GetToolchainVersions()[tool] {
+					out = append(out, fmt.Sprintf("%s version of package %q", tool, a.PackageMeta[i].Name))
+				}
+			}
+		}
+	}
+
+	if len(a.ProtoFiles) != len(b.ProtoFiles) {
+		out = append(out, "protobuf files")
+	} else {
+		for i := range a.ProtoFiles {
+			differs(fmt.Sprintf("protobuf file %q", a.ProtoFiles[i].GetName()), a.ProtoFiles[i], b.ProtoFiles[i])
+		}
+	}
+
+	if len(a.Modules.GetBinaries()) != len(b.Modules.GetBinaries()) {
+		out = append(out, "binaries")
+	} else {
+		for i := range a.Modules.Binaries {
+			differs(fmt.Sprintf("binary #%d", i), a.Modules.Binaries[i], b.Modules.Binaries[i])
+		}
+	}
+
+	if len(a.Modules.GetModules()) != len(b.Modules.GetModules()) {
+		out = append(out, "modules")
+	} else {
+		for i := range a.Modules.Modules {
+			differs(fmt.Sprintf("module %q", a.Modules.Modules[i].Name), a.Modules.Modules[i], b.Modules.Modules[i])
+		}
+	}
+
+	if len(a.ModuleMeta) != len(b.ModuleMeta) {
+		out = append(out, "module metadata")
+	} else {
+		for i := range a.ModuleMeta {
+			differs(fmt.Sprintf("module metadata #%d", i), a.ModuleMeta[i], b.ModuleMeta[i])
+		}
+	}
+
+	if len(a.ProjectTemplates) != len(b.ProjectTemplates) {
+		out = append(out, "project templates")
+	} else {
+		for i := range a.ProjectTemplates {
+			differs(fmt.Sprintf("project template %q", a.ProjectTemplates[i].Name), a.ProjectTemplates[i], b.ProjectTemplates[i])
+		}
+	}
+
+	differs("sink config", a.SinkConfig, b.SinkConfig)
+	if a.SinkModule != b.SinkModule {
+		out = append(out, "sink module")
+	}
+
+	return out
+}
+
+func toolchainNames(provenances ...*pbsubstreams.BuildProvenance) (out []string) {
+	seen := map[string]bool{}
+	for _, provenance := range provenances {
+		for tool := range provenance.GetToolchainVersions() {
+			if !seen[tool] {
+				seen[tool] = true
+				out = append(out, tool)
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,92 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+func TestNewBuildProvenance(t *testing.T) {
+	manifestPath := "testdata/binaries_relative_path.yaml"
+
+	pkg, err := NewReader(manifestPath).Read()
+	require.NoError(t, err)
+
+	provenance, err := NewBuildProvenance(manifestPath, pkg, map[string]string{"substreams": "v1.0.0"})
+	require.NoError(t, err)
+
+	manifestContent, err := os.ReadFile(manifestPath)
+	require.NoError(t, err)
+	binaryContent, err := os.ReadFile("testdata/binaries/dummy.wasm")
+	require.NoError(t, err)
+
+	assert.Equal(t, sha256Hex(manifestContent), provenance.ManifestSha256)
+	assert.Equal(t, []string{sha256Hex(binaryContent)}, provenance.BinarySha256)
+	assert.Equal(t, map[string]string{"substreams": "v1.0.0"}, provenance.ToolchainVersions)
+}
+
+func TestMarshalPackage_Reproducible(t *testing.T) {
+	build := func(dir string) []byte {
+		t.Helper()
+
+		copyTestFile(t, "testdata/binaries/dummy.wasm", filepath.Join(dir, "binaries", "dummy.wasm"))
+		copyTestFile(t, "testdata/proto1/sf/substreams/test1.proto", filepath.Join(dir, "proto", "sf", "substreams", "test1.proto"))
+
+		manifestPath := filepath.Join(dir, "substreams.yaml")
+		manifestContent := "specVersion: v0.1.0\npackage:\n  name: test\n  version: v0.0.0\n\n" +
+			"protobuf:\n  files:\n    - " + filepath.Join(dir, "proto", "sf", "substreams", "test1.proto") + "\n  importPaths:\n    - ./proto\n\n" +
+			"binaries:\n  default:\n    type: wasm/rust-v1\n    file: binaries/dummy.wasm\n\n" +
+			"modules:\n  - name: test_mapper\n    kind: map\n    inputs:\n      - source: sf.test.Block\n    output:\n      type: proto:sf.test.Output\n"
+		require.NoError(t, os.WriteFile(manifestPath, []byte(manifestContent), 0644))
+
+		pkg, err := NewReader(manifestPath).Read()
+		require.NoError(t, err)
+		assert.Equal(t, "sf/substreams/test1.proto", pkg.ProtoFiles[len(pkg.ProtoFiles)-1].GetName())
+
+		cnt, err := MarshalPackage(pkg)
+		require.NoError(t, err)
+		return cnt
+	}
+
+	// The absolute path of the proto file differs between the two manifests, but is not
+	// embedded in the package.
+	assert.Equal(t, build(t.TempDir()), build(t.TempDir()))
+}
+
+func TestPackageDifferences(t *testing.T) {
+	pkg := readTestPackage(t)
+	pkg.PackageMeta[0].Provenance = &pbsubstreams.BuildProvenance{
+		ManifestSha256:    "aa",
+		ToolchainVersions: map[string]string{"substreams": "v1.0.0", "rustc": "rustc 1.70.0"},
+	}
+
+	other := proto.Clone(pkg).(*pbsubstreams.Package)
+	assert.Empty(t, PackageDifferences(pkg, other))
+
+	other.PackageMeta[0].Provenance.ManifestSha256 = "bb"
+	other.PackageMeta[0].Provenance.ToolchainVersions = map[string]string{"substreams": "v1.0.0", "cargo": "cargo 1.70.0"}
+	other.PackageMeta[0].Doc = "changed"
+	other.Signatures = []*pbsubstreams.PackageSignature{{PublicKey: []byte("key")}}
+
+	assert.Equal(t, []string{
+		`package metadata "spkg1"`,
+		`manifest hash of package "spkg1"`,
+		`cargo version of package "spkg1"`,
+		`rustc version of package "spkg1"`,
+	}, PackageDifferences(pkg, other))
+}
+
+func copyTestFile(t *testing.T, from string, to string) {
+	t.Helper()
+
+	cnt, err := os.ReadFile(from)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(to), os.ModePerm))
+	require.NoError(t, os.WriteFile(to, cnt, 0644))
+}
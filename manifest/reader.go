@@ -2,6 +2,7 @@ package manifest
 
 import (
 	"context"
+	"crypto/ed25519"
 	"fmt"
 	"io"
 	"net/http"
@@ -20,6 +21,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/wasm/codehash"
 )
 
 var httpClient = &http.Client{
@@ -42,6 +44,39 @@ func SkipModuleOutputTypeValidationReader() Options {
 	}
 }
 
+// WithRegistry sets the registry resolving the imports written as `name@version` that are
+// not recorded in the lockfile, either an HTTP(S) URL or a local directory, see `Registry`.
+// Without it, such imports fail to be read.
+func WithRegistry(base string) Options {
+	return func(r *Reader) *Reader {
+		r.registry = NewRegistry(base)
+		return r
+	}
+}
+
+// WithLockfileUpdate records in the lockfile the imports resolved through the registry, and
+// the hashes of the locked imports without one. Reading a manifest leaves its lockfile
+// untouched otherwise, and requires the hashes of the locked imports.
+func WithLockfileUpdate() Options {
+	return func(r *Reader) *Reader {
+		r.updateLockfile = true
+		return r
+	}
+}
+
+// WithTrustedKeys requires the packages read from `.spkg` files, directly or through
+// imports (local, remote or from the registry), to carry a valid signature by one of `keys`.
+// The manifests read, themselves or imported, are built from sources and trusted without
+// checks. It defaults to the keys of the trust list file set in the `SUBSTREAMS_TRUSTED_KEYS`
+// environment variable, see `LoadTrustedKeys`.
+func WithTrustedKeys(keys ...ed25519.PublicKey) Options {
+	return func(r *Reader) *Reader {
+		r.trustedKeys = keys
+		r.trustedKeysFile = ""
+		return r
+	}
+}
+
 func WithCollectProtoDefinitions(f func(protoDefinitions []*desc.FileDescriptor)) Options {
 	return func(r *Reader) *Reader {
 		r.collectProtoDefinitionsFunc = f
@@ -61,10 +96,15 @@ type Reader struct {
 	//options
 	skipSourceCodeImportValidation bool
 	skipModuleOutputTypeValidation bool
+	registry                       *Registry
+	updateLockfile                 bool
+	trustedKeys                    []ed25519.PublicKey
+	trustedKeysFile                string
 }
 
 func NewReader(input string, opts ...Options) *Reader {
 	r := &Reader{input: input}
+	r.trustedKeysFile = os.Getenv(TrustedKeysEnvVar)
 	for _, opt := range opts {
 		r = opt(r)
 	}
@@ -190,6 +230,16 @@ func (r *Reader) fromContents(contents []byte) (pkg *pbsubstreams.Package, err e
 		return nil, fmt.Errorf("unmarshalling: %w", err)
 	}
 
+	if r.trustedKeysFile != "" {
+		if r.trustedKeys, err = LoadTrustedKeys(r.trustedKeysFile); err != nil {
+			return nil, err
+		}
+		r.trustedKeysFile = ""
+	}
+	if err := verifyTrustedSignature(contents, r.trustedKeys); err != nil {
+		return nil, fmt.Errorf("signature verification failed: %w", err)
+	}
+
 	if err := r.validate(pkg); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
@@ -332,6 +382,15 @@ func ValidateModules(mods *pbsubstreams.Modules) error {
 			return fmt.Errorf("limit of 30 inputs for a given module (%q) reached", mod.Name)
 		}
 
+		if mod.GetKindBlocks() != nil {
+			if len(mod.Inputs) != 1 || mod.Inputs[0].GetSource() == nil {
+				return fmt.Errorf("module %q: kind 'blocks' requires exactly one 'source' input", mod.Name)
+			}
+			if mod.Output == nil || mod.Output.Type != "proto:"+mod.Inputs[0].GetSource().Type {
+				return fmt.Errorf("module %q: kind 'blocks' output type must be the source type %q", mod.Name, mod.Inputs[0].GetSource().Type)
+			}
+		}
+
 		for idx, in := range mod.Inputs {
 			switch i := in.Input.(type) {
 			case *pbsubstreams.Module_Input_Params_:
@@ -348,8 +407,10 @@ func ValidateModules(mods *pbsubstreams.Modules) error {
 				for _, mod2 := range mods.Modules {
 					if mod2.Name == seekMod {
 						found = true
-						if _, ok := mod2.Kind.(*pbsubstreams.Module_KindMap_); !ok {
-							return fmt.Errorf("module %q: input %d: referenced module %q not of 'map' kind", mod.Name, idx, seekMod)
+						switch mod2.Kind.(type) {
+						case *pbsubstreams.Module_KindMap_, *pbsubstreams.Module_KindBlocks_:
+						default:
+							return fmt.Errorf("module %q: input %d: referenced module %q not of 'map' or 'blocks' kind", mod.Name, idx, seekMod)
 						}
 					}
 				}
@@ -425,6 +486,10 @@ func LoadManifestFile(inputPath string) (*Manifest, error) {
 			if err := validateStoreBuilder(s); err != nil {
 				return nil, fmt.Errorf("stream %q: %w", s.Name, err)
 			}
+		case ModuleKindBlocks:
+			if err := validateBlocksTransform(s); err != nil {
+				return nil, fmt.Errorf("stream %q: %w", s.Name, err)
+			}
 
 		default:
 			return nil, fmt.Errorf("stream %q: invalid kind %q", s.Name, s.Kind)
@@ -439,26 +504,119 @@ func LoadManifestFile(inputPath string) (*Manifest, error) {
 	return m, nil
 }
 
-func loadImports(pkg *pbsubstreams.Package, manif *Manifest) error {
+func (r *Reader) loadImports(pkg *pbsubstreams.Package, manif *Manifest) error {
+	var lockfile *Lockfile
+	var lockfileChanged bool
+	lockfilePath := filepath.Join(manif.Workdir, LockfileName)
+
 	for _, kv := range manif.Imports {
 		importName := kv[0]
-		importPath := manif.resolvePath(kv[1])
 
-		subpkgReader := NewReader(importPath)
-		subpkg, err := subpkgReader.Read()
-		if err != nil {
-			return fmt.Errorf("importing %q: %w", importPath, err)
+		var subpkg *pbsubstreams.Package
+		if name, constraint, ok := ParseRegistryImport(kv[1]); ok {
+			if lockfile == nil {
+				var err error
+				if lockfile, err = LoadLockfile(lockfilePath); err != nil {
+					return err
+				}
+			}
+
+			var changed bool
+			var err error
+			subpkg, changed, err = r.readRegistryImport(lockfile, name, constraint)
+			if err != nil {
+				return fmt.Errorf("importing %q: %w", kv[1], err)
+			}
+			lockfileChanged = lockfileChanged || changed
+		} else {
+			importPath := manif.resolvePath(kv[1])
+
+			subpkgReader := NewReader(importPath)
+			subpkgReader.registry = r.registry
+			subpkgReader.updateLockfile = r.updateLockfile
+			subpkgReader.trustedKeys = r.trustedKeys
+			subpkgReader.trustedKeysFile = r.trustedKeysFile
+
+			var err error
+			subpkg, err = subpkgReader.Read()
+			if err != nil {
+				return fmt.Errorf("importing %q: %w", importPath, err)
+			}
 		}
 
 		prefixModules(subpkg.Modules.Modules, importName)
 		reindexAndMergePackage(subpkg, pkg)
 		mergeProtoFiles(subpkg, pkg)
 	}
-	// loop through the Manifest, and get the `imports` statements,
-	// pull the Package files from Disk, and merge them into this one
+
+	if lockfileChanged && r.updateLockfile {
+		if err := lockfile.Save(lockfilePath); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// readRegistryImport reads the package imported as `name@constraint` as recorded in the
+// lockfile, or resolved through the registry when missing, `lockfileChanged` telling the
+// lockfile needs to be saved. The content of the package must match the recorded hash, and
+// its metadata the resolved version.
+func (r *Reader) readRegistryImport(lockfile *Lockfile, name string, constraint string) (pkg *pbsubstreams.Package, lockfileChanged bool, err error) {
+	locked := lockfile.Find(name, constraint)
+	if locked == nil {
+		if r.registry == nil {
+			return nil, false, fmt.Errorf("import is not recorded in %s, record it with `substreams pack` and the %s environment variable set", LockfileName, RegistryEnvVar)
+		}
+
+		resolved, err := r.registry.Resolve(name, constraint)
+		if err != nil {
+			return nil, false, err
+		}
+
+		locked = &LockedImport{
+			Name:       name,
+			Constraint: constraint,
+			Version:    resolved.Version,
+			URL:        resolved.URL,
+			SHA256:     resolved.SHA256,
+		}
+		lockfile.Set(locked)
+		lockfileChanged = true
+	}
+
+	cnt, err := readLocation(locked.URL)
+	if err != nil {
+		return nil, false, fmt.Errorf("reading package: %w", err)
+	}
+
+	hash := sha256Hex(cnt)
+	if locked.SHA256 == "" {
+		if !lockfileChanged && !r.updateLockfile {
+			return nil, false, fmt.Errorf("no hash recorded in %s for %q, record it with `substreams pack`", LockfileName, locked.URL)
+		}
+		locked.SHA256 = hash
+		lockfileChanged = true
+	}
+	if hash != locked.SHA256 {
+		return nil, false, fmt.Errorf("content of %q has hash %s, expected %s", locked.URL, hash, locked.SHA256)
+	}
+
+	pkg, err = r.fromContents(cnt)
+	if err != nil {
+		return nil, false, fmt.Errorf("reading package %q: %w", locked.URL, err)
+	}
+
+	if len(pkg.PackageMeta) == 0 {
+		return nil, false, fmt.Errorf("package %q has no metadata", locked.URL)
+	}
+	meta := pkg.PackageMeta[0]
+	if meta.Name != strings.ReplaceAll(name, "-", "_") || meta.Version != locked.Version {
+		return nil, false, fmt.Errorf("package %q is %s@%s, expected %s@%s", locked.URL, meta.Name, meta.Version, name, locked.Version)
+	}
+
+	return pkg, lockfileChanged, nil
+}
+
 const PrefixSeparator = ":"
 
 func prefixModules(mods []*pbsubstreams.Module, prefix string) {
@@ -536,14 +694,22 @@ func (r *Reader) manifestToPkg(m *Manifest) (*pbsubstreams.Package, []*desc.File
 		return nil, nil, fmt.Errorf("error loading protobuf: %w", err)
 	}
 
-	if err := loadImports(pkg, m); err != nil {
+	if err := r.loadImports(pkg, m); err != nil {
 		return nil, nil, fmt.Errorf("error loading imports: %w", err)
 	}
 
+	if err := loadParams(pkg, m); err != nil {
+		return nil, nil, err
+	}
+
 	if err := r.loadSinkConfig(pkg, m); err != nil {
 		return nil, nil, fmt.Errorf("error parsing sink configuration: %w", err)
 	}
 
+	if err := loadProjectTemplates(pkg, m); err != nil {
+		return nil, nil, fmt.Errorf("error loading project templates: %w", err)
+	}
+
 	return pkg, protoDefinitions, nil
 }
 
@@ -562,12 +728,24 @@ func (r *Reader) convertToPkg(m *Manifest) (pkg *pbsubstreams.Package, err error
 	}
 
 	moduleCodeIndexes := map[string]int{}
+	parsedBinaries := map[string]*codehash.Binary{}
 	for _, mod := range m.Modules {
 		pbmeta := &pbsubstreams.ModuleMetadata{
 			Doc: mod.Doc,
 		}
 		var pbmod *pbsubstreams.Module
 
+		if mod.Kind == ModuleKindBlocks {
+			pbmod, err = mod.ToProtoBlocks()
+			if err != nil {
+				return nil, err
+			}
+
+			pkg.ModuleMeta = append(pkg.ModuleMeta, pbmeta)
+			pkg.Modules.Modules = append(pkg.Modules.Modules, pbmod)
+			continue
+		}
+
 		binaryName := "default"
 		implicit := ""
 		if mod.Binary != "" {
@@ -598,6 +776,12 @@ func (r *Reader) convertToPkg(m *Manifest) (pkg *pbsubstreams.Package, err error
 				moduleCodeIndexes[binaryDef.File] = codeIndex
 			}
 			pbmod, err = mod.ToProtoWASM(uint32(codeIndex))
+			if err == nil && binaryDef.HashReachableCode && !r.skipSourceCodeImportValidation {
+				pbmod.CodeHash, err = reachableCodeHash(parsedBinaries, binaryDef.File, pkg.Modules.Binaries[codeIndex].Content, pbmod.BinaryEntrypoint)
+				if err != nil {
+					err = fmt.Errorf("module %q: %w", mod.Name, err)
+				}
+			}
 		default:
 			return nil, fmt.Errorf("module %q: invalid code type %q", mod.Name, binaryDef.Type)
 		}
@@ -609,27 +793,82 @@ func (r *Reader) convertToPkg(m *Manifest) (pkg *pbsubstreams.Package, err error
 		pkg.Modules.Modules = append(pkg.Modules.Modules, pbmod)
 	}
 
-	for modName, paramValue := range m.Params {
+	return
+}
+
+func reachableCodeHash(parsedBinaries map[string]*codehash.Binary, file string, content []byte, entrypoint string) ([]byte, error) {
+	parsed, found := parsedBinaries[file]
+	if !found {
+		var err error
+		if parsed, err = codehash.Parse(content); err != nil {
+			return nil, fmt.Errorf("parsing binary %q to hash reachable code: %w", file, err)
+		}
+		parsedBinaries[file] = parsed
+	}
+
+	return parsed.ReachableHash(entrypoint)
+}
+
+// loadParams applies the top-level `params` values of the manifest, once the protobuf
+// definitions are loaded as values of `proto:` typed params are encoded with them.
+func loadParams(pkg *pbsubstreams.Package, m *Manifest) error {
+	protoFiles := packageProtoFiles(pkg)
+	for modName, param := range m.Params {
+		if param == nil {
+			param = &Param{}
+		}
 		var modFound bool
 		for _, mod := range pkg.Modules.Modules {
 			if mod.Name == modName {
 				if len(mod.Inputs) == 0 {
-					return nil, fmt.Errorf("params value defined for module %q but module has no inputs defined, add 'params: string' to 'inputs' for module", modName)
+					return fmt.Errorf("params value defined for module %q but module has no inputs defined, add 'params: string' to 'inputs' for module", modName)
 				}
 				p := mod.Inputs[0].GetParams()
 				if p == nil {
-					return nil, fmt.Errorf("params value defined for module %q: module %q does not have 'params' as its first input type", modName, modName)
+					return fmt.Errorf("params value defined for module %q: module %q does not have 'params' as its first input type", modName, modName)
+				}
+				if err := applyParamDeclaration(p, param); err != nil {
+					return fmt.Errorf("params defined for module %q: %w", modName, err)
+				}
+				if err := setParamsValue(mod, param.Default, protoFiles); err != nil {
+					return fmt.Errorf("params value defined for module %q: %w", modName, err)
 				}
-				p.Value = paramValue
 				modFound = true
 			}
 		}
 		if !modFound {
-			return nil, fmt.Errorf("params value defined for module %q, but such module is not defined", modName)
+			return fmt.Errorf("params value defined for module %q, but such module is not defined", modName)
+		}
+	}
+	return nil
+}
+
+// applyParamDeclaration sets the type, required flag and doc declared in the top-level
+// `params` mapping on the module's `params` input, keeping the ones not declared, for
+// example by the package declaring an imported module.
+func applyParamDeclaration(p *pbsubstreams.Module_Input_Params, param *Param) error {
+	if param.Required != nil && *param.Required && param.Default != "" {
+		return fmt.Errorf("a required param cannot have a default value")
+	}
+
+	if param.Type != "" {
+		paramsType, err := ParseParamsType(param.Type)
+		if err != nil {
+			return err
+		}
+		if p.Type != "" && p.Type != paramsType.String() {
+			return fmt.Errorf("type %q conflicts with type %q declared by the module's 'params' input", param.Type, p.Type)
 		}
+		p.Type = paramsType.String()
 	}
 
-	return
+	if param.Required != nil {
+		p.Required = *param.Required
+	}
+	if param.Doc != "" {
+		p.Doc = param.Doc
+	}
+	return nil
 }
 
 var storeValidTypes = map[string]bool{
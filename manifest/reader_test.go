@@ -213,6 +213,118 @@ func TestReader_Read(t *testing.T) {
 			},
 			require.NoError,
 		},
+		{
+			"blocks_module.yaml",
+			args{validateBinary: true},
+			&pbsubstreams.Package{
+				Version:    1,
+				ProtoFiles: readSystemProtoDescriptors(t),
+				PackageMeta: []*pbsubstreams.PackageMetadata{
+					{
+						Name:    "test",
+						Version: "v0.0.0",
+					},
+				},
+				ModuleMeta: []*pbsubstreams.ModuleMetadata{
+					{},
+				},
+				Modules: &pbsubstreams.Modules{
+					Modules: []*pbsubstreams.Module{
+						{
+							Name:         "test_blocks",
+							InitialBlock: UNSET,
+							Kind: &pbsubstreams.Module_KindBlocks_{
+								KindBlocks: &pbsubstreams.Module_KindBlocks{
+									FieldMask: []string{"header", "transactions.hash"},
+									Filter:    `.header.number != "0"`,
+								},
+							},
+							Inputs: []*pbsubstreams.Module_Input{
+								{Input: &pbsubstreams.Module_Input_Source_{Source: &pbsubstreams.Module_Input_Source{Type: "sf.test.Block"}}},
+							},
+							Output: &pbsubstreams.Module_Output{Type: "proto:sf.test.Block"},
+						},
+					},
+				},
+			},
+			require.NoError,
+		},
+		{
+			"project_templates.yaml",
+			args{},
+			&pbsubstreams.Package{
+				Version:    1,
+				ProtoFiles: readSystemProtoDescriptors(t),
+				Modules:    &pbsubstreams.Modules{},
+				PackageMeta: []*pbsubstreams.PackageMetadata{
+					{
+						Name:    "test",
+						Version: "v0.0.0",
+					},
+				},
+				ProjectTemplates: []*pbsubstreams.ProjectTemplate{
+					{
+						Name:        "stats",
+						Description: "Stats of each block",
+						Files: []*pbsubstreams.ProjectTemplateFile{
+							{Path: "src/lib.rs", Content: []byte("// {{ .sourceType }}\n")},
+						},
+					},
+				},
+			},
+			require.NoError,
+		},
+		{
+			"invalid_blocks_module.yaml",
+			args{},
+			nil,
+			require.Error,
+		},
+		{
+			"typed_params.yaml",
+			args{validateBinary: true},
+			&pbsubstreams.Package{
+				Version:    1,
+				ProtoFiles: readSystemProtoDescriptors(t),
+				PackageMeta: []*pbsubstreams.PackageMetadata{
+					{
+						Name:    "test",
+						Version: "v0.0.0",
+					},
+				},
+				ModuleMeta: []*pbsubstreams.ModuleMetadata{
+					{},
+					{},
+				},
+				Modules: &pbsubstreams.Modules{
+					Binaries: []*pbsubstreams.Binary{newTestBinaryModel([]byte{})},
+					Modules: []*pbsubstreams.Module{
+						withParamsInput(newTestModuleModel("map_min", UNSET, "sf.test.Block", "proto:sf.test.Output"), &pbsubstreams.Module_Input_Params{
+							Value: "10",
+							Type:  "int64",
+							Doc:   "Minimum value to emit",
+						}),
+						withParamsInput(newTestModuleModel("map_contract", UNSET, "sf.test.Block", "proto:sf.test.Output"), &pbsubstreams.Module_Input_Params{
+							Type:     "address",
+							Required: true,
+						}),
+					},
+				},
+			},
+			require.NoError,
+		},
+		{
+			"invalid_params_default.yaml",
+			args{validateBinary: true},
+			nil,
+			require.Error,
+		},
+		{
+			"invalid_params_type_conflict.yaml",
+			args{validateBinary: true},
+			nil,
+			require.Error,
+		},
 		{
 			"invalid_map_module.yaml",
 			args{},
@@ -280,6 +392,13 @@ func newTestModuleModel(name string, initialBlock uint64, inputType string, outp
 	}
 }
 
+func withParamsInput(module *pbsubstreams.Module, params *pbsubstreams.Module_Input_Params) *pbsubstreams.Module {
+	module.Inputs = append([]*pbsubstreams.Module_Input{
+		{Input: &pbsubstreams.Module_Input_Params_{Params: params}},
+	}, module.Inputs...)
+	return module
+}
+
 func readProtoDescriptor(t *testing.T, importPath string, file string) (out *descriptorpb.FileDescriptorProto) {
 	t.Helper()
 
@@ -311,3 +430,21 @@ func readSystemProtoDescriptors(t *testing.T) (out []*descriptorpb.FileDescripto
 
 	return systemProtoFiles.File
 }
+
+func TestApplyParamDeclaration(t *testing.T) {
+	imported := func() *pbsubstreams.Module_Input_Params {
+		return &pbsubstreams.Module_Input_Params{Type: "address", Required: true, Doc: "Contract to track"}
+	}
+	notRequired := false
+
+	p := imported()
+	require.NoError(t, applyParamDeclaration(p, &Param{Default: "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"}))
+	require.Equal(t, imported(), p, "a value alone keeps the imported declaration")
+
+	p = imported()
+	require.NoError(t, applyParamDeclaration(p, &Param{Required: &notRequired, Doc: "Other contract"}))
+	require.Equal(t, &pbsubstreams.Module_Input_Params{Type: "address", Doc: "Other contract"}, p)
+
+	p = imported()
+	require.EqualError(t, applyParamDeclaration(p, &Param{Type: "int64"}), `type "int64" conflicts with type "address" declared by the module's 'params' input`)
+}
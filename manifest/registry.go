@@ -0,0 +1,175 @@
+package manifest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/streamingfast/dstore"
+	"golang.org/x/mod/semver"
+)
+
+// RegistryEnvVar is the environment variable holding the registry used by `substreams pack`
+// to resolve the imports written as `name@version` and record them in the lockfile.
+const RegistryEnvVar = "SUBSTREAMS_REGISTRY"
+
+// RegistryIndexFile is the file listing the versions of a package in a registry, found at
+// `<registry>/<name>/index.json`.
+const RegistryIndexFile = "index.json"
+
+var registryImportRegexp = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9_-]{0,63})@(latest|v[0-9]+(\.[0-9]+(\.[0-9]+(-[0-9A-Za-z.-]+)?)?)?)$`)
+
+// ParseRegistryImport splits an import written as `name@version` into the package name and
+// its version constraint. The constraint is either a full version (`v1.2.3`), a version prefix
+// (`v1` or `v1.2`) matching its highest version, or `latest`.
+func ParseRegistryImport(in string) (name string, constraint string, ok bool) {
+	matches := registryImportRegexp.FindStringSubmatch(in)
+	if matches == nil {
+		return "", "", false
+	}
+	return matches[1], matches[2], true
+}
+
+// RegistryIndex is the content of a package's `index.json` file in a registry.
+type RegistryIndex struct {
+	Name     string                    `json:"name"`
+	Versions []*RegistryPackageVersion `json:"versions"`
+}
+
+type RegistryPackageVersion struct {
+	Version string `json:"version"`
+	// URL of the `.spkg` file, relative to the index file when it is not absolute.
+	URL    string `json:"url"`
+	SHA256 string `json:"sha256"`
+}
+
+// Registry resolves packages by name and version through index files laid out as
+// `<base>/<name>/index.json`, where `base` is either an HTTP(S) URL or a local directory
+// mirroring it for offline use.
+type Registry struct {
+	base string
+}
+
+func NewRegistry(base string) *Registry {
+	return &Registry{base: strings.TrimSuffix(base, "/")}
+}
+
+func (r *Registry) Resolve(name string, constraint string) (*RegistryPackageVersion, error) {
+	indexURL := joinLocation(r.base, name, RegistryIndexFile)
+	cnt, err := readLocation(indexURL)
+	if err != nil {
+		return nil, fmt.Errorf("reading registry index: %w", err)
+	}
+
+	index := &RegistryIndex{}
+	if err := json.Unmarshal(cnt, index); err != nil {
+		return nil, fmt.Errorf("decoding registry index %q: %w", indexURL, err)
+	}
+
+	var resolved *RegistryPackageVersion
+	for _, candidate := range index.Versions {
+		if !semver.IsValid(candidate.Version) || !matchesVersionConstraint(candidate.Version, constraint) {
+			continue
+		}
+		if resolved == nil || semver.Compare(candidate.Version, resolved.Version) > 0 {
+			resolved = candidate
+		}
+	}
+	if resolved == nil {
+		return nil, fmt.Errorf("no version of package %q matching %q in registry index %q", name, constraint, indexURL)
+	}
+
+	return &RegistryPackageVersion{
+		Version: resolved.Version,
+		URL:     resolveLocation(indexURL, resolved.URL),
+		SHA256:  resolved.SHA256,
+	}, nil
+}
+
+func matchesVersionConstraint(version string, constraint string) bool {
+	switch {
+	case constraint == "latest":
+		return semver.Prerelease(version) == ""
+	case semver.Canonical(constraint) == constraint:
+		return version == constraint
+	}
+	return semver.Prerelease(version) == "" && strings.HasPrefix(version, constraint+".")
+}
+
+// readLocation reads the file at a URL (http, https, gs, s3 or az) or a local path.
+func readLocation(location string) ([]byte, error) {
+	u, err := url.Parse(location)
+	if err == nil {
+		switch u.Scheme {
+		case "http", "https":
+			resp, err := httpClient.Get(location)
+			if err != nil {
+				return nil, fmt.Errorf("error downloading %q: %w", location, err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				return nil, fmt.Errorf("error downloading %q: unexpected status %s", location, resp.Status)
+			}
+			return io.ReadAll(resp.Body)
+
+		case "gs", "s3", "az":
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			return dstore.ReadObject(ctx, location)
+
+		case "file":
+			location = u.Path
+		}
+	}
+
+	return os.ReadFile(location)
+}
+
+func isURLLocation(location string) bool {
+	u, err := url.Parse(location)
+	return err == nil && u.Scheme != "" && u.Scheme != "file" && len(u.Scheme) > 1
+}
+
+func joinLocation(base string, elems ...string) string {
+	if isURLLocation(base) {
+		return base + "/" + path.Join(elems...)
+	}
+	return filepath.Join(append([]string{base}, elems...)...)
+}
+
+// resolveLocation resolves `ref` relative to the location of the file `from`.
+func resolveLocation(from string, ref string) string {
+	if isURLLocation(ref) || filepath.IsAbs(ref) {
+		return ref
+	}
+
+	if isURLLocation(from) {
+		base, err := url.Parse(from)
+		if err != nil {
+			return ref
+		}
+		refURL, err := url.Parse(ref)
+		if err != nil {
+			return ref
+		}
+		return base.ResolveReference(refURL).String()
+	}
+	return filepath.Join(filepath.Dir(from), ref)
+}
+
+func sha256Hex(cnt []byte) string {
+	sum := sha256.Sum256(cnt)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,193 @@
+package manifest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseRegistryImport(t *testing.T) {
+	tests := []struct {
+		in                 string
+		expectedName       string
+		expectedConstraint string
+		expectedOK         bool
+	}{
+		{"erc20@v1.2.3", "erc20", "v1.2.3", true},
+		{"eth-common@v1.2", "eth-common", "v1.2", true},
+		{"eth_common@v1", "eth_common", "v1", true},
+		{"erc20@latest", "erc20", "latest", true},
+		{"erc20@v1.2.3-rc.1", "erc20", "v1.2.3-rc.1", true},
+		{"erc20@1.2.3", "", "", false},
+		{"./erc20@v1.2.3.spkg", "", "", false},
+		{"https://example.com/erc20@v1.spkg", "", "", false},
+		{"erc20-v1.2.3.spkg", "", "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			name, constraint, ok := ParseRegistryImport(test.in)
+			assert.Equal(t, test.expectedOK, ok)
+			assert.Equal(t, test.expectedName, name)
+			assert.Equal(t, test.expectedConstraint, constraint)
+		})
+	}
+}
+
+func TestRegistry_Resolve(t *testing.T) {
+	registryDir := t.TempDir()
+	writeRegistryIndex(t, registryDir, "spkg1", `{
+		"name": "spkg1",
+		"versions": [
+			{"version": "v0.0.0", "url": "spkg1-v0.0.0.spkg", "sha256": "aa"},
+			{"version": "v0.1.0", "url": "spkg1-v0.1.0.spkg", "sha256": "bb"},
+			{"version": "v0.1.2", "url": "https://example.com/spkg1-v0.1.2.spkg", "sha256": "cc"},
+			{"version": "v0.2.0-rc.1", "url": "spkg1-v0.2.0-rc.1.spkg", "sha256": "dd"},
+			{"version": "v1.0.0", "url": "/abs/spkg1-v1.0.0.spkg", "sha256": "ee"}
+		]
+	}`)
+
+	server := httptest.NewServer(http.FileServer(http.Dir(registryDir)))
+	defer server.Close()
+
+	tests := []struct {
+		constraint      string
+		expectedVersion string
+		expectedURL     string
+		expectedErr     string
+	}{
+		{"latest", "v1.0.0", "/abs/spkg1-v1.0.0.spkg", ""},
+		{"v0", "v0.1.2", "https://example.com/spkg1-v0.1.2.spkg", ""},
+		{"v0.1.0", "v0.1.0", "{base}/spkg1/spkg1-v0.1.0.spkg", ""},
+		{"v0.0", "v0.0.0", "{base}/spkg1/spkg1-v0.0.0.spkg", ""},
+		{"v0.2.0-rc.1", "v0.2.0-rc.1", "{base}/spkg1/spkg1-v0.2.0-rc.1.spkg", ""},
+		{"v0.2", "", "", `no version of package "spkg1" matching "v0.2" in registry index "{base}/spkg1/index.json"`},
+	}
+
+	for _, base := range []string{registryDir, server.URL} {
+		for _, test := range tests {
+			t.Run(base+"/"+test.constraint, func(t *testing.T) {
+				resolved, err := NewRegistry(base).Resolve("spkg1", test.constraint)
+				if test.expectedErr != "" {
+					require.EqualError(t, err, replaceBase(test.expectedErr, base))
+					return
+				}
+
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedVersion, resolved.Version)
+				assert.Equal(t, replaceBase(test.expectedURL, base), resolved.URL)
+			})
+		}
+	}
+
+	_, err := NewRegistry(registryDir).Resolve("unknown", "latest")
+	require.Error(t, err)
+}
+
+func TestReader_RegistryImport(t *testing.T) {
+	spkgContent, err := os.ReadFile("testdata/spkg1/spkg1-v0.0.0.spkg")
+	require.NoError(t, err)
+
+	registryDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(registryDir, "spkg1"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(registryDir, "spkg1", "spkg1-v0.0.0.spkg"), spkgContent, 0644))
+	writeRegistryIndex(t, registryDir, "spkg1", `{"name": "spkg1", "versions": [{"version": "v0.0.0", "url": "spkg1-v0.0.0.spkg"}]}`)
+
+	// The registry is only taken from the environment by `substreams pack`
+	t.Setenv(RegistryEnvVar, registryDir)
+
+	projectDir := t.TempDir()
+	manifestPath := filepath.Join(projectDir, "substreams.yaml")
+	require.NoError(t, os.WriteFile(manifestPath, []byte("specVersion: v0.1.0\npackage:\n  name: test\n  version: v0.0.0\n\nimports:\n  bare: spkg1@v0\n"), 0644))
+	lockfilePath := filepath.Join(projectDir, LockfileName)
+
+	_, err = NewReader(manifestPath).Read()
+	require.ErrorContains(t, err, `importing "spkg1@v0": import is not recorded in substreams.lock, record it with `+"`substreams pack`"+` and the SUBSTREAMS_REGISTRY environment variable set`)
+
+	// Without lockfile update, the import is resolved but not recorded
+	pkg, err := NewReader(manifestPath, WithRegistry(registryDir)).Read()
+	require.NoError(t, err)
+	require.Len(t, pkg.PackageMeta, 2)
+	assert.Equal(t, "spkg1", pkg.PackageMeta[1].Name)
+	assert.NoFileExists(t, lockfilePath)
+
+	_, err = NewReader(manifestPath, WithRegistry(registryDir), WithLockfileUpdate()).Read()
+	require.NoError(t, err)
+
+	lockfile, err := LoadLockfile(lockfilePath)
+	require.NoError(t, err)
+	assert.Equal(t, []*LockedImport{{
+		Name:       "spkg1",
+		Constraint: "v0",
+		Version:    "v0.0.0",
+		URL:        filepath.Join(registryDir, "spkg1", "spkg1-v0.0.0.spkg"),
+		SHA256:     sha256Hex(spkgContent),
+	}}, lockfile.Imports)
+
+	// Once locked, the registry is not needed anymore
+	_, err = NewReader(manifestPath).Read()
+	require.NoError(t, err)
+
+	lockfile.Imports[0].SHA256 = ""
+	require.NoError(t, lockfile.Save(lockfilePath))
+	_, err = NewReader(manifestPath).Read()
+	require.ErrorContains(t, err, "no hash recorded in substreams.lock for")
+
+	_, err = NewReader(manifestPath, WithLockfileUpdate()).Read()
+	require.NoError(t, err)
+	lockfile, err = LoadLockfile(lockfilePath)
+	require.NoError(t, err)
+	assert.Equal(t, sha256Hex(spkgContent), lockfile.Imports[0].SHA256)
+
+	lockfile.Imports[0].SHA256 = "00"
+	require.NoError(t, lockfile.Save(lockfilePath))
+	_, err = NewReader(manifestPath, WithRegistry(registryDir)).Read()
+	require.ErrorContains(t, err, "has hash "+sha256Hex(spkgContent)+", expected 00")
+
+	lockfile.Imports[0].SHA256 = sha256Hex(spkgContent)
+	lockfile.Imports[0].Version = "v0.0.1"
+	require.NoError(t, lockfile.Save(lockfilePath))
+	_, err = NewReader(manifestPath, WithRegistry(registryDir)).Read()
+	require.ErrorContains(t, err, "is spkg1@v0.0.0, expected spkg1@v0.0.1")
+}
+
+func TestReader_RegistryImportWithoutMetadata(t *testing.T) {
+	content, err := os.ReadFile("testdata/spkg1/spkg1-v0.0.0.spkg")
+	require.NoError(t, err)
+	pkg := &pbsubstreams.Package{}
+	require.NoError(t, proto.Unmarshal(content, pkg))
+	pkg.PackageMeta = nil
+	spkgContent, err := proto.Marshal(pkg)
+	require.NoError(t, err)
+
+	registryDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(registryDir, "bare"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(registryDir, "bare", "bare-v0.0.0.spkg"), spkgContent, 0644))
+	writeRegistryIndex(t, registryDir, "bare", `{"name": "bare", "versions": [{"version": "v0.0.0", "url": "bare-v0.0.0.spkg"}]}`)
+
+	manifestPath := filepath.Join(t.TempDir(), "substreams.yaml")
+	require.NoError(t, os.WriteFile(manifestPath, []byte("specVersion: v0.1.0\npackage:\n  name: test\n  version: v0.0.0\n\nimports:\n  bare: bare@v0\n"), 0644))
+
+	_, err = NewReader(manifestPath, WithRegistry(registryDir)).Read()
+	require.ErrorContains(t, err, `importing "bare@v0"`)
+	require.ErrorContains(t, err, "no package metadata present in package")
+}
+
+func writeRegistryIndex(t *testing.T, registryDir string, name string, content string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(registryDir, name), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(registryDir, name, RegistryIndexFile), []byte(content), 0644))
+}
+
+func replaceBase(in string, base string) string {
+	return strings.ReplaceAll(in, "{base}", base)
+}
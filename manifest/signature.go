@@ -3,12 +3,14 @@ package manifest
 import (
 	"bytes"
 	"crypto/sha1"
+	"crypto/sha256"
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"sync"
 
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/wasm/codehash"
 )
 
 type ModuleHash []byte
@@ -16,13 +18,17 @@ type ModuleHash []byte
 type ModuleHashes struct {
 	cache map[string][]byte
 
+	// binaries holds the binaries of modules with a `code_hash`
+	binaries map[*pbsubstreams.Binary]*hashedBinary
+
 	mu *sync.RWMutex
 }
 
 func NewModuleHashes() *ModuleHashes {
 	return &ModuleHashes{
-		cache: make(map[string][]byte),
-		mu:    &sync.RWMutex{},
+		cache:    make(map[string][]byte),
+		binaries: make(map[*pbsubstreams.Binary]*hashedBinary),
+		mu:       &sync.RWMutex{},
 	}
 }
 
@@ -66,13 +72,33 @@ func (m *ModuleHashes) HashModule(modules *pbsubstreams.Modules, module *pbsubst
 		buf.WriteString("map")
 	case *pbsubstreams.Module_KindStore_:
 		buf.WriteString("store")
+	case *pbsubstreams.Module_KindBlocks_:
+		buf.WriteString("blocks")
 	default:
 		return nil, fmt.Errorf("invalid module file %T", module.Kind)
 	}
 
-	buf.WriteString("binary")
-	buf.WriteString(modules.Binaries[module.BinaryIndex].Type)
-	buf.Write(modules.Binaries[module.BinaryIndex].Content)
+	if blocks := module.GetKindBlocks(); blocks != nil {
+		// Blocks modules have no binary, the transform definition replaces it
+		buf.WriteString("field_mask")
+		for _, path := range blocks.FieldMask {
+			buf.WriteString(path)
+		}
+		buf.WriteString("filter")
+		buf.WriteString(blocks.Filter)
+	} else if len(module.CodeHash) != 0 {
+		codeHash, err := m.verifiedCodeHash(modules.Binaries[module.BinaryIndex], module)
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString("code_hash")
+		buf.WriteString(modules.Binaries[module.BinaryIndex].Type)
+		buf.Write(codeHash)
+	} else {
+		buf.WriteString("binary")
+		buf.WriteString(modules.Binaries[module.BinaryIndex].Type)
+		buf.Write(modules.Binaries[module.BinaryIndex].Content)
+	}
 
 	buf.WriteString("inputs")
 	for _, input := range module.Inputs {
@@ -112,6 +138,90 @@ func (m *ModuleHashes) HashModule(modules *pbsubstreams.Modules, module *pbsubst
 	return output, nil
 }
 
+type hashedBinary struct {
+	contentHash [sha256.Size]byte
+	// parsed is only set once a code hash of the binary is missing from `reachableCodeHashes`
+	parsed *codehash.Binary
+}
+
+// verifiedCodeHash recomputes the hash of the code of `binary` reachable from the module's
+// entrypoint, which must match the module's `code_hash`: it is never trusted as is since
+// modules' caches are shared by hash.
+func (m *ModuleHashes) verifiedCodeHash(binary *pbsubstreams.Binary, module *pbsubstreams.Module) ([]byte, error) {
+	m.mu.Lock()
+	hashed := m.binaries[binary]
+	if hashed == nil {
+		hashed = &hashedBinary{contentHash: sha256.Sum256(binary.Content)}
+		m.binaries[binary] = hashed
+	}
+
+	key := codeHashKey{contentHash: hashed.contentHash, entrypoint: module.BinaryEntrypoint}
+	codeHash, found := reachableCodeHashes.get(key)
+	if !found {
+		if hashed.parsed == nil {
+			parsed, err := codehash.Parse(binary.Content)
+			if err != nil {
+				m.mu.Unlock()
+				return nil, fmt.Errorf("module %q: parsing binary: %w", module.Name, err)
+			}
+			hashed.parsed = parsed
+		}
+		parsed := hashed.parsed
+		m.mu.Unlock()
+
+		var err error
+		if codeHash, err = parsed.ReachableHash(module.BinaryEntrypoint); err != nil {
+			return nil, fmt.Errorf("module %q: hashing code: %w", module.Name, err)
+		}
+		reachableCodeHashes.add(key, codeHash)
+	} else {
+		m.mu.Unlock()
+	}
+
+	if !bytes.Equal(codeHash, module.CodeHash) {
+		return nil, fmt.Errorf("module %q: code hash %x does not match the code of its binary, expected %x", module.Name, module.CodeHash, codeHash)
+	}
+	return codeHash, nil
+}
+
+// reachableCodeHashes holds the reachable code hashes computed by all the requests, the
+// binaries of a package being parsed and hashed once rather than by every request.
+var reachableCodeHashes = &codeHashCache{hashes: map[codeHashKey][]byte{}, maxEntries: 4096}
+
+type codeHashKey struct {
+	contentHash [sha256.Size]byte
+	entrypoint  string
+}
+
+// codeHashCache is a map of code hashes evicting its oldest entries beyond `maxEntries`.
+type codeHashCache struct {
+	mu         sync.Mutex
+	hashes     map[codeHashKey][]byte
+	keys       []codeHashKey
+	maxEntries int
+}
+
+func (c *codeHashCache) get(key codeHashKey) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	hash, found := c.hashes[key]
+	return hash, found
+}
+
+func (c *codeHashCache) add(key codeHashKey, hash []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, found := c.hashes[key]; found {
+		return
+	}
+	if len(c.keys) >= c.maxEntries {
+		delete(c.hashes, c.keys[0])
+		c.keys = c.keys[1:]
+	}
+	c.hashes[key] = hash
+	c.keys = append(c.keys, key)
+}
+
 func inputName(input *pbsubstreams.Module_Input) (string, error) {
 	switch input.Input.(type) {
 	case *pbsubstreams.Module_Input_Store_:
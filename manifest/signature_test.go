@@ -1,9 +1,14 @@
 package manifest
 
 import (
+	"crypto/sha256"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/bytecodealliance/wasmtime-go/v4"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -72,3 +77,101 @@ func Test_HashModule(t *testing.T) {
 
 	require.NotEqual(t, hashMapPoolsInitialized, hashMapPoolsCreated)
 }
+
+func Test_HashModule_ReachableCode(t *testing.T) {
+	build := func(helperB string) *pbsubstreams.Package {
+		t.Helper()
+
+		content, err := wasmtime.Wat2Wasm(`
+			(module
+			  (func $helper_a (result i32) (i32.const 1))
+			  (func $helper_b (result i32) (i32.const ` + helperB + `))
+			  (func (export "map_a") (param i32 i32) (drop (call $helper_a)))
+			  (func (export "map_b") (param i32 i32) (drop (call $helper_b))))
+		`)
+		require.NoError(t, err)
+
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "code.wasm"), content, 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "substreams.yaml"), []byte(`
+specVersion: v0.1.0
+package:
+  name: test
+  version: v0.0.0
+
+binaries:
+  default:
+    type: wasm/rust-v1
+    file: code.wasm
+    hashReachableCode: true
+
+modules:
+  - name: map_a
+    kind: map
+    inputs:
+      - source: sf.test.Block
+    output:
+      type: proto:sf.test.Output
+  - name: map_b
+    kind: map
+    inputs:
+      - source: sf.test.Block
+    output:
+      type: proto:sf.test.Output
+`), 0644))
+
+		pkg, err := NewReader(filepath.Join(dir, "substreams.yaml")).Read()
+		require.NoError(t, err)
+		require.NotEmpty(t, pkg.Modules.Modules[0].CodeHash)
+		return pkg
+	}
+
+	hash := func(pkg *pbsubstreams.Package, name string) (string, error) {
+		graph, err := NewModuleGraph(pkg.Modules.Modules)
+		require.NoError(t, err)
+		module, err := graph.Module(name)
+		require.NoError(t, err)
+
+		hashes := NewModuleHashes()
+		if _, err := hashes.HashModule(pkg.Modules, module, graph); err != nil {
+			return "", err
+		}
+		return hashes.Get(name), nil
+	}
+
+	pkg, changedPkg := build("2"), build("3")
+	for name, expectedSame := range map[string]bool{"map_a": true, "map_b": false} {
+		before, err := hash(pkg, name)
+		require.NoError(t, err)
+		after, err := hash(changedPkg, name)
+		require.NoError(t, err)
+		assert.Equal(t, expectedSame, before == after, name)
+	}
+
+	// The code hash is verified against the binary
+	pkg.Modules.Modules[0].CodeHash = changedPkg.Modules.Modules[1].CodeHash
+	_, err := hash(pkg, "map_a")
+	require.ErrorContains(t, err, `module "map_a": code hash `)
+	require.ErrorContains(t, err, "does not match the code of its binary")
+}
+
+func Test_CodeHashCache(t *testing.T) {
+	cache := &codeHashCache{hashes: map[codeHashKey][]byte{}, maxEntries: 2}
+	key := func(entrypoint string) codeHashKey {
+		return codeHashKey{contentHash: sha256.Sum256([]byte("code")), entrypoint: entrypoint}
+	}
+
+	cache.add(key("map_a"), []byte("a"))
+	cache.add(key("map_b"), []byte("b"))
+	hash, found := cache.get(key("map_a"))
+	require.True(t, found)
+	assert.Equal(t, []byte("a"), hash)
+
+	cache.add(key("map_c"), []byte("c"))
+	_, found = cache.get(key("map_a"))
+	assert.False(t, found)
+	for _, entrypoint := range []string{"map_b", "map_c"} {
+		_, found = cache.get(key(entrypoint))
+		assert.True(t, found, entrypoint)
+	}
+}
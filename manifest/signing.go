@@ -0,0 +1,197 @@
+package manifest
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+// TrustedKeysEnvVar is the environment variable holding the path of the trust list file used
+// when none is given with `WithTrustedKeys`.
+const TrustedKeysEnvVar = "SUBSTREAMS_TRUSTED_KEYS"
+
+// SignPackage returns the serialized package `contents` with a signature by `key` over its
+// signed bytes appended, see `packageSignedBytes`. The bytes stored are left untouched, so the
+// signatures already present remain valid.
+func SignPackage(contents []byte, key ed25519.PrivateKey) ([]byte, error) {
+	signed, _, err := splitPackageSignatures(contents)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := proto.Marshal(&pbsubstreams.PackageSignature{
+		PublicKey: key.Public().(ed25519.PublicKey),
+		Signature: ed25519.Sign(key, signed),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding signature: %w", err)
+	}
+
+	out := append([]byte{}, contents...)
+	out = protowire.AppendTag(out, packageSignaturesField, protowire.BytesType)
+	return protowire.AppendBytes(out, signature), nil
+}
+
+// VerifyPackageSignatures checks that all the signatures of the serialized package `contents`
+// are valid, and returns the public keys of their signers.
+func VerifyPackageSignatures(contents []byte) ([]ed25519.PublicKey, error) {
+	signed, signatures, err := splitPackageSignatures(contents)
+	if err != nil {
+		return nil, err
+	}
+
+	var signers []ed25519.PublicKey
+	for _, signature := range signatures {
+		if len(signature.PublicKey) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid signature: public key must be %d bytes, got %d", ed25519.PublicKeySize, len(signature.PublicKey))
+		}
+
+		publicKey := ed25519.PublicKey(signature.PublicKey)
+		if !ed25519.Verify(publicKey, signed, signature.Signature) {
+			return nil, fmt.Errorf("invalid signature by key %s", hex.EncodeToString(publicKey))
+		}
+		signers = append(signers, publicKey)
+	}
+	return signers, nil
+}
+
+// verifyTrustedSignature checks that the serialized package `contents` has a valid signature
+// by one of `trustedKeys`.
+func verifyTrustedSignature(contents []byte, trustedKeys []ed25519.PublicKey) error {
+	signers, err := VerifyPackageSignatures(contents)
+	if err != nil {
+		return err
+	}
+	if len(trustedKeys) == 0 {
+		return nil
+	}
+
+	for _, signer := range signers {
+		if IsTrustedKey(signer, trustedKeys) {
+			return nil
+		}
+	}
+
+	if len(signers) == 0 {
+		return fmt.Errorf("package is not signed and a signature by a trusted key is required")
+	}
+	return fmt.Errorf("package is not signed by a trusted key")
+}
+
+func IsTrustedKey(key ed25519.PublicKey, trustedKeys []ed25519.PublicKey) bool {
+	for _, trusted := range trustedKeys {
+		if key.Equal(trusted) {
+			return true
+		}
+	}
+	return false
+}
+
+// packageSignaturesField is the number of the `signatures` field of `Package`.
+const packageSignaturesField protowire.Number = 13
+
+// splitPackageSignatures splits the serialized package `contents` in its signed bytes and its
+// signatures. The signed bytes are the serialized package exactly as stored, with the
+// top-level records of the `signatures` field removed, the other bytes being kept as is and
+// in order. They don't depend on how the package is decoded and encoded again, so any
+// protobuf implementation can reproduce them from the stored package.
+func splitPackageSignatures(contents []byte) (signed []byte, signatures []*pbsubstreams.PackageSignature, err error) {
+	signed = make([]byte, 0, len(contents))
+	for rest := contents; len(rest) != 0; {
+		number, wireType, tagLength := protowire.ConsumeTag(rest)
+		if tagLength < 0 {
+			return nil, nil, fmt.Errorf("decoding package: %w", protowire.ParseError(tagLength))
+		}
+		valueLength := protowire.ConsumeFieldValue(number, wireType, rest[tagLength:])
+		if valueLength < 0 {
+			return nil, nil, fmt.Errorf("decoding package: %w", protowire.ParseError(valueLength))
+		}
+		record := rest[:tagLength+valueLength]
+		rest = rest[len(record):]
+
+		if number != packageSignaturesField {
+			signed = append(signed, record...)
+			continue
+		}
+		if wireType != protowire.BytesType {
+			return nil, nil, fmt.Errorf("decoding package: invalid signature wire type %d", wireType)
+		}
+
+		value, _ := protowire.ConsumeBytes(record[tagLength:])
+		signature := &pbsubstreams.PackageSignature{}
+		if err := proto.Unmarshal(value, signature); err != nil {
+			return nil, nil, fmt.Errorf("decoding package signature: %w", err)
+		}
+		signatures = append(signatures, signature)
+	}
+	return signed, signatures, nil
+}
+
+// LoadSigningKey reads an ed25519 private key from a PEM encoded PKCS #8 file, like the one
+// produced by `openssl genpkey -algorithm ed25519`.
+func LoadSigningKey(path string) (ed25519.PrivateKey, error) {
+	cnt, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading signing key: %w", err)
+	}
+
+	block, _ := pem.Decode(cnt)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, fmt.Errorf("signing key %q: expected a PEM encoded 'PRIVATE KEY' block", path)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("signing key %q: %w", path, err)
+	}
+
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("signing key %q: expected an ed25519 key, got %T", path, key)
+	}
+	return privateKey, nil
+}
+
+// LoadTrustedKeys reads the ed25519 public keys of a trust list file, holding one or more
+// PEM encoded 'PUBLIC KEY' blocks like the ones produced by `openssl pkey -pubout`.
+func LoadTrustedKeys(path string) ([]ed25519.PublicKey, error) {
+	cnt, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading trusted keys: %w", err)
+	}
+
+	var keys []ed25519.PublicKey
+	rest := bytes.TrimSpace(cnt)
+	for len(rest) != 0 {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil || block.Type != "PUBLIC KEY" {
+			return nil, fmt.Errorf("trusted keys %q: expected PEM encoded 'PUBLIC KEY' blocks", path)
+		}
+		rest = bytes.TrimSpace(rest)
+
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("trusted keys %q: %w", path, err)
+		}
+		publicKey, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("trusted keys %q: expected ed25519 keys, got %T", path, key)
+		}
+		keys = append(keys, publicKey)
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("trusted keys %q: no key found", path)
+	}
+	return keys, nil
+}
@@ -0,0 +1,152 @@
+package manifest
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+func TestSignPackage(t *testing.T) {
+	publicKey, privateKey := newTestKey(t)
+	otherPublicKey, otherPrivateKey := newTestKey(t)
+
+	unsigned := readTestPackageContents(t)
+	cnt, err := SignPackage(unsigned, privateKey)
+	require.NoError(t, err)
+	cnt, err = SignPackage(cnt, otherPrivateKey)
+	require.NoError(t, err)
+	assert.Equal(t, unsigned, cnt[:len(unsigned)], "signing appends the signatures to the bytes stored")
+
+	signers, err := VerifyPackageSignatures(cnt)
+	require.NoError(t, err)
+	assert.Equal(t, []ed25519.PublicKey{publicKey, otherPublicKey}, signers)
+
+	pkg := &pbsubstreams.Package{}
+	require.NoError(t, proto.Unmarshal(cnt, pkg))
+	require.Len(t, pkg.Signatures, 2)
+	assert.Equal(t, []byte(otherPublicKey), pkg.Signatures[1].PublicKey)
+
+	require.NoError(t, verifyTrustedSignature(cnt, []ed25519.PublicKey{otherPublicKey}))
+
+	thirdPublicKey, _ := newTestKey(t)
+	require.EqualError(t, verifyTrustedSignature(cnt, []ed25519.PublicKey{thirdPublicKey}), "package is not signed by a trusted key")
+	require.EqualError(t, verifyTrustedSignature(unsigned, []ed25519.PublicKey{publicKey}), "package is not signed and a signature by a trusted key is required")
+	require.NoError(t, verifyTrustedSignature(unsigned, nil))
+
+	// Only the records of the signatures are left out of the signed bytes, wherever they are.
+	reordered := append(append([]byte{}, cnt[len(unsigned):]...), unsigned...)
+	reorderedPkg := &pbsubstreams.Package{}
+	require.NoError(t, proto.Unmarshal(reordered, reorderedPkg))
+	assert.True(t, proto.Equal(pkg, reorderedPkg))
+	signers, err = VerifyPackageSignatures(reordered)
+	require.NoError(t, err)
+	assert.Len(t, signers, 2)
+
+	tampered := protowire.AppendString(protowire.AppendTag(append([]byte{}, cnt...), 9, protowire.BytesType), "other")
+	_, err = VerifyPackageSignatures(tampered)
+	require.ErrorContains(t, err, "invalid signature by key")
+}
+
+func TestReader_TrustedKeys(t *testing.T) {
+	t.Setenv(TrustedKeysEnvVar, "")
+
+	publicKey, privateKey := newTestKey(t)
+	otherPublicKey, _ := newTestKey(t)
+
+	cnt, err := SignPackage(readTestPackageContents(t), privateKey)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	spkgPath := filepath.Join(dir, "signed.spkg")
+	require.NoError(t, os.WriteFile(spkgPath, cnt, 0644))
+
+	_, err = NewReader(spkgPath, WithTrustedKeys(publicKey)).Read()
+	require.NoError(t, err)
+
+	_, err = NewReader(spkgPath, WithTrustedKeys(otherPublicKey)).Read()
+	require.EqualError(t, err, "signature verification failed: package is not signed by a trusted key")
+
+	manifestPath := filepath.Join(dir, "substreams.yaml")
+	require.NoError(t, os.WriteFile(manifestPath, []byte("specVersion: v0.1.0\npackage:\n  name: test\n  version: v0.0.0\nimports:\n  signed: ./signed.spkg\n"), 0644))
+
+	_, err = NewReader(manifestPath, WithTrustedKeys(publicKey)).Read()
+	require.NoError(t, err)
+
+	_, err = NewReader(manifestPath, WithTrustedKeys(otherPublicKey)).Read()
+	require.ErrorContains(t, err, "package is not signed by a trusted key")
+
+	trustListPath := filepath.Join(dir, "trusted.pem")
+	require.NoError(t, os.WriteFile(trustListPath, append(publicKeyPEM(t, otherPublicKey), publicKeyPEM(t, publicKey)...), 0644))
+	t.Setenv(TrustedKeysEnvVar, trustListPath)
+
+	keys, err := LoadTrustedKeys(trustListPath)
+	require.NoError(t, err)
+	assert.Equal(t, []ed25519.PublicKey{otherPublicKey, publicKey}, keys)
+
+	_, err = NewReader(spkgPath).Read()
+	require.NoError(t, err)
+
+	_, err = NewReader("testdata/spkg1/spkg1-v0.0.0.spkg").Read()
+	require.EqualError(t, err, "signature verification failed: package is not signed and a signature by a trusted key is required")
+}
+
+func TestLoadSigningKey(t *testing.T) {
+	publicKey, privateKey := newTestKey(t)
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+
+	keyPath := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+
+	loaded, err := LoadSigningKey(keyPath)
+	require.NoError(t, err)
+	assert.Equal(t, publicKey, loaded.Public())
+
+	require.NoError(t, os.WriteFile(keyPath, publicKeyPEM(t, publicKey), 0600))
+	_, err = LoadSigningKey(keyPath)
+	require.ErrorContains(t, err, "expected a PEM encoded 'PRIVATE KEY' block")
+}
+
+func newTestKey(t *testing.T) (ed25519.PublicKey, ed25519.PrivateKey) {
+	t.Helper()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return publicKey, privateKey
+}
+
+func publicKeyPEM(t *testing.T, key ed25519.PublicKey) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func readTestPackageContents(t *testing.T) []byte {
+	t.Helper()
+
+	cnt, err := os.ReadFile("testdata/spkg1/spkg1-v0.0.0.spkg")
+	require.NoError(t, err)
+	return cnt
+}
+
+func readTestPackage(t *testing.T) *pbsubstreams.Package {
+	t.Helper()
+
+	pkg := &pbsubstreams.Package{}
+	require.NoError(t, proto.Unmarshal(readTestPackageContents(t), pkg))
+	return pkg
+}
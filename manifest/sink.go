@@ -52,7 +52,7 @@ files:
 					return fmt.Errorf("sink: config: encoding json into protobuf message: %w", err)
 				}
 				r.sinkConfigDynamicMessage = dynConf
-				pbBytes, err := dynConf.Marshal()
+				pbBytes, err := dynConf.MarshalDeterministic()
 				if err != nil {
 					return fmt.Errorf("sink: config: encoding protobuf from dynamic message: %w", err)
 				}
@@ -0,0 +1,98 @@
+package manifest
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+// ProjectTemplateDescriptorFile is the file, at the root of a project template's directory,
+// holding its `name` and `description`.
+const ProjectTemplateDescriptorFile = "template.yaml"
+
+var projectTemplateNameRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]{0,63}$`)
+
+type projectTemplateDescriptor struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+}
+
+// LoadProjectTemplate reads the project template in `dir`, described by its
+// `template.yaml` file. Every other file of the directory is part of the project.
+func LoadProjectTemplate(dir string) (*pbsubstreams.ProjectTemplate, error) {
+	return LoadProjectTemplateFS(os.DirFS(dir))
+}
+
+// LoadProjectTemplateFS is like `LoadProjectTemplate`, reading the template at the root of `fsys`.
+func LoadProjectTemplateFS(fsys fs.FS) (*pbsubstreams.ProjectTemplate, error) {
+	descriptorContent, err := fs.ReadFile(fsys, ProjectTemplateDescriptorFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading descriptor: %w", err)
+	}
+
+	var descriptor projectTemplateDescriptor
+	if err := yaml.Unmarshal(descriptorContent, &descriptor); err != nil {
+		return nil, fmt.Errorf("decoding descriptor: %w", err)
+	}
+	if !projectTemplateNameRegexp.MatchString(descriptor.Name) {
+		return nil, fmt.Errorf("invalid name %q: must match %s", descriptor.Name, projectTemplateNameRegexp)
+	}
+
+	out := &pbsubstreams.ProjectTemplate{
+		Name:        descriptor.Name,
+		Description: descriptor.Description,
+	}
+
+	err = fs.WalkDir(fsys, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if entry.Name() == ".git" {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		if path == ProjectTemplateDescriptorFile {
+			return nil
+		}
+
+		content, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+		out.Files = append(out.Files, &pbsubstreams.ProjectTemplateFile{
+			Path:    path,
+			Content: content,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading files: %w", err)
+	}
+
+	return out, nil
+}
+
+func loadProjectTemplates(pkg *pbsubstreams.Package, m *Manifest) error {
+	names := map[string]bool{}
+	for _, dir := range m.Templates {
+		template, err := LoadProjectTemplate(m.resolvePath(dir))
+		if err != nil {
+			return fmt.Errorf("template %q: %w", dir, err)
+		}
+		if names[template.Name] {
+			return fmt.Errorf("template %q: name %q is already used by another template", dir, template.Name)
+		}
+		names[template.Name] = true
+
+		pkg.ProjectTemplates = append(pkg.ProjectTemplates, template)
+	}
+	return nil
+}
@@ -0,0 +1 @@
+// {{ .sourceType }}
@@ -12,3 +12,10 @@ var BlockEndProcess = MetricSet.NewCounter("substreams_block_process_end_counter
 var SquashesLaunched = MetricSet.NewCounter("substreams_total_squashes_launched", "Counter for Total squashes launched, used for rate")
 var SquashersStarted = MetricSet.NewCounter("substreams_total_squash_processes_launched", "Counter for Total squash processes launched, used for rate")
 var SquashersEnded = MetricSet.NewCounter("substreams_total_squash_processes_closed", "Counter for Total squash processes closed, used for active processes")
+
+var WorkerSlotsInUse = MetricSet.NewGauge("substreams_tier2_worker_slots_in_use", "Number of tier2 worker slots in use across all requests")
+var WorkerSlotsQueueDepth = MetricSet.NewGauge("substreams_tier2_worker_slots_queue_depth", "Number of jobs waiting for a tier2 worker slot")
+var WorkerSlotsWaitTime = MetricSet.NewHistogram("substreams_tier2_worker_slots_wait_time", "Time waited by jobs for a tier2 worker slot")
+
+var JobRetries = MetricSet.NewCounter("substreams_tier2_job_retries", "Counter of tier2 jobs run again after an infrastructure failure")
+var CircuitBreakerOpened = MetricSet.NewCounter("substreams_tier2_circuit_breaker_opened", "Counter of tier2 endpoints no longer sent jobs after repeated failures")
@@ -0,0 +1,335 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/streamingfast/dstore"
+	"go.uber.org/zap"
+
+	"github.com/streamingfast/substreams/block"
+	"github.com/streamingfast/substreams/manifest"
+	"github.com/streamingfast/substreams/reqctx"
+	"github.com/streamingfast/substreams/storage"
+	"github.com/streamingfast/substreams/storage/store"
+	"github.com/streamingfast/substreams/storage/store/state"
+)
+
+// DefaultCheckpointLease is how long the checkpoint of a run is considered live
+// without being saved again, after which the run is assumed to be dead
+const DefaultCheckpointLease = time.Minute
+
+// Checkpoint is the backprocessing progress of a run on one store module, saved in the
+// state store so that the runs processing the same store after this one died, from the
+// same request or not, clean up what it left behind. The work it completed is picked up
+// from storage: its jobs write a partial per store save interval, and its squashers a
+// complete store at each interval, which the plans of the next runs start from.
+type Checkpoint struct {
+	// Owner identifies the run saving the checkpoint
+	Owner      string `json:"owner"`
+	ModuleHash string `json:"module_hash"`
+	// LeaseExpiresAt is the time after which the run is considered dead, unless it
+	// saves its checkpoint again
+	LeaseExpiresAt time.Time `json:"lease_expires_at"`
+
+	// InFlightJobs are the ranges of the jobs running on tier2
+	InFlightJobs block.Ranges `json:"in_flight_jobs,omitempty"`
+
+	// PendingPartials are the partials written by completed jobs, not squashed yet
+	PendingPartials block.Ranges `json:"pending_partials,omitempty"`
+}
+
+// Expired tells if the run owning the checkpoint is dead at `now`
+func (c *Checkpoint) Expired(now time.Time) bool {
+	return now.After(c.LeaseExpiresAt)
+}
+
+func (c *Checkpoint) tracks(rng *block.Range) bool {
+	for _, ranges := range []block.Ranges{c.InFlightJobs, c.PendingPartials} {
+		for _, tracked := range ranges {
+			if tracked.StartBlock <= rng.StartBlock && rng.ExclusiveEndBlock <= tracked.ExclusiveEndBlock {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Checkpointer keeps the Checkpoints of a run, saving them in the background every
+// third of their lease under `checkpoints/<module_hash>/<owner>.json` in the state store.
+// The checkpoints of all the runs processing a store are found next to each other,
+// whatever the request, and each run has its own file, concurrent runs never
+// overwriting each other.
+type Checkpointer struct {
+	store        dstore.Store
+	owner        string
+	moduleHashes *manifest.ModuleHashes
+	moduleNames  []string
+	lease        time.Duration
+
+	mu          sync.Mutex
+	checkpoints map[string]*Checkpoint // by module name
+
+	launched bool
+	stopOnce sync.Once
+	stop     chan struct{}
+	stopped  chan struct{}
+}
+
+func NewCheckpointer(baseStore dstore.Store, moduleHashes *manifest.ModuleHashes, moduleNames []string, lease time.Duration) (*Checkpointer, error) {
+	store, err := baseStore.SubStore("checkpoints")
+	if err != nil {
+		return nil, fmt.Errorf("checkpoints store: %w", err)
+	}
+	// the checkpoint of a run is rewritten on each save
+	store.SetOverwrite(true)
+
+	owner := make([]byte, 8)
+	if _, err := rand.Read(owner); err != nil {
+		return nil, fmt.Errorf("generating checkpoint owner: %w", err)
+	}
+
+	return &Checkpointer{
+		store:        store,
+		owner:        hex.EncodeToString(owner),
+		moduleHashes: moduleHashes,
+		moduleNames:  moduleNames,
+		lease:        lease,
+		checkpoints:  make(map[string]*Checkpoint),
+		stop:         make(chan struct{}),
+		stopped:      make(chan struct{}),
+	}, nil
+}
+
+func checkpointFilename(moduleHash, owner string) string {
+	return fmt.Sprintf("%s/%s.json", moduleHash, owner)
+}
+
+// Load returns the checkpoints left by the other runs processing the modules of this
+// one, the live ones included, by module name
+func (c *Checkpointer) Load(ctx context.Context) (map[string][]*Checkpoint, error) {
+	out := make(map[string][]*Checkpoint)
+	for _, name := range c.moduleNames {
+		hash := c.moduleHashes.Get(name)
+		err := c.store.Walk(ctx, hash+"/", func(filename string) error {
+			if filename == checkpointFilename(hash, c.owner) {
+				return nil
+			}
+
+			checkpoint, err := c.read(ctx, filename)
+			if err != nil {
+				// checkpoints are rewritten in place, one may be deleted while we read it
+				reqctx.Logger(ctx).Info("skipping unreadable checkpoint", zap.String("filename", filename), zap.Error(err))
+				return nil
+			}
+			out[name] = append(out[name], checkpoint)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing checkpoints of module %q: %w", name, err)
+		}
+	}
+	return out, nil
+}
+
+func (c *Checkpointer) read(ctx context.Context, filename string) (*Checkpoint, error) {
+	reader, err := c.store.OpenObject(ctx, filename)
+	if err != nil {
+		return nil, fmt.Errorf("opening %q: %w", filename, err)
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %w", filename, err)
+	}
+
+	checkpoint := &Checkpoint{}
+	if err := json.Unmarshal(content, checkpoint); err != nil {
+		return nil, fmt.Errorf("unmarshalling %q: %w", filename, err)
+	}
+	return checkpoint, nil
+}
+
+// Remove deletes the checkpoint of a dead run, once cleaned up after
+func (c *Checkpointer) Remove(ctx context.Context, checkpoint *Checkpoint) error {
+	filename := checkpointFilename(checkpoint.ModuleHash, checkpoint.Owner)
+	if err := c.store.DeleteObject(ctx, filename); err != nil && err != dstore.ErrNotFound {
+		return fmt.Errorf("deleting %q: %w", filename, err)
+	}
+	return nil
+}
+
+// Launch saves the checkpoints, taking their lease, then keeps saving them in the
+// background until Stop is called or `ctx` is done
+func (c *Checkpointer) Launch(ctx context.Context) {
+	c.launched = true
+	c.saveLogged(ctx)
+
+	go func() {
+		defer close(c.stopped)
+
+		ticker := time.NewTicker(c.lease / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-c.stop:
+				return
+			case <-ticker.C:
+				c.saveLogged(ctx)
+			}
+		}
+	}()
+}
+
+// Stop ends the background saves, the checkpoints being left for their lease to expire
+func (c *Checkpointer) Stop() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
+}
+
+func (c *Checkpointer) JobStarted(moduleName string, rng *block.Range) {
+	c.update(moduleName, func(m *Checkpoint) {
+		m.InFlightJobs = append(m.InFlightJobs, rng)
+	})
+}
+
+func (c *Checkpointer) JobTerminated(moduleName string, rng *block.Range, partialsWritten block.Ranges) {
+	c.update(moduleName, func(m *Checkpoint) {
+		m.InFlightJobs = removeRange(m.InFlightJobs, rng)
+		m.PendingPartials = append(m.PendingPartials, partialsWritten...)
+		sort.Sort(m.PendingPartials)
+	})
+}
+
+func (c *Checkpointer) StoreSquashed(moduleName string, upToBlock uint64) {
+	c.update(moduleName, func(m *Checkpoint) {
+		var pending block.Ranges
+		for _, partial := range m.PendingPartials {
+			if partial.ExclusiveEndBlock > upToBlock {
+				pending = append(pending, partial)
+			}
+		}
+		m.PendingPartials = pending
+	})
+}
+
+// Delete stops the background saves and removes the checkpoints, once the backprocessing
+// is completed
+func (c *Checkpointer) Delete(ctx context.Context) error {
+	c.Stop()
+	if c.launched {
+		select {
+		case <-c.stopped:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, checkpoint := range c.checkpoints {
+		if err := c.Remove(ctx, checkpoint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Checkpointer) update(moduleName string, f func(m *Checkpoint)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	checkpoint, found := c.checkpoints[moduleName]
+	if !found {
+		checkpoint = &Checkpoint{Owner: c.owner, ModuleHash: c.moduleHashes.Get(moduleName)}
+		c.checkpoints[moduleName] = checkpoint
+	}
+	f(checkpoint)
+}
+
+// saveLogged saves the checkpoints, a missed save only leaving more partials behind if
+// the run dies
+func (c *Checkpointer) saveLogged(ctx context.Context) {
+	if err := c.save(ctx); err != nil {
+		reqctx.Logger(ctx).Warn("unable to save backprocessing checkpoint", zap.String("owner", c.owner), zap.Error(err))
+	}
+}
+
+func (c *Checkpointer) save(ctx context.Context) error {
+	c.mu.Lock()
+	leaseExpiresAt := time.Now().Add(c.lease)
+	contents := make(map[string][]byte, len(c.checkpoints))
+	for _, checkpoint := range c.checkpoints {
+		checkpoint.LeaseExpiresAt = leaseExpiresAt
+		content, err := json.Marshal(checkpoint)
+		if err != nil {
+			c.mu.Unlock()
+			return fmt.Errorf("marshalling checkpoint: %w", err)
+		}
+		contents[checkpointFilename(checkpoint.ModuleHash, checkpoint.Owner)] = content
+	}
+	c.mu.Unlock()
+
+	for filename, content := range contents {
+		if err := c.store.WriteObject(ctx, filename, bytes.NewReader(content)); err != nil {
+			return fmt.Errorf("writing %q: %w", filename, err)
+		}
+	}
+	return nil
+}
+
+func removeRange(ranges block.Ranges, rng *block.Range) (out block.Ranges) {
+	for _, el := range ranges {
+		if !el.Equals(rng) {
+			out = append(out, el)
+		}
+	}
+	return
+}
+
+// cleanupOrphanedPartials deletes the partials that will never be squashed and that
+// were written by the jobs of the `dead` runs, by module name. Other orphans, and the ones
+// tracked by the `live` runs of any request processing the same store, may be in use by
+// requests still running, they are left alone.
+func cleanupOrphanedPartials(ctx context.Context, dead, live map[string][]*Checkpoint, modulesStateMap storage.ModuleStorageStateMap, storeConfigs store.ConfigMap) {
+	logger := reqctx.Logger(ctx)
+	tracked := func(checkpoints []*Checkpoint, partial *block.Range) bool {
+		for _, checkpoint := range checkpoints {
+			if checkpoint.tracks(partial) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for name, modState := range modulesStateMap {
+		storeState, ok := modState.(*state.StoreStorageState)
+		if !ok || len(storeState.PartialsOrphaned) == 0 {
+			continue
+		}
+
+		config := storeConfigs[name]
+		for _, partial := range storeState.PartialsOrphaned {
+			if !tracked(dead[name], partial) || tracked(live[name], partial) {
+				continue
+			}
+			logger.Info("deleting orphaned partial", zap.String("module", name), zap.Stringer("range", partial))
+			// another request may be cleaning up after the same dead run
+			if err := config.NewPartialKV(partial.StartBlock, logger).DeleteStore(ctx, partial.ExclusiveEndBlock); err != nil {
+				logger.Warn("unable to delete orphaned partial", zap.String("module", name), zap.Stringer("range", partial), zap.Error(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/dstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/streamingfast/substreams/block"
+	"github.com/streamingfast/substreams/manifest"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/storage"
+	"github.com/streamingfast/substreams/storage/store"
+	"github.com/streamingfast/substreams/storage/store/state"
+)
+
+func testModuleHashes(t *testing.T) *manifest.ModuleHashes {
+	t.Helper()
+
+	mods := manifest.NewTestModules()
+	modules := &pbsubstreams.Modules{Modules: mods, Binaries: []*pbsubstreams.Binary{{Type: "wasm/rust-v1", Content: []byte("code")}}}
+	graph, err := manifest.NewModuleGraph(mods)
+	require.NoError(t, err)
+	hashes := manifest.NewModuleHashes()
+	for _, module := range mods {
+		_, err := hashes.HashModule(modules, module, graph)
+		require.NoError(t, err)
+	}
+	return hashes
+}
+
+func TestCheckpointer(t *testing.T) {
+	ctx := context.Background()
+	baseStore, err := dstore.NewStore(t.TempDir(), "", "", false)
+	require.NoError(t, err)
+	hashes := testModuleHashes(t)
+	lease := 60 * time.Millisecond
+
+	checkpointer, err := NewCheckpointer(baseStore, hashes, []string{"B", "E"}, lease)
+	require.NoError(t, err)
+	checkpoints, err := checkpointer.Load(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, checkpoints)
+
+	checkpointer.Launch(ctx)
+	checkpointer.JobStarted("B", block.NewRange(0, 20))
+	checkpointer.JobStarted("B", block.NewRange(20, 40))
+	checkpointer.JobTerminated("B", block.NewRange(0, 20), block.ParseRanges("0-10,10-20"))
+	checkpointer.StoreSquashed("B", 10)
+
+	// Another request processing the store B, along with other modules
+	concurrent, err := NewCheckpointer(baseStore, hashes, []string{"B", "C"}, lease)
+	require.NoError(t, err)
+	var previous *Checkpoint
+	require.Eventually(t, func() bool {
+		checkpoints, err := concurrent.Load(ctx)
+		require.NoError(t, err)
+		if len(checkpoints["B"]) == 0 {
+			return false
+		}
+		previous = checkpoints["B"][0]
+		return true
+	}, time.Second, 5*time.Millisecond, "saved in the background")
+	assert.False(t, previous.Expired(time.Now()), "the run is live")
+	assert.Equal(t, hashes.Get("B"), previous.ModuleHash)
+	assert.Equal(t, "[20, 40)", previous.InFlightJobs.String())
+	assert.Equal(t, "[10, 20)", previous.PendingPartials.String())
+
+	// the concurrent run has its own checkpoint
+	concurrent.JobStarted("B", block.NewRange(40, 60))
+	concurrent.Launch(ctx)
+	defer concurrent.Stop()
+	checkpoints, err = checkpointer.Load(ctx)
+	require.NoError(t, err)
+	require.Len(t, checkpoints["B"], 1)
+	assert.Equal(t, "[40, 60)", checkpoints["B"][0].InFlightJobs.String())
+	assert.Empty(t, checkpoints["E"])
+
+	// a run dying stops renewing its lease
+	checkpointer.Stop()
+	time.Sleep(lease + 10*time.Millisecond)
+	checkpoints, err = concurrent.Load(ctx)
+	require.NoError(t, err)
+	require.Len(t, checkpoints["B"], 1)
+	assert.True(t, checkpoints["B"][0].Expired(time.Now()))
+
+	require.NoError(t, concurrent.Remove(ctx, checkpoints["B"][0]))
+	checkpoints, err = concurrent.Load(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, checkpoints)
+
+	require.NoError(t, concurrent.Delete(ctx))
+	checkpoints, err = checkpointer.Load(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, checkpoints)
+}
+
+func TestCleanupOrphanedPartials(t *testing.T) {
+	ctx := context.Background()
+	baseStore, err := dstore.NewStore(t.TempDir(), "", "", false)
+	require.NoError(t, err)
+
+	config, err := store.NewConfig("B", 0, "abc", pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", baseStore)
+	require.NoError(t, err)
+	for _, filename := range []string{"0000000030-0000000020.partial", "0000000045-0000000040.partial", "0000000055-0000000050.partial", "0000000090-0000000080.partial"} {
+		require.NoError(t, baseStore.WriteObject(ctx, "abc/states/"+filename, bytes.NewReader([]byte("{}"))))
+	}
+
+	dead := map[string][]*Checkpoint{"B": {{
+		ModuleHash:      "abc",
+		InFlightJobs:    block.ParseRanges("40-60"),
+		PendingPartials: block.ParseRanges("20-30"),
+	}}}
+	// The live run may be of another request, processing the same store
+	live := map[string][]*Checkpoint{"B": {{ModuleHash: "abc", PendingPartials: block.ParseRanges("50-55")}}}
+	modulesStateMap := storage.ModuleStorageStateMap{
+		"B": &state.StoreStorageState{ModuleName: "B", PartialsOrphaned: block.ParseRanges("20-30,40-45,50-55,80-90")},
+	}
+
+	cleanupOrphanedPartials(ctx, dead, live, modulesStateMap, store.ConfigMap{"B": config})
+
+	var remaining []string
+	require.NoError(t, baseStore.Walk(ctx, "abc/states/", func(filename string) error {
+		remaining = append(remaining, filename)
+		return nil
+	}))
+	// 50-55 is still tracked by a live run, 80-90 was not written by the dead run, it
+	// may be in use by a concurrent request
+	assert.Equal(t, []string{"abc/states/0000000055-0000000050.partial", "abc/states/0000000090-0000000080.partial"}, remaining)
+}
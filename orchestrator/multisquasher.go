@@ -60,6 +60,10 @@ func NewMultiSquasher(
 			if err != nil {
 				return nil, err
 			}
+			storeSquasher.jobRegistry = runtimeConfig.JobRegistry
+			if storeConfig.MergeAssociative() {
+				storeSquasher.mergeBudget = runtimeConfig.SquashMemoryBudget
+			}
 
 			storeSquashers[storeModuleName] = storeSquasher
 			logger.Debug("store squasher initialized", zap.String("module_name", storeModuleName))
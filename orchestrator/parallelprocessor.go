@@ -3,6 +3,10 @@ package orchestrator
 import (
 	"context"
 	"fmt"
+	"time"
+
+	"github.com/streamingfast/dauth/authenticator"
+	"go.uber.org/zap"
 
 	"github.com/streamingfast/substreams"
 	"github.com/streamingfast/substreams/block"
@@ -13,6 +17,7 @@ import (
 	"github.com/streamingfast/substreams/service/config"
 	"github.com/streamingfast/substreams/storage"
 	"github.com/streamingfast/substreams/storage/execout"
+	"github.com/streamingfast/substreams/storage/jobcost"
 	"github.com/streamingfast/substreams/storage/store"
 )
 
@@ -20,6 +25,8 @@ type ParallelProcessor struct {
 	plan             *work.Plan
 	scheduler        *Scheduler
 	squasher         *MultiSquasher
+	checkpointer     *Checkpointer
+	jobRegistry      *work.RequestJobRegistry
 	workerPool       work.WorkerPool
 	execOutputReader *execout.LinearReader
 }
@@ -45,14 +52,26 @@ func BuildParallelProcessor(
 		}
 		firstRange := block.NewBoundedRange(requestedModule.InitialBlock, runtimeConfig.CacheSaveInterval, reqDetails.ResolvedStartBlockNum, reqDetails.LinearHandoffBlockNum)
 		requestedModuleCache := execoutStorage.NewFile(requestedModule.Name, firstRange)
+
+		extraModules := outputGraph.ExtraOutputModules()
+		extraModuleCaches := make([]*execout.File, len(extraModules))
+		for i, extraModule := range extraModules {
+			extraRange := block.NewBoundedRange(extraModule.InitialBlock, runtimeConfig.CacheSaveInterval, reqDetails.ResolvedStartBlockNum, reqDetails.LinearHandoffBlockNum)
+			extraModuleCaches[i] = execoutStorage.NewFile(extraModule.Name, extraRange)
+		}
+
 		execOutputReader = execout.NewLinearReader(
 			reqDetails.ResolvedStartBlockNum,
 			reqDetails.LinearHandoffBlockNum,
 			requestedModule,
 			requestedModuleCache,
+			extraModules,
+			extraModuleCaches,
 			respFunc,
 			runtimeConfig.CacheSaveInterval,
 			pendingUndoMessage,
+			reqDetails.OutputBatchMaxBlocks,
+			reqDetails.OutputBatchMaxBytes,
 		)
 	}
 
@@ -88,7 +107,26 @@ func BuildParallelProcessor(
 		return nil, fmt.Errorf("build storage map: %w", err)
 	}
 
-	plan, err := work.BuildNewPlan(ctx, modulesStateMap, runtimeConfig.SubrequestsSplitSize, reqDetails.LinearHandoffBlockNum, runtimeConfig.MaxJobsAhead, outputGraph)
+	var checkpointer *Checkpointer
+	if runtimeConfig.BackprocessingCheckpoints {
+		checkpointer, err = NewCheckpointer(runtimeConfig.BaseObjectStore, outputGraph.ModuleHashes(), modulesStateMap.Names(), DefaultCheckpointLease)
+		if err != nil {
+			return nil, err
+		}
+		cleanupAfterDeadRuns(ctx, checkpointer, modulesStateMap, storeConfigs)
+	}
+
+	var jobCostStore *jobcost.Store
+	var jobCosts map[string]jobcost.Observations
+	if runtimeConfig.CostAwareJobSplitting {
+		jobCostStore, err = jobcost.NewStore(runtimeConfig.BaseObjectStore)
+		if err != nil {
+			return nil, err
+		}
+		jobCosts = loadJobCosts(ctx, jobCostStore, modulesStateMap, outputGraph)
+	}
+
+	plan, err := work.BuildNewPlan(ctx, modulesStateMap, runtimeConfig.SubrequestsSplitSize, reqDetails.LinearHandoffBlockNum, runtimeConfig.MaxJobsAhead, outputGraph, jobCosts, runtimeConfig.Prioritizer)
 	if err != nil {
 		return nil, fmt.Errorf("build work plan: %w", err)
 	}
@@ -97,9 +135,26 @@ func BuildParallelProcessor(
 		return nil, fmt.Errorf("send initial progress: %w", err)
 	}
 
+	var jobRegistry *work.RequestJobRegistry
+	if runtimeConfig.JobRegistry != nil {
+		// the squashers release the partials of the shared jobs through it too
+		jobRegistry = work.NewRequestJobRegistry(runtimeConfig.JobRegistry)
+		runtimeConfig.JobRegistry = jobRegistry
+	}
+
 	scheduler := NewScheduler(plan, respFunc, reqDetails.Modules)
-	if err != nil {
-		return nil, err
+	scheduler.JobRegistry = runtimeConfig.JobRegistry
+	scheduler.ModuleHashes = outputGraph.ModuleHashes()
+	scheduler.JobCosts = jobCostStore
+	scheduler.Checkpointer = checkpointer
+	scheduler.RetryPolicy = runtimeConfig.RetryPolicy
+	scheduler.CircuitBreaker = runtimeConfig.CircuitBreaker
+	if runtimeConfig.ProgressEstimateInterval != 0 {
+		scheduler.Progress = work.NewProgressEstimator(plan)
+		scheduler.ProgressInterval = runtimeConfig.ProgressEstimateInterval
+	}
+	if runtimeConfig.Speculation != nil {
+		scheduler.Stragglers = work.NewStragglerDetector(*runtimeConfig.Speculation, jobCosts)
 	}
 
 	squasher, err := NewMultiSquasher(ctx, runtimeConfig, plan.ModulesStateMap, storeConfigs, storeLinearHandoffBlockNum, scheduler.OnStoreCompletedUntilBlock)
@@ -108,23 +163,107 @@ func BuildParallelProcessor(
 	}
 
 	scheduler.OnStoreJobTerminated = squasher.Squash
+	if execOutputReader != nil {
+		// The outputs of the mappers streamed are produced by tier2 jobs, read as soon as they complete
+		scheduler.OnMapJobTerminated = func(moduleName string, _ *block.Range) {
+			if outputGraph.IsOutputModule(moduleName) || outputGraph.IsExtraOutputModule(moduleName) {
+				execOutputReader.NotifyOutputsWritten()
+			}
+		}
+	}
 
-	runnerPool := work.NewWorkerPool(ctx, runtimeConfig.ParallelSubrequests, runtimeConfig.WorkerFactory)
+	var runnerPool work.WorkerPool
+	if slots := runtimeConfig.WorkerSlots; slots != nil {
+		userID := authenticator.GetCredentials(ctx).GetUserID()
+		if err := slots.ValidateUser(userID); err != nil {
+			return nil, err
+		}
+		nearHead := slots.IsNearHead(reqDetails.ResolvedStartBlockNum, reqDetails.RecentFinalBlockNum)
+		runnerPool = work.NewFairShareWorkerPool(ctx, runtimeConfig.ParallelSubrequests, runtimeConfig.WorkerFactory, slots, userID, nearHead)
+	} else {
+		runnerPool = work.NewWorkerPool(ctx, runtimeConfig.ParallelSubrequests, runtimeConfig.WorkerFactory)
+	}
 
 	return &ParallelProcessor{
 		plan:             plan,
 		scheduler:        scheduler,
 		squasher:         squasher,
+		checkpointer:     checkpointer,
+		jobRegistry:      jobRegistry,
 		workerPool:       runnerPool,
 		execOutputReader: execOutputReader,
 	}, nil
 }
 
+// cleanupAfterDeadRuns cleans up after the runs processing the same stores, for this
+// request or another one, that died before completing their backprocessing, their lease
+// having expired. Their partials that are still useful are part of the storage state
+// already, and will be squashed without running their jobs again.
+func cleanupAfterDeadRuns(ctx context.Context, checkpointer *Checkpointer, modulesStateMap storage.ModuleStorageStateMap, storeConfigs store.ConfigMap) {
+	logger := reqctx.Logger(ctx)
+	checkpoints, err := checkpointer.Load(ctx)
+	if err != nil {
+		logger.Warn("unable to load backprocessing checkpoints, skipping clean up", zap.Error(err))
+		return
+	}
+
+	dead, live := make(map[string][]*Checkpoint), make(map[string][]*Checkpoint)
+	var deadCount int
+	now := time.Now()
+	for name, moduleCheckpoints := range checkpoints {
+		for _, checkpoint := range moduleCheckpoints {
+			if checkpoint.Expired(now) {
+				dead[name] = append(dead[name], checkpoint)
+				deadCount++
+			} else {
+				live[name] = append(live[name], checkpoint)
+			}
+		}
+	}
+	if deadCount == 0 {
+		return
+	}
+
+	logger.Info("cleaning up after dead backprocessing runs", zap.Int("dead_checkpoints", deadCount))
+	cleanupOrphanedPartials(ctx, dead, live, modulesStateMap, storeConfigs)
+	for _, moduleCheckpoints := range dead {
+		for _, checkpoint := range moduleCheckpoints {
+			if err := checkpointer.Remove(ctx, checkpoint); err != nil {
+				logger.Warn("unable to delete checkpoint of dead run", zap.String("owner", checkpoint.Owner), zap.Error(err))
+			}
+		}
+	}
+}
+
+// loadJobCosts returns the job costs observed for the modules to process. Modules whose
+// costs cannot be loaded are split in fixed size jobs.
+func loadJobCosts(ctx context.Context, jobCostStore *jobcost.Store, modulesStateMap storage.ModuleStorageStateMap, outputGraph *outputmodules.Graph) map[string]jobcost.Observations {
+	out := make(map[string]jobcost.Observations)
+	for _, moduleName := range modulesStateMap.Names() {
+		observations, err := jobCostStore.Load(ctx, outputGraph.ModuleHashes().Get(moduleName))
+		if err != nil {
+			reqctx.Logger(ctx).Warn("unable to load job costs", zap.String("module", moduleName), zap.Error(err))
+			continue
+		}
+		out[moduleName] = observations
+	}
+	return out
+}
+
 func (b *ParallelProcessor) Run(ctx context.Context) (storeMap store.Map, err error) {
 	if b.execOutputReader != nil {
 		b.execOutputReader.Launch(ctx)
 	}
 	b.squasher.Launch(ctx)
+	if b.jobRegistry != nil {
+		// a failed run does not squash all the partials of its shared jobs
+		defer b.jobRegistry.ReleaseAll()
+	}
+	if b.checkpointer != nil {
+		b.checkpointer.Launch(ctx)
+		// a failed run leaves its checkpoint, for the next one to clean up after it
+		defer b.checkpointer.Stop()
+	}
 
 	if err := b.scheduler.Schedule(ctx, b.workerPool); err != nil {
 		return nil, fmt.Errorf("scheduler run: %w", err)
@@ -135,6 +274,12 @@ func (b *ParallelProcessor) Run(ctx context.Context) (storeMap store.Map, err er
 		return nil, err
 	}
 
+	if b.checkpointer != nil {
+		if err := b.checkpointer.Delete(ctx); err != nil {
+			reqctx.Logger(ctx).Warn("unable to delete backprocessing checkpoint", zap.Error(err))
+		}
+	}
+
 	if b.execOutputReader != nil {
 		select {
 		case <-b.execOutputReader.Terminated():
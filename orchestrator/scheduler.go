@@ -2,19 +2,24 @@ package orchestrator
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
-	"github.com/streamingfast/derr"
 	"go.uber.org/zap"
 
 	"github.com/streamingfast/substreams"
 	"github.com/streamingfast/substreams/block"
 	"github.com/streamingfast/substreams/manifest"
+	"github.com/streamingfast/substreams/metrics"
 	"github.com/streamingfast/substreams/orchestrator/work"
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"github.com/streamingfast/substreams/reqctx"
+	execoutState "github.com/streamingfast/substreams/storage/execout/state"
+	"github.com/streamingfast/substreams/storage/jobcost"
 )
 
 type Scheduler struct {
@@ -28,6 +33,38 @@ type Scheduler struct {
 	currentJobs     map[string]*work.Job
 
 	OnStoreJobTerminated func(ctx context.Context, moduleName string, partialsWritten block.Ranges) error
+	// OnMapJobTerminated, if set, is called when a job of a mapper completes, its outputs files being written
+	OnMapJobTerminated func(moduleName string, jobRange *block.Range)
+
+	// JobRegistry, when set along with ModuleHashes, shares the jobs of this
+	// scheduler with the ones of concurrent requests for the same module hash
+	// and range, so that they run only once.
+	JobRegistry  work.JobRegistry
+	ModuleHashes *manifest.ModuleHashes
+
+	// JobCosts, when set along with ModuleHashes, records the time taken by each
+	// completed job, for later plans to size their jobs from it
+	JobCosts *jobcost.Store
+
+	// Checkpointer, when set, saves the jobs in flight and the partials they wrote
+	Checkpointer *Checkpointer
+
+	// RetryPolicy tells how jobs failing because of the infrastructure are run again
+	RetryPolicy work.RetryPolicy
+	// CircuitBreaker, when set, stops sending jobs to a tier2 endpoint failing repeatedly
+	CircuitBreaker *work.CircuitBreaker
+
+	// Stragglers, when set, detects the jobs taking too long, for a free worker to run
+	// a duplicate of them, the first one completing being the one kept
+	Stragglers *work.StragglerDetector
+
+	// Progress, when set, estimates when the backprocessing completes, the estimates
+	// being sent to the client every ProgressInterval
+	Progress         *work.ProgressEstimator
+	ProgressInterval time.Duration
+
+	attemptsLock sync.Mutex
+	attempts     map[*work.Job]*jobAttempts
 }
 
 func NewScheduler(workPlan *work.Plan, respFunc substreams.ResponseFunc, upstreamRequestModules *pbsubstreams.Modules) *Scheduler {
@@ -36,6 +73,8 @@ func NewScheduler(workPlan *work.Plan, respFunc substreams.ResponseFunc, upstrea
 		respFunc:               respFunc,
 		upstreamRequestModules: upstreamRequestModules,
 		currentJobs:            make(map[string]*work.Job),
+		attempts:               make(map[*work.Job]*jobAttempts),
+		RetryPolicy:            work.DefaultRetryPolicy(),
 	}
 }
 
@@ -74,7 +113,45 @@ func (s *Scheduler) Schedule(ctx context.Context, pool work.WorkerPool) (err err
 		logger.Debug("result channel closed")
 	}()
 
-	return s.gatherResults(ctx, result)
+	if s.Progress != nil && s.ProgressInterval != 0 {
+		done := make(chan struct{})
+		defer close(done)
+		go s.sendProgressEstimates(ctx, done)
+	}
+
+	if err := s.gatherResults(ctx, result); err != nil {
+		return err
+	}
+
+	if s.Progress != nil {
+		s.sendProgressEstimate(ctx)
+	}
+	return nil
+}
+
+func (s *Scheduler) sendProgressEstimates(ctx context.Context, done <-chan struct{}) {
+	ticker := time.NewTicker(s.ProgressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-done:
+			return
+		case <-ticker.C:
+			s.sendProgressEstimate(ctx)
+		}
+	}
+}
+
+func (s *Scheduler) sendProgressEstimate(ctx context.Context) {
+	resp := &pbsubstreamsrpc.Response{
+		Message: &pbsubstreamsrpc.Response_Progress{Progress: s.Progress.ModulesProgress(time.Now())},
+	}
+	if err := s.respFunc(resp); err != nil {
+		reqctx.Logger(ctx).Debug("unable to send progress estimate", zap.Error(err))
+	}
 }
 
 func jobsSummary(jobs map[string]*work.Job) (out []string) {
@@ -85,54 +162,176 @@ func jobsSummary(jobs map[string]*work.Job) (out []string) {
 }
 
 func (s *Scheduler) run(ctx context.Context, wg *sync.WaitGroup, result chan jobResult, pool work.WorkerPool) (finished bool) {
-	worker := pool.Borrow(ctx)
-	if worker == nil {
+	// The job is found before borrowing a worker, for this request not to hold one
+	// of the worker slots shared across requests while waiting on its dependencies
+	nextJob, straggling := s.getNextJob(ctx)
+	if nextJob == nil && !straggling {
 		return true
 	}
 
-	nextJob := s.getNextJob(ctx)
-	if nextJob == nil {
-		return true
+	if straggling {
+		worker := pool.Borrow(ctx)
+		if worker == nil {
+			return true
+		}
+
+		// The straggler may have completed while we were waiting for a worker
+		straggler := s.nextStraggler(work.EndpointOf(worker))
+		if straggler == nil {
+			pool.Return(worker)
+			return false
+		}
+		s.runSpeculativeJob(ctx, wg, worker, straggler, pool)
+		return false
+	}
+
+	// A job already running for another request is waited for without borrowing a
+	// worker, for it not to hold one of our workers and worker slots doing nothing
+	sharedJob, leader := s.attachJob(nextJob)
+	var worker work.Worker
+	if leader {
+		if worker = pool.Borrow(ctx); worker == nil {
+			if sharedJob != nil {
+				sharedJob.Complete(&work.Result{Error: borrowError(ctx)})
+			}
+			return true
+		}
 	}
 
 	wg.Add(1)
 	s.submittedJobs = append(s.submittedJobs, nextJob)
-	s.currentJobsLock.Lock()
-	reqctx.Logger(ctx).Debug("current running jobs", zap.Strings("jobs", jobsSummary(s.currentJobs)))
-	s.currentJobs[worker.ID()] = nextJob
-	s.currentJobsLock.Unlock()
+	if worker != nil {
+		s.currentJobsLock.Lock()
+		reqctx.Logger(ctx).Debug("current running jobs", zap.Strings("jobs", jobsSummary(s.currentJobs)))
+		s.currentJobs[worker.ID()] = nextJob
+		s.currentJobsLock.Unlock()
+	}
+	if s.Checkpointer != nil {
+		s.Checkpointer.JobStarted(nextJob.ModuleName, nextJob.RequestRange)
+	}
+	if s.Progress != nil {
+		s.Progress.JobStarted(nextJob, time.Now())
+	}
 	go func() {
-		jr := s.runSingleJob(ctx, worker, nextJob, s.upstreamRequestModules)
+		jr := s.runSingleJob(ctx, pool, worker, sharedJob, nextJob, s.upstreamRequestModules)
+		if s.Progress != nil {
+			s.Progress.JobEnded(nextJob, jr.err == nil)
+		}
+		if s.Checkpointer != nil && jr.err == nil {
+			s.Checkpointer.JobTerminated(nextJob.ModuleName, nextJob.RequestRange, jr.partialsWritten)
+		}
 		select {
 		case <-ctx.Done():
 		case result <- jr:
 		}
-		s.currentJobsLock.Lock()
-		delete(s.currentJobs, worker.ID())
-		s.currentJobsLock.Unlock()
+		if worker != nil {
+			s.currentJobsLock.Lock()
+			delete(s.currentJobs, worker.ID())
+			s.currentJobsLock.Unlock()
 
-		pool.Return(worker)
+			pool.Return(worker)
+		}
 		wg.Done()
 	}()
 
 	return false
 }
 
-func (s *Scheduler) getNextJob(ctx context.Context) (nextJob *work.Job) {
+// getNextJob returns the next job to run or, when none is ready, if a job is
+// straggling, for a duplicate of it to be run
+func (s *Scheduler) getNextJob(ctx context.Context) (nextJob *work.Job, straggling bool) {
 	for {
 		if ctx.Err() != nil {
-			return nil
+			return nil, false
 		}
 		nextJob, moreJobs := s.workPlan.NextJob()
 		if nextJob != nil {
-			return nextJob
+			return nextJob, false
 		}
-		if moreJobs {
+		if s.hasStraggler() {
+			return nil, true
+		}
+		if moreJobs || s.mayStraggle() {
 			time.Sleep(1 * time.Second)
 			continue
 		}
+		return nil, false
+	}
+}
+
+// hasStraggler tells if a job is straggling without a duplicate yet
+func (s *Scheduler) hasStraggler() bool {
+	if s.Stragglers == nil {
+		return false
+	}
+
+	s.attemptsLock.Lock()
+	defer s.attemptsLock.Unlock()
+	for _, attempts := range s.attempts {
+		if attempts.canSpeculate() && s.Stragglers.IsStraggling(attempts.job, time.Since(attempts.started)) {
+			return true
+		}
+	}
+	return false
+}
+
+// nextStraggler returns the job running for the longest time among the straggling
+// ones without a duplicate yet, registering a duplicate attempt for it. The jobs
+// running on another endpoint than `endpoint`, the one of the worker running the
+// duplicate, come first, for the duplicate not to be slowed down the same way.
+func (s *Scheduler) nextStraggler(endpoint string) *jobAttempts {
+	if s.Stragglers == nil {
 		return nil
 	}
+
+	s.attemptsLock.Lock()
+	defer s.attemptsLock.Unlock()
+
+	var candidates []*jobAttempts
+	for _, attempts := range s.attempts {
+		if s.Stragglers.IsStraggling(attempts.job, time.Since(attempts.started)) {
+			candidates = append(candidates, attempts)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if sameI, sameJ := candidates[i].endpoint == endpoint, candidates[j].endpoint == endpoint; sameI != sameJ {
+			return sameJ
+		}
+		return candidates[i].started.Before(candidates[j].started)
+	})
+	for _, attempts := range candidates {
+		if attempts.speculate() {
+			return attempts
+		}
+	}
+	return nil
+}
+
+// mayStraggle tells if jobs are still running, that could end up straggling
+func (s *Scheduler) mayStraggle() bool {
+	if s.Stragglers == nil {
+		return false
+	}
+
+	s.currentJobsLock.Lock()
+	defer s.currentJobsLock.Unlock()
+	return len(s.currentJobs) != 0
+}
+
+func (s *Scheduler) runSpeculativeJob(ctx context.Context, wg *sync.WaitGroup, worker work.Worker, straggler *jobAttempts, pool work.WorkerPool) {
+	reqctx.Logger(ctx).Info("job straggling, running a duplicate",
+		zap.Object("job", straggler.job),
+		zap.Duration("running_for", time.Since(straggler.started)),
+		zap.String("endpoint", work.EndpointOf(worker)),
+		zap.Bool("same_endpoint", work.EndpointOf(worker) == straggler.endpoint),
+	)
+
+	wg.Add(1)
+	go func() {
+		straggler.record(s.runJob(straggler.ctx, worker, straggler.job, s.upstreamRequestModules))
+		pool.Return(worker)
+		wg.Done()
+	}()
 }
 func (s *Scheduler) gatherResults(ctx context.Context, result chan jobResult) (err error) {
 	for {
@@ -165,6 +364,12 @@ func (s *Scheduler) processJobResult(ctx context.Context, result jobResult) erro
 		}
 	}
 
+	if s.OnMapJobTerminated != nil {
+		if _, isMapper := s.workPlan.ModulesStateMap[result.job.ModuleName].(*execoutState.ExecOutputStorageState); isMapper {
+			s.OnMapJobTerminated(result.job.ModuleName, result.job.RequestRange)
+		}
+	}
+
 	return nil
 }
 
@@ -173,39 +378,138 @@ func (s *Scheduler) processJobResult(ctx context.Context, result jobResult) erro
 //
 // This should unlock all jobs that were dependent
 func (s *Scheduler) OnStoreCompletedUntilBlock(storeName string, blockNum uint64) {
+	if s.Checkpointer != nil {
+		s.Checkpointer.StoreSquashed(storeName, blockNum)
+	}
+	if s.Progress != nil {
+		s.Progress.StoreSquashed(storeName, blockNum)
+	}
 	s.workPlan.MarkDependencyComplete(storeName, blockNum)
 }
 
-func (s *Scheduler) runSingleJob(ctx context.Context, worker work.Worker, job *work.Job, requestModules *pbsubstreams.Modules) jobResult {
+// attachJob attaches `job` to the identical job of other requests, `leader` telling if
+// this request is the one running it. Without a registry, the request runs all its jobs.
+func (s *Scheduler) attachJob(job *work.Job) (sharedJob work.SharedJob, leader bool) {
+	if s.JobRegistry == nil || s.ModuleHashes == nil {
+		return nil, true
+	}
+	return s.JobRegistry.Attach(work.NewJobKey(s.ModuleHashes.Get(job.ModuleName), job.RequestRange))
+}
+
+// borrowError is the error of a job no worker could be borrowed for, the pools only
+// failing to lend one when the request is canceled.
+func borrowError(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return errors.New("unable to borrow a worker")
+}
+
+// runSingleJob runs `job` on `worker` when this request leads it, or else waits for the
+// identical job of another request, `worker` being nil. When that one fails, the job is
+// attached again, a worker being borrowed from `pool` if this request leads it this time.
+func (s *Scheduler) runSingleJob(ctx context.Context, pool work.WorkerPool, worker work.Worker, sharedJob work.SharedJob, job *work.Job, requestModules *pbsubstreams.Modules) jobResult {
+	if sharedJob == nil {
+		return s.runLeadingJob(ctx, worker, job, requestModules)
+	}
+
+	logger := reqctx.Logger(ctx)
+	for {
+		if worker != nil {
+			jr := s.runLeadingJob(ctx, worker, job, requestModules)
+			sharedJob.Complete(&work.Result{PartialsWritten: jr.partialsWritten, Error: jr.err})
+			return jr
+		}
+
+		logger.Info("job already running for another request, waiting for it", zap.Object("job", job))
+		result, err := sharedJob.Wait(ctx)
+		if err != nil {
+			logger.Info("job not completed", zap.Object("job", job), zap.Error(err))
+			return jobResult{err: err}
+		}
+		if result.Error == nil {
+			// Progress of the job was only sent to the leader's request
+			if err := s.respFunc(job.ProgressResponse()); err != nil {
+				return jobResult{err: fmt.Errorf("sending progress: %w", err)}
+			}
+
+			logger.Info("job completed by another request", zap.Object("job", job))
+			return jobResult{job: job, partialsWritten: result.PartialsWritten}
+		}
+
+		// The leader's request may have been canceled, try again, possibly leading this time
+		logger.Info("job failed for another request, attaching again", zap.Object("job", job), zap.Error(result.Error))
+		var leader bool
+		sharedJob, leader = s.attachJob(job)
+		if leader {
+			if worker = pool.Borrow(ctx); worker == nil {
+				err := borrowError(ctx)
+				sharedJob.Complete(&work.Result{Error: err})
+				return jobResult{err: err}
+			}
+			defer pool.Return(worker)
+		}
+	}
+}
+
+// runLeadingJob runs a job this request is the one responsible for, along with a
+// duplicate of it if it ends up straggling
+func (s *Scheduler) runLeadingJob(ctx context.Context, worker work.Worker, job *work.Job, requestModules *pbsubstreams.Modules) jobResult {
+	if s.Stragglers == nil {
+		return s.runJob(ctx, worker, job, requestModules)
+	}
+
+	attempts := newJobAttempts(ctx, job, work.EndpointOf(worker))
+	s.attemptsLock.Lock()
+	s.attempts[job] = attempts
+	s.attemptsLock.Unlock()
+	defer func() {
+		s.attemptsLock.Lock()
+		delete(s.attempts, job)
+		s.attemptsLock.Unlock()
+	}()
+
+	return attempts.wait(s.runJob(attempts.ctx, worker, job, requestModules))
+}
+
+func (s *Scheduler) runJob(ctx context.Context, worker work.Worker, job *work.Job, requestModules *pbsubstreams.Modules) jobResult {
 	logger := reqctx.Logger(ctx)
 	request := job.CreateRequest(requestModules)
+	endpoint := work.EndpointOf(worker)
 
 	var workResult *work.Result
-	var nonRetryableError error
+	var workDuration time.Duration
+	for attempt := 1; ; attempt++ {
+		err := s.CircuitBreaker.Allow(endpoint)
+		if err == nil {
+			start := time.Now()
+			workResult = worker.Work(ctx, request, s.respFunc)
+			workDuration = time.Since(start)
+			err = workResult.Error
+			s.CircuitBreaker.Record(endpoint, err)
+		}
+		if err == nil || ctx.Err() != nil {
+			break
+		}
 
-	_ = derr.RetryContext(ctx, 3, func(ctx context.Context) error {
-		if nonRetryableError != nil {
-			return nonRetryableError
+		class := work.ClassifyError(err)
+		if class != work.ErrorClassInfra || attempt >= s.RetryPolicy.MaxAttempts {
+			logger.Info("job failed", zap.Object("job", job), zap.Int("attempt", attempt), zap.Stringer("error_class", class), zap.Error(err))
+			return jobResult{err: err}
 		}
 
-		workResult = worker.Work(ctx, request, s.respFunc)
-		err := workResult.Error
+		backoff := s.RetryPolicy.Backoff(attempt)
+		circuitOpen := s.CircuitBreaker.IsOpen(endpoint)
+		logger.Info("worker failed with retryable error", zap.Object("job", job), zap.Int("attempt", attempt), zap.Duration("backoff", backoff), zap.Bool("circuit_open", circuitOpen), zap.Error(err))
+		metrics.JobRetries.Inc()
+		if err := s.respFunc(job.RetryingResponse(attempt, s.RetryPolicy.MaxAttempts, err, backoff, circuitOpen)); err != nil {
+			return jobResult{err: fmt.Errorf("sending progress: %w", err)}
+		}
 
-		switch err.(type) {
-		case *work.RetryableErr:
-			logger.Info("worker failed with retryable error", zap.Error(err))
-			return err
-		default:
-			if err != nil {
-				logger.Info("worker failed with a non-retryable error", zap.Error(err))
-			}
-			nonRetryableError = err
-			return nil
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
 		}
-	})
-	if nonRetryableError != nil {
-		logger.Info("job failed", zap.Object("job", job), zap.Error(nonRetryableError))
-		return jobResult{err: nonRetryableError}
 	}
 
 	if err := ctx.Err(); err != nil {
@@ -214,6 +518,26 @@ func (s *Scheduler) runSingleJob(ctx context.Context, worker work.Worker, job *w
 	}
 
 	jr := fromWorkResult(job, workResult)
-	logger.Info("job completed", zap.Object("job", job), zap.Error(workResult.Error))
+	logger.Info("job completed", zap.Object("job", job), zap.Duration("duration", workDuration))
+	s.recordJobCost(ctx, job, workDuration, workResult)
+	if s.Stragglers != nil {
+		s.Stragglers.Observe(job, workDuration)
+	}
 	return jr
 }
+
+func (s *Scheduler) recordJobCost(ctx context.Context, job *work.Job, duration time.Duration, result *work.Result) {
+	if s.JobCosts == nil || s.ModuleHashes == nil {
+		return
+	}
+
+	observation := &jobcost.Observation{
+		Range:        job.RequestRange,
+		Duration:     duration,
+		BytesRead:    result.BytesRead,
+		BytesWritten: result.BytesWritten,
+	}
+	if err := s.JobCosts.Record(ctx, s.ModuleHashes.Get(job.ModuleName), observation); err != nil {
+		reqctx.Logger(ctx).Warn("unable to record job cost", zap.Object("job", job), zap.Error(err))
+	}
+}
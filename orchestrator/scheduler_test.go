@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -13,8 +14,11 @@ import (
 	"github.com/streamingfast/substreams/manifest"
 	"github.com/streamingfast/substreams/orchestrator/work"
 	pbssinternal "github.com/streamingfast/substreams/pb/sf/substreams/intern/v2"
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/storage/jobcost"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -66,6 +70,70 @@ func TestSchedulerInOut(t *testing.T) {
 	)
 }
 
+func TestScheduler_OnMapJobTerminated(t *testing.T) {
+	runnerPool, inchan, outchan := testRunnerPool(1)
+	plan := work.TestPlanReadyJobs(
+		work.TestJob("B", "0-10", 1),
+		work.TestJob("C", "0-10", 0),
+	)
+	plan.ModulesStateMap = work.TestModStateMap(work.TestStoreState("B", "0-10"), work.TestMapState("C", "0-10"))
+	sched := NewScheduler(plan, func(_ substreams.ResponseFromAnyTier) error { return nil }, &pbsubstreams.Modules{Modules: manifest.NewTestModules()})
+	sched.OnStoreJobTerminated = func(_ context.Context, _ string, _ block.Ranges) error { return nil }
+
+	var mapJobs []string
+	sched.OnMapJobTerminated = func(moduleName string, jobRange *block.Range) {
+		mapJobs = append(mapJobs, fmt.Sprintf("%s:%s", moduleName, jobRange))
+	}
+	go func() {
+		for i := 0; i < 2; i++ {
+			in := <-inchan
+			outchan <- out{partialsWritten: block.ParseRanges(fmt.Sprintf("%d-%d", in.request.StartBlockNum, in.request.StopBlockNum))}
+		}
+	}()
+
+	require.NoError(t, sched.Schedule(context.Background(), runnerPool))
+	assert.Equal(t, []string{"C:[0, 10)"}, mapJobs)
+}
+
+func TestScheduler_ProgressEstimates(t *testing.T) {
+	runnerPool, inchan, outchan := testRunnerPool(1)
+	plan := work.TestPlanReadyJobs(
+		work.TestJob("B", "0-10", 1),
+		work.TestJob("B", "10-20", 0),
+	)
+
+	var lock sync.Mutex
+	var estimates []*pbsubstreamsrpc.ProgressEstimate
+	sched := NewScheduler(plan, func(resp substreams.ResponseFromAnyTier) error {
+		if progress := resp.(*pbsubstreamsrpc.Response).GetProgress(); progress.GetEstimate() != nil {
+			lock.Lock()
+			estimates = append(estimates, progress.Estimate)
+			lock.Unlock()
+		}
+		return nil
+	}, &pbsubstreams.Modules{Modules: manifest.NewTestModules()})
+	sched.OnStoreJobTerminated = func(_ context.Context, _ string, _ block.Ranges) error { return nil }
+	sched.Progress = work.NewProgressEstimator(plan)
+	sched.ProgressInterval = time.Hour
+
+	go func() {
+		for i := 0; i < 2; i++ {
+			in := <-inchan
+			outchan <- out{partialsWritten: block.ParseRanges(fmt.Sprintf("%d-%d", in.request.StartBlockNum, in.request.StopBlockNum))}
+		}
+	}()
+
+	require.NoError(t, sched.Schedule(context.Background(), runnerPool))
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.Len(t, estimates, 1, "only the final estimate, before the first tick")
+	assert.Equal(t, uint64(20), estimates[0].ProcessedBlocks)
+	assert.Equal(t, uint64(20), estimates[0].TotalBlocks)
+	assert.Zero(t, estimates[0].JobsRunning)
+	assert.Zero(t, estimates[0].JobsQueued)
+}
+
 func testRunnerPool(parallelism int) (work.WorkerPool, chan in, chan out) {
 	inchan := make(chan in)
 	outchan := make(chan out)
@@ -135,3 +203,258 @@ func testNoopRunnerPool(parallelism uint64) work.WorkerPool {
 	)
 	return runnerPool
 }
+
+func TestScheduler_WorkerSlotsReturned(t *testing.T) {
+	slots := work.NewWorkerSlots(1, 1, 0)
+	pool := work.NewFairShareWorkerPool(context.Background(), 2, func(logger *zap.Logger) work.Worker {
+		return work.NewWorkerFactoryFromFunc(func(ctx context.Context, request *pbssinternal.ProcessRangeRequest, respFunc substreams.ResponseFunc) *work.Result {
+			return &work.Result{}
+		})
+	}, slots, "user", false)
+
+	sched := NewScheduler(
+		work.TestPlanReadyJobs(work.TestJob("B", "0-10", 0), work.TestJob("B", "10-20", 0)),
+		func(_ substreams.ResponseFromAnyTier) error { return nil },
+		nil,
+	)
+	require.NoError(t, sched.Schedule(context.Background(), pool))
+
+	// the slot is free for another request once all the jobs are started
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	release, err := slots.Acquire(ctx, "other", false)
+	require.NoError(t, err)
+	release()
+}
+
+func TestScheduler_SharedJobs(t *testing.T) {
+	mods := manifest.NewTestModules()
+	modules := &pbsubstreams.Modules{Modules: mods, Binaries: []*pbsubstreams.Binary{{Type: "wasm/rust-v1", Content: []byte("code")}}}
+	graph, err := manifest.NewModuleGraph(mods)
+	require.NoError(t, err)
+	hashes := manifest.NewModuleHashes()
+	for _, module := range mods {
+		_, err := hashes.HashModule(modules, module, graph)
+		require.NoError(t, err)
+	}
+
+	var workCount int32
+	release := make(chan struct{})
+	newPool := func() work.WorkerPool {
+		return work.NewWorkerPool(context.Background(), 1, func(logger *zap.Logger) work.Worker {
+			return work.NewWorkerFactoryFromFunc(func(ctx context.Context, request *pbssinternal.ProcessRangeRequest, respFunc substreams.ResponseFunc) *work.Result {
+				atomic.AddInt32(&workCount, 1)
+				<-release
+				return &work.Result{PartialsWritten: block.ParseRanges(fmt.Sprintf("%d-%d", request.StartBlockNum, request.StopBlockNum))}
+			})
+		})
+	}
+
+	registry := &countingJobRegistry{JobRegistry: work.NewJobRegistry()}
+	newScheduler := func(progress *int32) (*Scheduler, *block.Ranges) {
+		sched := NewScheduler(
+			work.TestPlanReadyJobs(work.TestJob("B", "0-10", 0)),
+			func(_ substreams.ResponseFromAnyTier) error {
+				atomic.AddInt32(progress, 1)
+				return nil
+			},
+			modules,
+		)
+		sched.JobRegistry = registry
+		sched.ModuleHashes = hashes
+		ranges := &block.Ranges{}
+		sched.OnStoreJobTerminated = func(_ context.Context, mod string, partialsWritten block.Ranges) error {
+			*ranges = append(*ranges, partialsWritten...)
+			return nil
+		}
+		return sched, ranges
+	}
+
+	var leaderProgress, followerProgress int32
+	leader, leaderRanges := newScheduler(&leaderProgress)
+	follower, followerRanges := newScheduler(&followerProgress)
+
+	errs := make(chan error)
+	go func() { errs <- leader.Schedule(context.Background(), newPool()) }()
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&workCount) == 1 }, time.Second, time.Millisecond)
+	followerPool := &countingWorkerPool{WorkerPool: newPool()}
+	go func() { errs <- follower.Schedule(context.Background(), followerPool) }()
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&registry.attached) == 2 }, time.Second, time.Millisecond)
+
+	close(release)
+	require.NoError(t, <-errs)
+	require.NoError(t, <-errs)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&workCount))
+	assert.Equal(t, block.ParseRanges("0-10").String(), leaderRanges.String())
+	assert.Equal(t, block.ParseRanges("0-10").String(), followerRanges.String())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&followerProgress), "follower receives the job progress")
+	assert.Equal(t, int32(0), atomic.LoadInt32(&followerPool.borrowed), "follower waits without a worker")
+	assert.False(t, registry.ReleasePartial(hashes.Get("B"), block.ParseRange("0-10")))
+	assert.True(t, registry.ReleasePartial(hashes.Get("B"), block.ParseRange("0-10")))
+}
+
+type countingJobRegistry struct {
+	work.JobRegistry
+	attached int32
+}
+
+func (r *countingJobRegistry) Attach(key work.JobKey) (work.SharedJob, bool) {
+	defer atomic.AddInt32(&r.attached, 1)
+	return r.JobRegistry.Attach(key)
+}
+
+type countingWorkerPool struct {
+	work.WorkerPool
+	borrowed int32
+}
+
+func (p *countingWorkerPool) Borrow(ctx context.Context) work.Worker {
+	atomic.AddInt32(&p.borrowed, 1)
+	return p.WorkerPool.Borrow(ctx)
+}
+
+func TestScheduler_SharedJobLeaderFailed(t *testing.T) {
+	mods := manifest.NewTestModules()
+	modules := &pbsubstreams.Modules{Modules: mods, Binaries: []*pbsubstreams.Binary{{Type: "wasm/rust-v1", Content: []byte("code")}}}
+	graph, err := manifest.NewModuleGraph(mods)
+	require.NoError(t, err)
+	hashes := manifest.NewModuleHashes()
+	for _, module := range mods {
+		_, err := hashes.HashModule(modules, module, graph)
+		require.NoError(t, err)
+	}
+
+	registry := work.NewJobRegistry()
+	leaderJob, leader := registry.Attach(work.NewJobKey(hashes.Get("B"), block.ParseRange("0-10")))
+	require.True(t, leader)
+
+	pool := &countingWorkerPool{WorkerPool: work.NewWorkerPool(context.Background(), 1, func(logger *zap.Logger) work.Worker {
+		return work.NewWorkerFactoryFromFunc(func(ctx context.Context, request *pbssinternal.ProcessRangeRequest, respFunc substreams.ResponseFunc) *work.Result {
+			return &work.Result{PartialsWritten: block.ParseRanges(fmt.Sprintf("%d-%d", request.StartBlockNum, request.StopBlockNum))}
+		})
+	})}
+	sched := NewScheduler(work.TestPlanReadyJobs(work.TestJob("B", "0-10", 0)), func(_ substreams.ResponseFromAnyTier) error { return nil }, modules)
+	sched.JobRegistry = registry
+	sched.ModuleHashes = hashes
+	var squashed block.Ranges
+	sched.OnStoreJobTerminated = func(_ context.Context, mod string, partialsWritten block.Ranges) error {
+		squashed = append(squashed, partialsWritten...)
+		return nil
+	}
+
+	errs := make(chan error)
+	go func() { errs <- sched.Schedule(context.Background(), pool) }()
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&pool.borrowed), "no worker borrowed while following")
+
+	// the other request failed, this one leads the job and borrows a worker to run it
+	leaderJob.Complete(&work.Result{Error: context.Canceled})
+	require.NoError(t, <-errs)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&pool.borrowed))
+	assert.Equal(t, block.ParseRanges("0-10").String(), squashed.String())
+}
+
+func TestScheduler_SpeculativeJobs(t *testing.T) {
+	var workCount int32
+	canceled := make(chan struct{})
+	pool := work.NewWorkerPool(context.Background(), 2, func(logger *zap.Logger) work.Worker {
+		return work.NewWorkerFactoryFromFunc(func(ctx context.Context, request *pbssinternal.ProcessRangeRequest, respFunc substreams.ResponseFunc) *work.Result {
+			if atomic.AddInt32(&workCount, 1) == 1 {
+				// the straggler, until its duplicate completes
+				<-ctx.Done()
+				close(canceled)
+				return &work.Result{Error: ctx.Err()}
+			}
+			return &work.Result{PartialsWritten: block.ParseRanges(fmt.Sprintf("%d-%d", request.StartBlockNum, request.StopBlockNum))}
+		})
+	})
+
+	sched := NewScheduler(work.TestPlanReadyJobs(work.TestJob("B", "0-10", 0)), func(_ substreams.ResponseFromAnyTier) error { return nil }, nil)
+	sched.Stragglers = work.NewStragglerDetector(
+		work.SpeculationPolicy{Factor: 2, MinDuration: 10 * time.Millisecond},
+		map[string]jobcost.Observations{"B": {{Range: block.NewRange(0, 10), Duration: time.Millisecond}}},
+	)
+	var squashed block.Ranges
+	sched.OnStoreJobTerminated = func(_ context.Context, mod string, partialsWritten block.Ranges) error {
+		select {
+		case <-canceled:
+		default:
+			t.Error("partials squashed while the straggler is still running")
+		}
+		squashed = append(squashed, partialsWritten...)
+		return nil
+	}
+
+	require.NoError(t, sched.Schedule(context.Background(), pool))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&workCount))
+	assert.Equal(t, block.ParseRanges("0-10").String(), squashed.String())
+}
+
+func TestScheduler_nextStragglerPrefersOtherEndpoints(t *testing.T) {
+	sched := NewScheduler(work.TestPlanReadyJobs(), func(_ substreams.ResponseFromAnyTier) error { return nil }, nil)
+	sched.Stragglers = work.NewStragglerDetector(
+		work.SpeculationPolicy{Factor: 2, MinDuration: time.Millisecond},
+		map[string]jobcost.Observations{"B": {{Range: block.NewRange(0, 10), Duration: time.Millisecond}}},
+	)
+
+	oldest := newJobAttempts(context.Background(), work.TestJob("B", "0-10", 0), "tier2-a")
+	oldest.started = time.Now().Add(-time.Minute)
+	other := newJobAttempts(context.Background(), work.TestJob("B", "10-20", 0), "tier2-b")
+	other.started = time.Now().Add(-time.Second)
+	sched.attempts[oldest.job] = oldest
+	sched.attempts[other.job] = other
+
+	assert.Same(t, other, sched.nextStraggler("tier2-a"), "straggler on another endpoint first")
+	assert.Same(t, oldest, sched.nextStraggler("tier2-a"), "then on the same endpoint")
+	assert.Nil(t, sched.nextStraggler("tier2-a"), "duplicates already running")
+}
+
+func TestScheduler_RetryPolicy(t *testing.T) {
+	runJob := func(errs ...error) (jobResult, []*pbsubstreamsrpc.ModuleProgress_Retrying, int) {
+		var calls int
+		worker := work.NewWorkerFactoryFromFunc(func(ctx context.Context, request *pbssinternal.ProcessRangeRequest, respFunc substreams.ResponseFunc) *work.Result {
+			calls++
+			if calls <= len(errs) {
+				return &work.Result{Error: errs[calls-1]}
+			}
+			return &work.Result{PartialsWritten: block.ParseRanges("0-10")}
+		})
+
+		var retries []*pbsubstreamsrpc.ModuleProgress_Retrying
+		sched := NewScheduler(nil, func(resp substreams.ResponseFromAnyTier) error {
+			for _, module := range resp.(*pbsubstreamsrpc.Response).GetProgress().GetModules() {
+				retries = append(retries, module.GetRetrying())
+			}
+			return nil
+		}, nil)
+		sched.RetryPolicy = work.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Multiplier: 2}
+		sched.CircuitBreaker = work.NewCircuitBreaker(2, time.Hour)
+
+		jr := sched.runJob(context.Background(), worker, work.TestJob("B", "0-10", 0), nil)
+		return jr, retries, calls
+	}
+
+	infraErr := work.NewRetryableErr(fmt.Errorf("receiving stream resp: unavailable"))
+
+	jr, retries, calls := runJob(infraErr)
+	require.NoError(t, jr.err)
+	assert.Equal(t, 2, calls)
+	require.Len(t, retries, 1)
+	assert.Equal(t, uint32(1), retries[0].Attempt)
+	assert.Equal(t, uint32(3), retries[0].MaxAttempts)
+	assert.Equal(t, uint64(10), retries[0].EndBlock)
+	assert.False(t, retries[0].CircuitOpen)
+
+	// the circuit opens after the second failure, the last attempt is not sent
+	jr, retries, calls = runJob(infraErr, infraErr)
+	assert.Equal(t, work.ErrCircuitOpen, jr.err)
+	assert.Equal(t, 2, calls)
+	require.Len(t, retries, 2)
+	assert.True(t, retries[1].CircuitOpen)
+
+	jr, retries, calls = runJob(fmt.Errorf("module B failed on host: panic"))
+	assert.EqualError(t, jr.err, "module B failed on host: panic")
+	assert.Equal(t, 1, calls, "module failures are not retried")
+	assert.Len(t, retries, 0)
+}
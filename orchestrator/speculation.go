@@ -0,0 +1,100 @@
+package orchestrator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/streamingfast/substreams/orchestrator/work"
+)
+
+// jobAttempts tracks the attempts of a job: its original run and, once straggling,
+// a speculative duplicate of it. The first attempt to succeed wins and cancels the
+// other one. As both write the same partial files, with the same content, the result
+// is only delivered when no attempt is running anymore, for the partials to be
+// squashed once, after the last write this request waited for.
+//
+// An attempt is over once its call to tier2 returns, which doesn't stop the tier2
+// running a canceled attempt right away: it may still write its partials after they
+// were squashed and deleted. Such a late partial has the content of the one squashed:
+// later requests either find it covered by a full store and leave it (see
+// `PartialsOrphaned`), or squash it in place of running the job again.
+type jobAttempts struct {
+	job     *work.Job
+	started time.Time
+	ctx     context.Context
+	cancel  context.CancelFunc
+	// endpoint of the worker running the original attempt
+	endpoint string
+
+	mu         sync.Mutex
+	running    int
+	speculated bool
+	won        bool
+	result     jobResult
+	over       chan struct{}
+}
+
+func newJobAttempts(ctx context.Context, job *work.Job, endpoint string) *jobAttempts {
+	ctx, cancel := context.WithCancel(ctx)
+	return &jobAttempts{
+		job:      job,
+		started:  time.Now(),
+		ctx:      ctx,
+		cancel:   cancel,
+		endpoint: endpoint,
+		running:  1,
+		over:     make(chan struct{}),
+	}
+}
+
+// canSpeculate tells if a duplicate attempt can still be registered
+func (a *jobAttempts) canSpeculate() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.canSpeculateLocked()
+}
+
+func (a *jobAttempts) canSpeculateLocked() bool {
+	return !a.speculated && !a.won && a.running != 0
+}
+
+// speculate registers a duplicate attempt, false if there is already one or the job is over
+func (a *jobAttempts) speculate() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.canSpeculateLocked() {
+		return false
+	}
+	a.speculated = true
+	a.running++
+	return true
+}
+
+func (a *jobAttempts) record(jr jobResult) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.won {
+		a.result = jr
+		if jr.err == nil {
+			a.won = true
+			a.cancel()
+		}
+	}
+
+	a.running--
+	if a.running == 0 {
+		close(a.over)
+	}
+}
+
+// wait records the result of the original attempt and returns the result of the
+// job once all its attempts are over
+func (a *jobAttempts) wait(jr jobResult) jobResult {
+	a.record(jr)
+	<-a.over
+	a.cancel()
+	return a.result
+}
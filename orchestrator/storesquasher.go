@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"runtime"
 	"sort"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 
 	"github.com/streamingfast/substreams/block"
 	"github.com/streamingfast/substreams/metrics"
+	"github.com/streamingfast/substreams/orchestrator/work"
 	"github.com/streamingfast/substreams/reqctx"
 )
 
@@ -37,6 +39,15 @@ type StoreSquasher struct {
 	storeSaveInterval uint64
 
 	onStoreCompletedUntilBlock func(storeName string, blockNum uint64)
+
+	// jobRegistry, when set, tells whether partials written by jobs shared
+	// with other requests are still needed by them
+	jobRegistry work.JobRegistry
+
+	// mergeBudget, when not 0, squashes the ranges ready in batches, loading
+	// their partials concurrently up to that many bytes and merging them in trees.
+	// Only set for stores whose merges are associative.
+	mergeBudget uint64
 }
 
 func NewStoreSquasher(
@@ -203,6 +214,13 @@ func (s *StoreSquasher) processRanges(ctx context.Context, eg *llerrgroup.Group)
 			return out, nil
 		}
 
+		if batch := s.mergeableBatch(); len(batch) > 1 {
+			if err := s.processBatch(ctx, eg, out, batch); err != nil {
+				return nil, fmt.Errorf("process batch %s: %w", block.NewRange(batch[0].StartBlock, batch[len(batch)-1].ExclusiveEndBlock), err)
+			}
+			continue
+		}
+
 		squashableRange := s.ranges[0]
 		err := s.processRange(ctx, eg, squashableRange)
 		if err == SkipRange {
@@ -211,20 +229,27 @@ func (s *StoreSquasher) processRanges(ctx context.Context, eg *llerrgroup.Group)
 		if err != nil {
 			return nil, fmt.Errorf("process range %s: %w", squashableRange.String(), err)
 		}
-		// This will inform the scheduler that this range has progressed, as it affects jobs dependending on it
-		s.onStoreCompletedUntilBlock(s.name, squashableRange.ExclusiveEndBlock)
+		s.completeRanges(ctx, out, 1)
+	}
+	return out, nil
+}
 
-		out.squashCount++
+// completeRanges removes the first `count` ranges, squashed, and signals the progress
+func (s *StoreSquasher) completeRanges(ctx context.Context, out *rangeProgress, count int) {
+	squashedUpTo := s.ranges[count-1].ExclusiveEndBlock
 
-		s.ranges = s.ranges[1:]
+	// This will inform the scheduler that this range has progressed, as it affects jobs dependending on it
+	s.onStoreCompletedUntilBlock(s.name, squashedUpTo)
 
-		if squashableRange.ExclusiveEndBlock == s.targetExclusiveEndBlock {
-			s.targetExclusiveEndBlockReach = true
-		}
-		logger.Debug("signaling the jobs planner that we completed", zap.String("module", s.name), zap.Uint64("end_block", squashableRange.ExclusiveEndBlock))
-		out.lastExclusiveEndBlock = squashableRange.ExclusiveEndBlock
+	out.squashCount += uint64(count)
+
+	s.ranges = s.ranges[count:]
+
+	if squashedUpTo == s.targetExclusiveEndBlock {
+		s.targetExclusiveEndBlockReach = true
 	}
-	return out, nil
+	s.logger(ctx).Debug("signaling the jobs planner that we completed", zap.String("module", s.name), zap.Uint64("end_block", squashedUpTo))
+	out.lastExclusiveEndBlock = squashedUpTo
 }
 
 func (s *StoreSquasher) processRange(ctx context.Context, eg *llerrgroup.Group, squashableRange *block.Range) error {
@@ -266,12 +291,7 @@ func (s *StoreSquasher) processRange(ctx context.Context, eg *llerrgroup.Group,
 	logger.Debug("store merge", zap.Object("store", s.store))
 	s.nextExpectedStartBlock = squashableRange.ExclusiveEndBlock
 
-	if reqctx.Details(ctx).ProductionMode || squashableRange.ExclusiveEndBlock%s.storeSaveInterval == 0 {
-		logger.Info("deleting store", zap.Stringer("store", nextStore))
-		eg.Go(func() error {
-			return nextStore.DeleteStore(ctx, squashableRange.ExclusiveEndBlock)
-		})
-	}
+	s.deletePartial(ctx, eg, nextStore, squashableRange)
 
 	if s.shouldSaveFullKV(s.store.InitialBlock(), squashableRange) {
 		saveTime := time.Now()
@@ -298,6 +318,175 @@ func (s *StoreSquasher) processRange(ctx context.Context, eg *llerrgroup.Group,
 	return nil
 }
 
+// mergeableBatch returns the contiguous ranges ready to be squashed, when squashing them in batches
+func (s *StoreSquasher) mergeableBatch() block.Ranges {
+	if s.mergeBudget == 0 || s.ranges[0].StartBlock != s.nextExpectedStartBlock {
+		return nil
+	}
+
+	end := 1
+	for end < len(s.ranges) && s.ranges[end].StartBlock == s.ranges[end-1].ExclusiveEndBlock {
+		end++
+	}
+	return s.ranges[:end]
+}
+
+// processBatch squashes the first ranges of `batch`, as many as their partials fit in
+// the memory budget. The partials between two full store snapshots are merged together
+// in a tree before being merged into the full store, the snapshots being saved as when
+// squashing the ranges one by one.
+func (s *StoreSquasher) processBatch(ctx context.Context, eg *llerrgroup.Group, out *rangeProgress, batch block.Ranges) error {
+	logger := s.logger(ctx)
+
+	startTime := time.Now()
+	partials, err := s.loadPartials(ctx, batch)
+	if err != nil {
+		return err
+	}
+	batch = batch[:len(partials)]
+	logger.Info("partials loaded for batch",
+		zap.Int("partial_count", len(partials)),
+		zap.Stringer("first_range", batch[0]),
+		zap.String("load_time", time.Since(startTime).String()),
+	)
+
+	for len(partials) != 0 {
+		segment := 1
+		for segment < len(partials) && !s.shouldSaveFullKV(s.store.InitialBlock(), batch[segment-1]) {
+			segment++
+		}
+		lastRange := batch[segment-1]
+
+		mergeTime := time.Now()
+		merged, err := mergeTree(partials[:segment])
+		if err != nil {
+			return fmt.Errorf("merging partials %s: %w", batch[:segment], err)
+		}
+		if err := s.store.Merge(merged); err != nil {
+			return fmt.Errorf("merging: %w", err)
+		}
+		mergeTimeTook := time.Since(mergeTime)
+		s.nextExpectedStartBlock = lastRange.ExclusiveEndBlock
+
+		for i, partial := range partials[:segment] {
+			s.deletePartial(ctx, eg, partial, batch[i])
+		}
+
+		if s.shouldSaveFullKV(s.store.InitialBlock(), lastRange) {
+			saveTime := time.Now()
+			_, writer, err := s.store.Save(lastRange.ExclusiveEndBlock)
+			if err != nil {
+				return fmt.Errorf("save full store: %w", err)
+			}
+			eg.Go(func() error {
+				return writer.Write(ctx)
+			})
+			logger.Info(
+				"squashing time metrics",
+				zap.Int("merged_partial_count", segment),
+				zap.String("merge_time", mergeTimeTook.String()),
+				zap.String("save_time", time.Since(saveTime).String()),
+			)
+		}
+
+		s.completeRanges(ctx, out, segment)
+		batch = batch[segment:]
+		partials = partials[segment:]
+	}
+	return nil
+}
+
+// loadPartials loads concurrently the partials of the first ranges of `batch`, at least
+// one group of them and then as long as their total size is below the memory budget
+func (s *StoreSquasher) loadPartials(ctx context.Context, batch block.Ranges) ([]*store.PartialKV, error) {
+	concurrency := runtime.NumCPU()
+
+	var partials []*store.PartialKV
+	var loadedBytes uint64
+	for len(partials) < len(batch) && loadedBytes < s.mergeBudget {
+		group := batch[len(partials):]
+		if len(group) > concurrency {
+			group = group[:concurrency]
+		}
+
+		loaded := make([]*store.PartialKV, len(group))
+		eg := llerrgroup.New(concurrency)
+		for i, rng := range group {
+			if eg.Stop() {
+				break
+			}
+			i, rng := i, rng
+			eg.Go(func() error {
+				partial := s.store.DerivePartialStore(rng.StartBlock)
+				if err := partial.Load(ctx, rng.ExclusiveEndBlock); err != nil {
+					return fmt.Errorf("initializing partial store %q for range %s: %w", s.name, rng, err)
+				}
+				loaded[i] = partial
+				return nil
+			})
+		}
+		if err := eg.Wait(); err != nil {
+			return nil, err
+		}
+
+		for _, partial := range loaded {
+			partials = append(partials, partial)
+			loadedBytes += partial.SizeBytes()
+		}
+	}
+	return partials, nil
+}
+
+// mergeTree merges contiguous partials in pairs, concurrently, level after level,
+// until one partial covering all of them is left
+func mergeTree(partials []*store.PartialKV) (*store.PartialKV, error) {
+	for len(partials) > 1 {
+		next := make([]*store.PartialKV, (len(partials)+1)/2)
+		eg := llerrgroup.New(runtime.NumCPU())
+		for i := 0; i < len(partials); i += 2 {
+			if i+1 == len(partials) {
+				next[i/2] = partials[i]
+				break
+			}
+			if eg.Stop() {
+				break
+			}
+			i := i
+			eg.Go(func() error {
+				if err := partials[i].MergePartial(partials[i+1]); err != nil {
+					return err
+				}
+				next[i/2] = partials[i]
+				return nil
+			})
+		}
+		if err := eg.Wait(); err != nil {
+			return nil, err
+		}
+		partials = next
+	}
+	return partials[0], nil
+}
+
+// deletePartial deletes the file of a partial merged into the full store, when it is not needed anymore
+func (s *StoreSquasher) deletePartial(ctx context.Context, eg *llerrgroup.Group, partial *store.PartialKV, partialRange *block.Range) {
+	// A partial written by a job shared with other requests is deleted by the last one merging it
+	lastHolder := s.releasePartial(partialRange)
+	if lastHolder && (reqctx.Details(ctx).ProductionMode || partialRange.ExclusiveEndBlock%s.storeSaveInterval == 0) {
+		s.logger(ctx).Info("deleting store", zap.Stringer("store", partial))
+		eg.Go(func() error {
+			return partial.DeleteStore(ctx, partialRange.ExclusiveEndBlock)
+		})
+	}
+}
+
+func (s *StoreSquasher) releasePartial(partialRange *block.Range) bool {
+	if s.jobRegistry == nil {
+		return true
+	}
+	return s.jobRegistry.ReleasePartial(s.store.ModuleHash(), partialRange)
+}
+
 func (s *StoreSquasher) shouldSaveFullKV(storeInitialBlock uint64, squashableRange *block.Range) bool {
 	// we check if the squashableRange we just merged into our FullKV store, ends on a storeInterval boundary block
 	// If someone the storeSaveInterval
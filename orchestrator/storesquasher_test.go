@@ -262,3 +262,112 @@ func newPartialKVContent(t *testing.T, data map[string][]byte, kv *store2.FullKV
 	//require.NoError(t, err)
 	return ioutil.NopCloser(bytes.NewReader([]byte{}))
 }
+
+func writeTestPartials(tb testing.TB, config *store2.Config, ranges block.Ranges, keyCount int) {
+	tb.Helper()
+
+	for i, rng := range ranges {
+		partial := config.NewPartialKV(rng.StartBlock, zap.NewNop())
+		for k := 0; k < keyCount; k++ {
+			partial.SumInt64(0, fmt.Sprintf("key:%d", k), int64(i*k))
+		}
+		_, writer, err := partial.Save(rng.ExclusiveEndBlock)
+		require.NoError(tb, err)
+		require.NoError(tb, writer.Write(context.Background()))
+	}
+}
+
+func squashTestPartials(tb testing.TB, config *store2.Config, ranges block.Ranges, mergeBudget uint64) (*store2.FullKV, uint64) {
+	tb.Helper()
+
+	ctx := reqctx.WithRequest(context.Background(), &reqctx.RequestDetails{})
+	var completedUpTo uint64
+	squasher := NewStoreSquasher(config.NewFullKV(zap.NewNop()), ranges[len(ranges)-1].ExclusiveEndBlock, ranges[0].StartBlock, 10, func(_ string, blockNum uint64) {
+		completedUpTo = blockNum
+	})
+	squasher.mergeBudget = mergeBudget
+	squasher.ranges = append(block.Ranges{}, ranges...)
+
+	eg := llerrgroup.New(250)
+	_, err := squasher.processRanges(ctx, eg)
+	require.NoError(tb, err)
+	require.NoError(tb, eg.Wait())
+	require.True(tb, squasher.targetExclusiveEndBlockReach)
+	require.True(tb, squasher.IsEmpty())
+	return squasher.store, completedUpTo
+}
+
+func TestStoreSquasher_processBatch(t *testing.T) {
+	ranges := block.ParseRanges("0-10,10-20,20-25,25-30,30-40,40-45,45-47,47-50,50-60")
+
+	tests := []struct {
+		name        string
+		mergeBudget uint64
+	}{
+		{"everything at once", 1_000_000},
+		{"budget of a few partials", 100},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			squash := func(mergeBudget uint64) (*store2.FullKV, uint64, []string) {
+				objStore, err := dstore.NewStore(t.TempDir(), "", "", false)
+				require.NoError(t, err)
+				config, err := store2.NewConfig("mod", 0, "mod.hash", pbsubstreams.Module_KindStore_UPDATE_POLICY_ADD, "int64", objStore)
+				require.NoError(t, err)
+				writeTestPartials(t, config, ranges, 20)
+
+				fullKV, completedUpTo := squashTestPartials(t, config, ranges, mergeBudget)
+
+				var files []string
+				require.NoError(t, objStore.Walk(context.Background(), "", func(filename string) error {
+					files = append(files, filename)
+					return nil
+				}))
+				return fullKV, completedUpTo, files
+			}
+
+			expectedKV, expectedCompletedUpTo, expectedFiles := squash(0)
+			fullKV, completedUpTo, files := squash(test.mergeBudget)
+
+			assert.Equal(t, expectedCompletedUpTo, completedUpTo)
+			assert.Equal(t, expectedFiles, files, "same snapshots saved and partials deleted")
+			assert.Equal(t, expectedKV.Length(), fullKV.Length())
+			require.NoError(t, expectedKV.Iter(func(key string, value []byte) error {
+				actual, found := fullKV.GetLast(key)
+				assert.True(t, found, key)
+				assert.Equal(t, string(value), string(actual), key)
+				return nil
+			}))
+		})
+	}
+}
+
+func BenchmarkStoreSquasher_processRanges(b *testing.B) {
+	var ranges block.Ranges
+	for start := uint64(0); start < 640; start += 10 {
+		ranges = append(ranges, block.NewRange(start, start+5), block.NewRange(start+5, start+10))
+	}
+
+	for _, bench := range []struct {
+		name        string
+		mergeBudget uint64
+	}{
+		{"sequential", 0},
+		{"tree", 1 << 30},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				objStore, err := dstore.NewStore(b.TempDir(), "", "", false)
+				require.NoError(b, err)
+				config, err := store2.NewConfig("mod", 0, "mod.hash", pbsubstreams.Module_KindStore_UPDATE_POLICY_ADD, "int64", objStore)
+				require.NoError(b, err)
+				writeTestPartials(b, config, ranges, 2000)
+				b.StartTimer()
+
+				squashTestPartials(b, config, ranges, bench.mergeBudget)
+			}
+		})
+	}
+}
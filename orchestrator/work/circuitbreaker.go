@@ -0,0 +1,104 @@
+package work
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/streamingfast/substreams/metrics"
+)
+
+var ErrCircuitOpen = errors.New("circuit open, tier2 endpoint failing repeatedly")
+
+// CircuitBreaker stops sending jobs to a tier2 endpoint after `failureThreshold`
+// consecutive infrastructure failures. Once `cooldown` has elapsed, a single job is
+// sent to probe the endpoint: its success closes the circuit, its failure opens it
+// again for `cooldown`. It is meant to be shared by all the requests of tier1.
+type CircuitBreaker struct {
+	failureThreshold int
+	cooldown         time.Duration
+
+	mu        sync.Mutex
+	endpoints map[string]*endpointHealth
+}
+
+type endpointHealth struct {
+	consecutiveFailures int
+	openedAt            time.Time // zero while closed
+	probing             bool
+}
+
+func NewCircuitBreaker(failureThreshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		failureThreshold: failureThreshold,
+		cooldown:         cooldown,
+		endpoints:        make(map[string]*endpointHealth),
+	}
+}
+
+// Allow returns ErrCircuitOpen when no job must be sent to `endpoint`. A nil
+// breaker allows everything.
+func (b *CircuitBreaker) Allow(endpoint string) error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	health := b.endpoints[endpoint]
+	if health == nil || health.openedAt.IsZero() {
+		return nil
+	}
+	if health.probing || time.Since(health.openedAt) < b.cooldown {
+		return ErrCircuitOpen
+	}
+	health.probing = true
+	return nil
+}
+
+// Record updates the health of `endpoint` from the outcome of a job allowed to run on it
+func (b *CircuitBreaker) Record(endpoint string, err error) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	health, found := b.endpoints[endpoint]
+	if !found {
+		health = &endpointHealth{}
+		b.endpoints[endpoint] = health
+	}
+	health.probing = false
+
+	switch {
+	case err == nil:
+		health.consecutiveFailures = 0
+		health.openedAt = time.Time{}
+	case ClassifyError(err) == ErrorClassInfra:
+		health.consecutiveFailures++
+		if health.consecutiveFailures >= b.failureThreshold {
+			if health.openedAt.IsZero() {
+				metrics.CircuitBreakerOpened.Inc()
+			}
+			health.openedAt = time.Now()
+		}
+	}
+	// canceled jobs and module failures tell nothing about the endpoint, a module
+	// failing on every endpoint it is sent to
+}
+
+// IsOpen tells if jobs are not sent to `endpoint` anymore
+func (b *CircuitBreaker) IsOpen(endpoint string) bool {
+	if b == nil {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	health := b.endpoints[endpoint]
+	return health != nil && !health.openedAt.IsZero()
+}
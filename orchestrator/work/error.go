@@ -1,5 +1,14 @@
 package work
 
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
 type RetryableErr struct {
 	cause error
 }
@@ -13,3 +22,67 @@ func NewRetryableErr(cause error) *RetryableErr {
 func (r *RetryableErr) Error() string {
 	return r.cause.Error()
 }
+
+func (r *RetryableErr) Unwrap() error {
+	return r.cause
+}
+
+// ModuleExecutionErr is the failure of a module reported by tier2, running the job
+// again fails the same way
+type ModuleExecutionErr struct {
+	ModuleName string
+	Reason     string
+}
+
+func (e *ModuleExecutionErr) Error() string {
+	return fmt.Sprintf("module %s failed on host: %s", e.ModuleName, e.Reason)
+}
+
+type ErrorClass int
+
+const (
+	// ErrorClassDeterministic is a failure of the module itself on its input, running the job again fails the same way
+	ErrorClassDeterministic ErrorClass = iota
+	// ErrorClassInfra is a failure of tier2 or of the network, running the job again may succeed
+	ErrorClassInfra
+	// ErrorClassCanceled is a job canceled by tier1
+	ErrorClassCanceled
+)
+
+func (c ErrorClass) String() string {
+	switch c {
+	case ErrorClassInfra:
+		return "infra"
+	case ErrorClassCanceled:
+		return "canceled"
+	default:
+		return "deterministic"
+	}
+}
+
+// ClassifyError tells if the error of a job comes from the module or from the infrastructure running it
+func ClassifyError(err error) ErrorClass {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return ErrorClassCanceled
+	}
+
+	var moduleErr *ModuleExecutionErr
+	if errors.As(err, &moduleErr) {
+		return ErrorClassDeterministic
+	}
+
+	var retryable *RetryableErr
+	if errors.As(err, &retryable) || errors.Is(err, ErrCircuitOpen) {
+		return ErrorClassInfra
+	}
+
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.Canceled:
+			return ErrorClassCanceled
+		case codes.Unavailable, codes.ResourceExhausted, codes.Aborted, codes.DeadlineExceeded, codes.Internal:
+			return ErrorClassInfra
+		}
+	}
+	return ErrorClassDeterministic
+}
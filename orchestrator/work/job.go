@@ -3,9 +3,11 @@ package work
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/streamingfast/substreams/block"
 	pbssinternal "github.com/streamingfast/substreams/pb/sf/substreams/intern/v2"
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"go.uber.org/zap/zapcore"
 )
@@ -18,6 +20,7 @@ type Job struct {
 	// the order of the job, as a unit of job scheduling, relative to the position in the chain.
 	requiredModules []string // modules that need to be sync'd before this one starts at RequestRange.StartBlockNum}
 	priority        int
+	dependentsDepth int // length of the longest chain of modules depending on this one, itself included
 }
 
 func NewJob(storeName string, requestRange *block.Range, requiredModules []string, priority int) *Job {
@@ -47,6 +50,37 @@ func (j *Job) CreateRequest(originalModules *pbsubstreams.Modules) *pbssinternal
 	}
 }
 
+// ProgressResponse reports the whole range of the job as processed
+func (j *Job) ProgressResponse() *pbsubstreamsrpc.Response {
+	return toRPCRangeProgressResponse(j.ModuleName, j.RequestRange.StartBlock, j.RequestRange.ExclusiveEndBlock)
+}
+
+// RetryingResponse tells the client that the failed `attempt` of the job will be run again after `backoff`
+func (j *Job) RetryingResponse(attempt, maxAttempts int, reason error, backoff time.Duration, circuitOpen bool) *pbsubstreamsrpc.Response {
+	return &pbsubstreamsrpc.Response{
+		Message: &pbsubstreamsrpc.Response_Progress{
+			Progress: &pbsubstreamsrpc.ModulesProgress{
+				Modules: []*pbsubstreamsrpc.ModuleProgress{
+					{
+						Name: j.ModuleName,
+						Type: &pbsubstreamsrpc.ModuleProgress_Retrying_{
+							Retrying: &pbsubstreamsrpc.ModuleProgress_Retrying{
+								StartBlock:         j.RequestRange.StartBlock,
+								EndBlock:           j.RequestRange.ExclusiveEndBlock,
+								Attempt:            uint32(attempt),
+								MaxAttempts:        uint32(maxAttempts),
+								Reason:             reason.Error(),
+								BackoffNanoSeconds: uint64(backoff),
+								CircuitOpen:        circuitOpen,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func (j *Job) String() string {
 	return fmt.Sprintf("job: module=%s range=%s deps=%s prio=%d", j.ModuleName, j.RequestRange, strings.Join(j.requiredModules, ","), j.priority)
 }
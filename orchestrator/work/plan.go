@@ -3,7 +3,6 @@ package work
 import (
 	"context"
 	"fmt"
-	"sort"
 	"sync"
 
 	"go.uber.org/zap"
@@ -13,8 +12,10 @@ import (
 	"github.com/streamingfast/substreams/pipeline/outputmodules"
 
 	"github.com/streamingfast/substreams/storage"
+	"github.com/streamingfast/substreams/storage/jobcost"
 
 	"github.com/streamingfast/substreams"
+	"github.com/streamingfast/substreams/block"
 	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
 )
 
@@ -31,21 +32,33 @@ type Plan struct {
 	highestModuleRunningBlock map[string]uint64
 	modulesReadyUpToBlock     map[string]uint64
 
+	prioritizer Prioritizer
+	// lastServed is the module of the last job returned by NextJob
+	lastServed string
+
 	mu     sync.Mutex
 	logger *zap.Logger
 }
 
-func BuildNewPlan(ctx context.Context, modulesStateMap storage.ModuleStorageStateMap, subrequestSplitSize, upToBlock uint64, maxJobsAhead uint64, outputGraph *outputmodules.Graph) (*Plan, error) {
+// maxCostAwareSplitFactor bounds the size of the jobs over cheap block ranges, relative to the split size
+const maxCostAwareSplitFactor = 4
+
+// BuildNewPlan splits the work of each module in jobs of `subrequestSplitSize` blocks. For the modules
+// with `jobCosts` observed by previous requests, jobs are sized to last as long as an average job of
+// `subrequestSplitSize` blocks instead. Ready jobs are ordered by `prioritizer`, the default one
+// when nil.
+func BuildNewPlan(ctx context.Context, modulesStateMap storage.ModuleStorageStateMap, subrequestSplitSize, upToBlock uint64, maxJobsAhead uint64, outputGraph *outputmodules.Graph, jobCosts map[string]jobcost.Observations, prioritizer Prioritizer) (*Plan, error) {
 	logger := reqctx.Logger(ctx)
 	plan := &Plan{
 		ModulesStateMap:    modulesStateMap,
 		schedulableModules: outputGraph.SchedulableModuleNames(),
 		upToBlock:          upToBlock,
 		maxBlocksAhead:     subrequestSplitSize * (maxJobsAhead + 1),
+		prioritizer:        prioritizer,
 		logger:             logger,
 	}
 
-	if err := plan.splitWorkIntoJobs(subrequestSplitSize, outputGraph.OutputModule().Name, outputGraph.AncestorsFrom); err != nil {
+	if err := plan.splitWorkIntoJobs(subrequestSplitSize, outputGraph.OutputModule().Name, outputGraph.AncestorsFrom, jobCosts); err != nil {
 		return nil, fmt.Errorf("split to jobs: %w", err)
 	}
 
@@ -56,17 +69,18 @@ func BuildNewPlan(ctx context.Context, modulesStateMap storage.ModuleStorageStat
 	return plan, nil
 }
 
-func (p *Plan) splitWorkIntoJobs(subrequestSplitSize uint64, outputModuleName string, ancestorsFrom func(string) []string) error {
+func (p *Plan) splitWorkIntoJobs(subrequestSplitSize uint64, outputModuleName string, ancestorsFrom func(string) []string, jobCosts map[string]jobcost.Observations) error {
 
 	stepSize := calculateHighestDependencyDepth(p.schedulableModules, p.ModulesStateMap, ancestorsFrom)
 	highestJobOrdinal := int(p.upToBlock/subrequestSplitSize) * stepSize
+	dependentsDepths := descendantsDepths(p.schedulableModules, ancestorsFrom)
 
 	for _, storeName := range p.schedulableModules {
 		modState := p.ModulesStateMap[storeName]
 		if modState == nil {
 			continue
 		}
-		requests := modState.BatchRequests(subrequestSplitSize)
+		requests := batchRequests(modState, subrequestSplitSize, jobCosts[storeName])
 		for _, requestRange := range requests {
 			requiredModules := ancestorsFrom(storeName)
 			dependencyDepth := ancestorsDepth(storeName, ancestorsFrom)
@@ -86,6 +100,7 @@ func (p *Plan) splitWorkIntoJobs(subrequestSplitSize uint64, outputModuleName st
 			)
 
 			job := NewJob(storeName, requestRange, requiredModules, priority)
+			job.dependentsDepth = dependentsDepths[storeName]
 			p.waitingJobs = append(p.waitingJobs, job)
 		}
 	}
@@ -98,6 +113,19 @@ func (p *Plan) splitWorkIntoJobs(subrequestSplitSize uint64, outputModuleName st
 	return nil
 }
 
+func batchRequests(modState storage.ModuleStorageState, subrequestSplitSize uint64, observations jobcost.Observations) block.Ranges {
+	costPerBlock := observations.CostPerBlock()
+	if costPerBlock == 0 {
+		return modState.BatchRequests(subrequestSplitSize)
+	}
+
+	profile := observations.Profile()
+	targetCost := float64(costPerBlock) * float64(subrequestSplitSize)
+	return modState.BatchRequestsByCost(subrequestSplitSize*maxCostAwareSplitFactor, targetCost, func(rng *block.Range) float64 {
+		return float64(profile.Estimate(rng))
+	})
+}
+
 func ancestorsDepth(moduleName string, ancestorsFrom func(string) []string) int {
 	deepest := 1
 	for _, ancestor := range ancestorsFrom(moduleName) {
@@ -109,6 +137,38 @@ func ancestorsDepth(moduleName string, ancestorsFrom func(string) []string) int
 	return deepest
 }
 
+// descendantsDepths returns, for each of `modules`, the length of the longest chain of
+// modules depending on it, itself included. Each module is visited once, chains sharing
+// modules being common.
+func descendantsDepths(modules []string, ancestorsFrom func(string) []string) map[string]int {
+	dependents := map[string][]string{}
+	for _, module := range modules {
+		for _, ancestor := range ancestorsFrom(module) {
+			dependents[ancestor] = append(dependents[ancestor], module)
+		}
+	}
+
+	depths := map[string]int{}
+	var depthOf func(moduleName string) int
+	depthOf = func(moduleName string) int {
+		if depth, found := depths[moduleName]; found {
+			return depth
+		}
+		deepest := 1
+		for _, dependent := range dependents[moduleName] {
+			if depth := 1 + depthOf(dependent); depth > deepest {
+				deepest = depth
+			}
+		}
+		depths[moduleName] = deepest
+		return deepest
+	}
+	for _, module := range modules {
+		depthOf(module)
+	}
+	return depths
+}
+
 func calculateHighestDependencyDepth(
 	schedulableModules []string,
 	modulesStateMap storage.ModuleStorageStateMap,
@@ -231,10 +291,11 @@ func (p *Plan) allDependenciesMet(job *Job) bool {
 
 func (p *Plan) prioritize() {
 	// Called with locked mutex
-	sort.Slice(p.readyJobs, func(i, j int) bool {
-		// reverse sorts priority, higher first
-		return p.readyJobs[i].priority > p.readyJobs[j].priority
-	})
+	prioritizer := p.prioritizer
+	if prioritizer == nil {
+		prioritizer = PriorityPrioritizer{}
+	}
+	p.readyJobs = prioritizer.Sort(p.readyJobs, p.lastServed)
 }
 
 func (p *Plan) NextJob() (job *Job, more bool) {
@@ -251,6 +312,7 @@ func (p *Plan) NextJob() (job *Job, more bool) {
 	p.readyJobs = p.readyJobs[1:]
 
 	p.highestModuleRunningBlock[job.ModuleName] = job.RequestRange.ExclusiveEndBlock
+	p.lastServed = job.ModuleName
 	return job, p.hasMore()
 }
 
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -18,6 +19,7 @@ import (
 	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"github.com/streamingfast/substreams/storage"
+	"github.com/streamingfast/substreams/storage/jobcost"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -30,6 +32,7 @@ func TestWorkPlanning(t *testing.T) {
 		state          storage.ModuleStorageStateMap
 		outMod         string
 		productionMode bool
+		jobCosts       map[string]jobcost.Observations
 
 		expectWaitingJobs []*Job
 		expectReadyJobs   []*Job
@@ -63,15 +66,37 @@ func TestWorkPlanning(t *testing.T) {
 				TestJob("As", "50-60", 3),
 			},
 		},
+		{
+			name:        "cost aware",
+			upToBlock:   85,
+			subreqSplit: 20,
+			state: TestModStateMap(
+				TestStoreState("As", "0-10,10-20,20-30,30-40,40-50,50-60,60-70,70-80"),
+			),
+			jobCosts: map[string]jobcost.Observations{
+				// 0-40 is cheap, 40-60 is 10 times more expensive
+				"As": {
+					{Range: block.ParseRange("0-40"), Duration: 40 * time.Second},
+					{Range: block.ParseRange("40-60"), Duration: 200 * time.Second},
+				},
+			},
+			outMod: "As",
+			expectReadyJobs: []*Job{
+				TestJob("As", "0-40", 5),
+				TestJob("As", "40-50", 3),
+				TestJob("As", "50-60", 3),
+				TestJob("As", "60-80", 2),
+			},
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			mods := manifest.NewTestModules()
-			outputGraph, err := outputmodules.NewOutputModuleGraph(test.outMod, test.productionMode, &pbsubstreams.Modules{Modules: mods, Binaries: []*pbsubstreams.Binary{{}}})
+			outputGraph, err := outputmodules.NewOutputModuleGraph(test.outMod, nil, test.productionMode, &pbsubstreams.Modules{Modules: mods, Binaries: []*pbsubstreams.Binary{{}}})
 			require.NoError(t, err)
 
-			plan, err := BuildNewPlan(context.Background(), test.state, uint64(test.subreqSplit), test.upToBlock, 0, outputGraph)
+			plan, err := BuildNewPlan(context.Background(), test.state, uint64(test.subreqSplit), test.upToBlock, 0, outputGraph, test.jobCosts, nil)
 			require.NoError(t, err)
 
 			assert.Equal(t, jobList(test.expectWaitingJobs), jobList(plan.waitingJobs), "waiting jobs") // these are not sorted by the engine
@@ -746,3 +771,23 @@ func reduceProgressMessages(in []*pbsubstreamsrpc.ModuleProgress) string {
 	}
 	return strings.Join(out, ";")
 }
+
+func TestDescendantsDepths(t *testing.T) {
+	// 40 levels of diamonds, each module of a level depending on both modules of the previous one
+	modules := []string{"root"}
+	ancestors := map[string][]string{}
+	previous := []string{"root"}
+	for level := 1; level <= 40; level++ {
+		current := []string{fmt.Sprintf("L%d_a", level), fmt.Sprintf("L%d_b", level)}
+		for _, module := range current {
+			ancestors[module] = previous
+		}
+		modules = append(modules, current...)
+		previous = current
+	}
+
+	depths := descendantsDepths(modules, func(module string) []string { return ancestors[module] })
+	assert.Equal(t, 41, depths["root"])
+	assert.Equal(t, 21, depths["L20_a"])
+	assert.Equal(t, 1, depths["L40_b"])
+}
@@ -1,5 +1,128 @@
 package work
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Prioritizer orders the jobs ready to run, the first ones being scheduled first.
+// `lastServed` is the module of the last job scheduled, empty before the first one.
+// Prioritizers are shared by all the requests, the state of a request is kept by its plan.
 type Prioritizer interface {
-	Sort(jobs []*Job) []*Job
+	Sort(jobs []*Job, lastServed string) []*Job
+}
+
+const (
+	PrioritizerPriority         = "priority"
+	PrioritizerCriticalPath     = "critical-path"
+	PrioritizerLowestStartBlock = "lowest-start-block"
+	PrioritizerRoundRobin       = "round-robin"
+)
+
+var prioritizers = map[string]Prioritizer{
+	PrioritizerPriority:         PriorityPrioritizer{},
+	PrioritizerCriticalPath:     CriticalPathPrioritizer{},
+	PrioritizerLowestStartBlock: LowestStartBlockPrioritizer{},
+	PrioritizerRoundRobin:       RoundRobinPrioritizer{},
+}
+
+// NewPrioritizer returns the built-in prioritizer named `name`, the default
+// one when `name` is empty.
+func NewPrioritizer(name string) (Prioritizer, error) {
+	if name == "" {
+		name = PrioritizerPriority
+	}
+	prioritizer, found := prioritizers[name]
+	if !found {
+		return nil, fmt.Errorf("unknown prioritizer %q, valid ones are %s", name, strings.Join(PrioritizerNames(), ", "))
+	}
+	return prioritizer, nil
+}
+
+func PrioritizerNames() (out []string) {
+	for name := range prioritizers {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return
+}
+
+// PriorityPrioritizer runs first the jobs with the highest priority computed by the
+// plan: the lowest start blocks, the modules with the fewest ancestors first, and the
+// output module ahead of its dependencies. This is the default.
+type PriorityPrioritizer struct{}
+
+func (PriorityPrioritizer) Sort(jobs []*Job, _ string) []*Job {
+	// stable, so that jobs of the same priority run in block order: with cost-aware
+	// splitting, the jobs shorter than `subrequestSplitSize` share their priority
+	sort.SliceStable(jobs, func(i, j int) bool {
+		// reverse sorts priority, higher first
+		return jobs[i].priority > jobs[j].priority
+	})
+	return jobs
+}
+
+// CriticalPathPrioritizer runs first the jobs of the modules with the longest chain
+// of modules depending on them, as they hold back the most work, then the lowest
+// start blocks.
+type CriticalPathPrioritizer struct{}
+
+func (CriticalPathPrioritizer) Sort(jobs []*Job, _ string) []*Job {
+	sort.SliceStable(jobs, func(i, j int) bool {
+		if jobs[i].dependentsDepth != jobs[j].dependentsDepth {
+			return jobs[i].dependentsDepth > jobs[j].dependentsDepth
+		}
+		return jobs[i].RequestRange.StartBlock < jobs[j].RequestRange.StartBlock
+	})
+	return jobs
+}
+
+// LowestStartBlockPrioritizer runs first the jobs with the lowest start block,
+// whatever their module.
+type LowestStartBlockPrioritizer struct{}
+
+func (LowestStartBlockPrioritizer) Sort(jobs []*Job, _ string) []*Job {
+	sort.SliceStable(jobs, func(i, j int) bool {
+		if jobs[i].RequestRange.StartBlock != jobs[j].RequestRange.StartBlock {
+			return jobs[i].RequestRange.StartBlock < jobs[j].RequestRange.StartBlock
+		}
+		return jobs[i].ModuleName < jobs[j].ModuleName
+	})
+	return jobs
+}
+
+// RoundRobinPrioritizer alternates between modules, running the jobs of each
+// module in block order, so that all modules progress at the same pace. The
+// rotation resumes with the module following the last one served.
+type RoundRobinPrioritizer struct{}
+
+func (RoundRobinPrioritizer) Sort(jobs []*Job, lastServed string) []*Job {
+	var modules []string
+	byModule := map[string][]*Job{}
+	for _, job := range jobs {
+		if _, found := byModule[job.ModuleName]; !found {
+			modules = append(modules, job.ModuleName)
+		}
+		byModule[job.ModuleName] = append(byModule[job.ModuleName], job)
+	}
+	sort.Strings(modules)
+	next := sort.SearchStrings(modules, lastServed)
+	if next < len(modules) && modules[next] == lastServed {
+		next++
+	}
+	modules = append(append([]string(nil), modules[next:]...), modules[:next]...)
+	for _, module := range modules {
+		LowestStartBlockPrioritizer{}.Sort(byModule[module], "")
+	}
+
+	out := make([]*Job, 0, len(jobs))
+	for round := 0; len(out) < len(jobs); round++ {
+		for _, module := range modules {
+			if round < len(byModule[module]) {
+				out = append(out, byModule[module][round])
+			}
+		}
+	}
+	return out
 }
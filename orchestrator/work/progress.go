@@ -0,0 +1,140 @@
+package work
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
+)
+
+// ProgressEstimator follows the jobs of a plan to estimate, for each module and for the
+// whole backprocessing, how fast blocks are processed and when the last job will complete.
+// The rate of a module is measured from the start of its first job, as modules depending
+// on stores only start once those are ready.
+type ProgressEstimator struct {
+	mu        sync.Mutex
+	startedAt time.Time
+	modules   map[string]*moduleProgress
+	names     []string
+}
+
+type moduleProgress struct {
+	startedAt       time.Time
+	totalBlocks     uint64
+	processedBlocks uint64
+	running         int
+	queued          int
+	squashedUpTo    uint64
+}
+
+// NewProgressEstimator returns an estimator for the jobs of `plan` not started yet
+func NewProgressEstimator(plan *Plan) *ProgressEstimator {
+	e := &ProgressEstimator{
+		modules: make(map[string]*moduleProgress),
+	}
+
+	plan.mu.Lock()
+	defer plan.mu.Unlock()
+	for _, jobs := range [][]*Job{plan.readyJobs, plan.waitingJobs} {
+		for _, job := range jobs {
+			module, found := e.modules[job.ModuleName]
+			if !found {
+				module = &moduleProgress{}
+				e.modules[job.ModuleName] = module
+				e.names = append(e.names, job.ModuleName)
+			}
+			module.totalBlocks += job.RequestRange.Size()
+			module.queued++
+		}
+	}
+	sort.Strings(e.names)
+	return e
+}
+
+func (e *ProgressEstimator) JobStarted(job *Job, now time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	module := e.modules[job.ModuleName]
+	if module == nil {
+		return
+	}
+	if e.startedAt.IsZero() {
+		e.startedAt = now
+	}
+	if module.startedAt.IsZero() {
+		module.startedAt = now
+	}
+	module.queued--
+	module.running++
+}
+
+// JobEnded records the end of a job, its blocks being processed when it succeeded
+func (e *ProgressEstimator) JobEnded(job *Job, succeeded bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	module := e.modules[job.ModuleName]
+	if module == nil {
+		return
+	}
+	module.running--
+	if succeeded {
+		module.processedBlocks += job.RequestRange.Size()
+	}
+}
+
+func (e *ProgressEstimator) StoreSquashed(moduleName string, upToBlock uint64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if module := e.modules[moduleName]; module != nil && upToBlock > module.squashedUpTo {
+		module.squashedUpTo = upToBlock
+	}
+}
+
+// ModulesProgress returns the estimates of each module, and of the whole backprocessing, at `now`
+func (e *ProgressEstimator) ModulesProgress(now time.Time) *pbsubstreamsrpc.ModulesProgress {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	out := &pbsubstreamsrpc.ModulesProgress{}
+	overall := &moduleProgress{startedAt: e.startedAt}
+	for _, name := range e.names {
+		module := e.modules[name]
+		out.Modules = append(out.Modules, &pbsubstreamsrpc.ModuleProgress{
+			Name: name,
+			Type: &pbsubstreamsrpc.ModuleProgress_Estimate{Estimate: module.estimate(now)},
+		})
+
+		overall.totalBlocks += module.totalBlocks
+		overall.processedBlocks += module.processedBlocks
+		overall.running += module.running
+		overall.queued += module.queued
+	}
+	out.Estimate = overall.estimate(now)
+	return out
+}
+
+func (m *moduleProgress) estimate(now time.Time) *pbsubstreamsrpc.ProgressEstimate {
+	out := &pbsubstreamsrpc.ProgressEstimate{
+		ProcessedBlocks:   m.processedBlocks,
+		TotalBlocks:       m.totalBlocks,
+		JobsRunning:       uint32(m.running),
+		JobsQueued:        uint32(m.queued),
+		SquashedUpToBlock: m.squashedUpTo,
+	}
+
+	if m.startedAt.IsZero() || m.processedBlocks == 0 {
+		return out
+	}
+	elapsed := now.Sub(m.startedAt)
+	if elapsed <= 0 {
+		return out
+	}
+	out.BlocksPerSecond = float64(m.processedBlocks) / elapsed.Seconds()
+	remaining := m.totalBlocks - m.processedBlocks
+	out.EtaNanoSeconds = uint64(float64(remaining) / out.BlocksPerSecond * float64(time.Second))
+	return out
+}
@@ -0,0 +1,55 @@
+package work
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressEstimator(t *testing.T) {
+	b1 := TestJob("B", "0-100", 3)
+	b2 := TestJob("B", "100-200", 2)
+	c1 := TestJob("C", "0-200", 1)
+	plan := TestPlanReadyJobs(b1, b2)
+	plan.waitingJobs = []*Job{c1}
+
+	estimator := NewProgressEstimator(plan)
+	t0 := time.Unix(1_000_000, 0)
+
+	progress := estimator.ModulesProgress(t0)
+	require.Len(t, progress.Modules, 2)
+	assert.Equal(t, uint64(400), progress.Estimate.TotalBlocks)
+	assert.Equal(t, uint32(3), progress.Estimate.JobsQueued)
+	assert.Zero(t, progress.Estimate.EtaNanoSeconds, "unknown before any job completes")
+
+	estimator.JobStarted(b1, t0)
+	estimator.JobStarted(b2, t0)
+	estimator.JobEnded(b1, true)
+	estimator.StoreSquashed("B", 100)
+
+	progress = estimator.ModulesProgress(t0.Add(10 * time.Second))
+	b := progress.Modules[0]
+	assert.Equal(t, "B", b.Name)
+	estimate := b.GetEstimate()
+	require.NotNil(t, estimate)
+	assert.Equal(t, uint64(100), estimate.ProcessedBlocks)
+	assert.Equal(t, 10.0, estimate.BlocksPerSecond)
+	assert.Equal(t, uint64(10*time.Second), estimate.EtaNanoSeconds)
+	assert.Equal(t, uint32(1), estimate.JobsRunning)
+	assert.Equal(t, uint32(0), estimate.JobsQueued)
+	assert.Equal(t, uint64(100), estimate.SquashedUpToBlock)
+
+	// C starts late, its rate is measured from its own start
+	estimator.JobStarted(c1, t0.Add(10*time.Second))
+	estimator.JobEnded(b2, true)
+	estimator.JobEnded(c1, true)
+	progress = estimator.ModulesProgress(t0.Add(20 * time.Second))
+	assert.Equal(t, 20.0, progress.Modules[1].GetEstimate().BlocksPerSecond)
+
+	assert.Equal(t, uint64(400), progress.Estimate.ProcessedBlocks)
+	assert.Equal(t, 20.0, progress.Estimate.BlocksPerSecond)
+	assert.Zero(t, progress.Estimate.EtaNanoSeconds)
+	assert.Zero(t, progress.Estimate.JobsRunning)
+}
@@ -0,0 +1,269 @@
+package work
+
+import (
+	"context"
+	"sync"
+
+	"github.com/streamingfast/substreams/block"
+)
+
+// JobKey identifies the work of a job independently of the request that
+// scheduled it: two jobs with the same key write the same partials.
+type JobKey struct {
+	ModuleHash        string
+	StartBlock        uint64
+	ExclusiveEndBlock uint64
+}
+
+func NewJobKey(moduleHash string, rng *block.Range) JobKey {
+	return JobKey{
+		ModuleHash:        moduleHash,
+		StartBlock:        rng.StartBlock,
+		ExclusiveEndBlock: rng.ExclusiveEndBlock,
+	}
+}
+
+// JobRegistry deduplicates the jobs scheduled by concurrent requests. The
+// first request attaching to a key is the leader, it runs the job and
+// completes it. The following ones attach to the in-flight job and wait
+// for its result instead of running it again.
+//
+// Partials written by a job shared by many requests are squashed by each
+// of them, so the registry also tracks which request is the last one
+// holding them, the one allowed to delete them.
+type JobRegistry interface {
+	// Attach returns the in-flight job for `key`, starting a new one when
+	// there is none. `leader` is true if the caller started the job, it
+	// must then run it and call `Complete` on it, even on failure.
+	Attach(key JobKey) (job SharedJob, leader bool)
+
+	// ReleasePartial signals that a request is done with the partial of
+	// module `moduleHash` covering `rng`. It returns true when no other
+	// request still needs it. Partials not written by a shared job are
+	// always released.
+	ReleasePartial(moduleHash string, rng *block.Range) (last bool)
+}
+
+type SharedJob interface {
+	// Complete is called by the leader with the result of the job, it
+	// wakes up the followers waiting on it.
+	Complete(result *Result)
+
+	// Wait blocks until the leader completes the job, returning its result.
+	// When `ctx` is done first, the caller is detached from the job and
+	// `ctx.Err()` is returned.
+	Wait(ctx context.Context) (*Result, error)
+}
+
+type partialKey struct {
+	moduleHash string
+	rng        block.Range
+}
+
+// InProcessJobRegistry is a JobRegistry sharing jobs between the requests
+// served by a single process.
+type InProcessJobRegistry struct {
+	mu       sync.Mutex
+	inFlight map[JobKey]*inProcessSharedJob
+	partials map[partialKey]int
+}
+
+func NewJobRegistry() *InProcessJobRegistry {
+	return &InProcessJobRegistry{
+		inFlight: make(map[JobKey]*inProcessSharedJob),
+		partials: make(map[partialKey]int),
+	}
+}
+
+func (r *InProcessJobRegistry) Attach(key JobKey) (SharedJob, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if job, found := r.inFlight[key]; found {
+		job.attached++
+		return job, false
+	}
+
+	job := &inProcessSharedJob{
+		registry: r,
+		key:      key,
+		attached: 1,
+		done:     make(chan struct{}),
+	}
+	r.inFlight[key] = job
+	return job, true
+}
+
+func (r *InProcessJobRegistry) ReleasePartial(moduleHash string, rng *block.Range) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := partialKey{moduleHash: moduleHash, rng: *rng}
+	holders, found := r.partials[key]
+	if !found {
+		return true
+	}
+
+	if holders <= 1 {
+		delete(r.partials, key)
+		return true
+	}
+	r.partials[key] = holders - 1
+	return false
+}
+
+// InFlight returns the number of jobs currently running
+func (r *InProcessJobRegistry) InFlight() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.inFlight)
+}
+
+// RequestJobRegistry is the view of a JobRegistry from a single request. It keeps
+// track of the partials of the jobs the request attached to, until it releases
+// them after squashing, so that the ones it never squashes, the request failing
+// or being canceled first, are released by ReleaseAll when it ends.
+var _ JobRegistry = (*RequestJobRegistry)(nil)
+
+type RequestJobRegistry struct {
+	registry JobRegistry
+
+	mu     sync.Mutex
+	held   map[partialKey]int
+	closed bool
+}
+
+var _ JobRegistry = (*RequestJobRegistry)(nil)
+
+func NewRequestJobRegistry(registry JobRegistry) *RequestJobRegistry {
+	return &RequestJobRegistry{
+		registry: registry,
+		held:     make(map[partialKey]int),
+	}
+}
+
+func (r *RequestJobRegistry) Attach(key JobKey) (SharedJob, bool) {
+	job, leader := r.registry.Attach(key)
+	return &requestSharedJob{SharedJob: job, request: r, moduleHash: key.ModuleHash}, leader
+}
+
+// ReleasePartial releases a partial squashed by the request. Once the request
+// ended, nothing is released anymore and false is returned.
+func (r *RequestJobRegistry) ReleasePartial(moduleHash string, rng *block.Range) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return false
+	}
+	key := partialKey{moduleHash: moduleHash, rng: *rng}
+	if holders := r.held[key]; holders > 1 {
+		r.held[key] = holders - 1
+	} else {
+		delete(r.held, key)
+	}
+	return r.registry.ReleasePartial(moduleHash, rng)
+}
+
+// ReleaseAll releases the partials the request got from its jobs and did not
+// release yet. Their files are left for the next requests to squash.
+func (r *RequestJobRegistry) ReleaseAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closed = true
+	for key, holders := range r.held {
+		rng := key.rng
+		for i := 0; i < holders; i++ {
+			r.registry.ReleasePartial(key.moduleHash, &rng)
+		}
+	}
+	r.held = nil
+}
+
+func (r *RequestJobRegistry) hold(moduleHash string, partials block.Ranges) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		// The request ended while its job was running, nobody will squash them
+		for _, rng := range partials {
+			r.registry.ReleasePartial(moduleHash, rng)
+		}
+		return
+	}
+	for _, rng := range partials {
+		r.held[partialKey{moduleHash: moduleHash, rng: *rng}]++
+	}
+}
+
+type requestSharedJob struct {
+	SharedJob
+	request    *RequestJobRegistry
+	moduleHash string
+}
+
+func (j *requestSharedJob) Complete(result *Result) {
+	j.SharedJob.Complete(result)
+	if result.Error == nil {
+		j.request.hold(j.moduleHash, result.PartialsWritten)
+	}
+}
+
+func (j *requestSharedJob) Wait(ctx context.Context) (*Result, error) {
+	result, err := j.SharedJob.Wait(ctx)
+	if err == nil && result.Error == nil {
+		j.request.hold(j.moduleHash, result.PartialsWritten)
+	}
+	return result, err
+}
+
+type inProcessSharedJob struct {
+	registry *InProcessJobRegistry
+	key      JobKey
+
+	attached int // guarded by registry.mu
+	result   *Result
+	done     chan struct{}
+}
+
+func (j *inProcessSharedJob) Complete(result *Result) {
+	r := j.registry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.inFlight[j.key] == j {
+		delete(r.inFlight, j.key)
+	}
+
+	// Each request still attached squashes the partials, the last one deletes them
+	if result.Error == nil && j.attached > 1 {
+		for _, rng := range result.PartialsWritten {
+			r.partials[partialKey{moduleHash: j.key.ModuleHash, rng: *rng}] += j.attached
+		}
+	}
+
+	j.result = result
+	close(j.done)
+}
+
+func (j *inProcessSharedJob) Wait(ctx context.Context) (*Result, error) {
+	select {
+	case <-j.done:
+		return j.result, nil
+	case <-ctx.Done():
+	}
+
+	r := j.registry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	select {
+	case <-j.done:
+		// Completed while we were acquiring the lock, we are already accounted for
+		return j.result, nil
+	default:
+	}
+	j.attached--
+	return nil, ctx.Err()
+}
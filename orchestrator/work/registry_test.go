@@ -0,0 +1,132 @@
+package work
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/streamingfast/substreams/block"
+)
+
+func TestJobRegistry_Attach(t *testing.T) {
+	registry := NewJobRegistry()
+	key := NewJobKey("abc", block.ParseRange("0-10"))
+
+	leaderJob, leader := registry.Attach(key)
+	require.True(t, leader)
+
+	followerJob, leader := registry.Attach(key)
+	require.False(t, leader)
+
+	_, leader = registry.Attach(NewJobKey("abc", block.ParseRange("10-20")))
+	assert.True(t, leader, "other range")
+	_, leader = registry.Attach(NewJobKey("def", block.ParseRange("0-10")))
+	assert.True(t, leader, "other module hash")
+
+	waited := make(chan *Result)
+	go func() {
+		result, err := followerJob.Wait(context.Background())
+		require.NoError(t, err)
+		waited <- result
+	}()
+
+	result := &Result{PartialsWritten: block.ParseRanges("0-10")}
+	leaderJob.Complete(result)
+	assert.Equal(t, result, <-waited)
+	assert.Equal(t, 2, registry.InFlight())
+
+	_, leader = registry.Attach(key)
+	assert.True(t, leader, "completed jobs are not shared anymore")
+}
+
+func TestJobRegistry_WaitCanceled(t *testing.T) {
+	registry := NewJobRegistry()
+	key := NewJobKey("abc", block.ParseRange("0-10"))
+
+	leaderJob, _ := registry.Attach(key)
+	followerJob, _ := registry.Attach(key)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := followerJob.Wait(ctx)
+	require.Equal(t, context.Canceled, err)
+
+	// The detached follower does not hold the partial
+	leaderJob.Complete(&Result{PartialsWritten: block.ParseRanges("0-10")})
+	assert.True(t, registry.ReleasePartial("abc", block.ParseRange("0-10")))
+}
+
+func TestJobRegistry_ReleasePartial(t *testing.T) {
+	registry := NewJobRegistry()
+	key := NewJobKey("abc", block.ParseRange("0-20"))
+
+	leaderJob, _ := registry.Attach(key)
+	registry.Attach(key)
+	registry.Attach(key)
+	leaderJob.Complete(&Result{PartialsWritten: block.ParseRanges("0-10,10-20")})
+
+	for i, expectLast := range []bool{false, false, true, true} {
+		assert.Equal(t, expectLast, registry.ReleasePartial("abc", block.ParseRange("0-10")), fmt.Sprintf("release %d", i))
+	}
+	assert.False(t, registry.ReleasePartial("abc", block.ParseRange("10-20")))
+	assert.True(t, registry.ReleasePartial("def", block.ParseRange("0-10")), "partial not written by a shared job")
+}
+
+func TestJobRegistry_FailedJob(t *testing.T) {
+	registry := NewJobRegistry()
+	key := NewJobKey("abc", block.ParseRange("0-10"))
+
+	leaderJob, _ := registry.Attach(key)
+	followerJob, _ := registry.Attach(key)
+	leaderJob.Complete(&Result{Error: fmt.Errorf("failed"), PartialsWritten: block.ParseRanges("0-10")})
+
+	result, err := followerJob.Wait(context.Background())
+	require.NoError(t, err)
+	assert.EqualError(t, result.Error, "failed")
+	assert.True(t, registry.ReleasePartial("abc", block.ParseRange("0-10")))
+}
+
+func TestRequestJobRegistry_ReleaseAll(t *testing.T) {
+	registry := NewJobRegistry()
+	key := NewJobKey("abc", block.ParseRange("0-20"))
+	leaderRequest, followerRequest := NewRequestJobRegistry(registry), NewRequestJobRegistry(registry)
+
+	leaderJob, _ := leaderRequest.Attach(key)
+	followerJob, _ := followerRequest.Attach(key)
+
+	waited := make(chan error)
+	go func() {
+		_, err := followerJob.Wait(context.Background())
+		waited <- err
+	}()
+	leaderJob.Complete(&Result{PartialsWritten: block.ParseRanges("0-10,10-20")})
+	require.NoError(t, <-waited)
+
+	// The follower squashed the first partial, then failed before squashing the second one
+	assert.False(t, followerRequest.ReleasePartial("abc", block.ParseRange("0-10")))
+	followerRequest.ReleaseAll()
+	assert.False(t, followerRequest.ReleasePartial("abc", block.ParseRange("10-20")), "released once the request ended")
+
+	assert.True(t, leaderRequest.ReleasePartial("abc", block.ParseRange("0-10")))
+	assert.True(t, leaderRequest.ReleasePartial("abc", block.ParseRange("10-20")))
+	assert.Empty(t, registry.partials)
+}
+
+func TestRequestJobRegistry_EndedBeforeCompletion(t *testing.T) {
+	registry := NewJobRegistry()
+	key := NewJobKey("abc", block.ParseRange("0-10"))
+	leaderRequest, followerRequest := NewRequestJobRegistry(registry), NewRequestJobRegistry(registry)
+
+	leaderJob, _ := leaderRequest.Attach(key)
+	followerRequest.Attach(key)
+
+	// The leader's request ended while its job was running, the follower's request squashes alone
+	leaderRequest.ReleaseAll()
+	leaderJob.Complete(&Result{PartialsWritten: block.ParseRanges("0-10")})
+
+	assert.True(t, followerRequest.ReleasePartial("abc", block.ParseRange("0-10")))
+	assert.Empty(t, registry.partials)
+}
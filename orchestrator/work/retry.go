@@ -0,0 +1,51 @@
+package work
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy tells how the jobs failing because of the infrastructure are run again.
+// Jobs failing because of their module are never retried.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times a job is run before failing the request, the first run included
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry, multiplied by Multiplier at each
+	// following retry, up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+
+	// Jitter randomizes each delay by up to this fraction of it, in both directions, so
+	// that jobs failing together are not retried together
+	Jitter float64
+}
+
+// DefaultRetryPolicy runs a job up to 4 times, waiting 1s, 2s then 4s between runs
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: time.Second,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+	}
+}
+
+// Backoff returns the delay to wait after the failed `attempt`, starting at 1
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	backoff := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff != 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		backoff += backoff * p.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(backoff)
+}
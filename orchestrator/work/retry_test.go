@@ -0,0 +1,82 @@
+package work
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	assert.Equal(t, time.Second, policy.Backoff(1))
+	assert.Equal(t, 2*time.Second, policy.Backoff(2))
+	assert.Equal(t, 4*time.Second, policy.Backoff(3))
+	assert.Equal(t, 5*time.Second, policy.Backoff(4), "capped")
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		backoff := policy.Backoff(2)
+		assert.GreaterOrEqual(t, backoff, time.Second)
+		assert.LessOrEqual(t, backoff, 3*time.Second)
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected ErrorClass
+	}{
+		{&ModuleExecutionErr{ModuleName: "map_a", Reason: "panic"}, ErrorClassDeterministic},
+		{NewRetryableErr(&ModuleExecutionErr{ModuleName: "map_a", Reason: "panic"}), ErrorClassDeterministic},
+		{fmt.Errorf("unknown: %w", errors.New("failure")), ErrorClassDeterministic},
+		{NewRetryableErr(fmt.Errorf("receiving stream resp: %w", errors.New("EOF"))), ErrorClassInfra},
+		{status.Error(codes.Unavailable, "no healthy upstream"), ErrorClassInfra},
+		{status.Error(codes.InvalidArgument, "invalid request"), ErrorClassDeterministic},
+		{ErrCircuitOpen, ErrorClassInfra},
+		{NewRetryableErr(context.Canceled), ErrorClassCanceled},
+		{status.Error(codes.Canceled, "canceled"), ErrorClassCanceled},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, ClassifyError(test.err), test.err.Error())
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	breaker := NewCircuitBreaker(2, 20*time.Millisecond)
+	infraErr := NewRetryableErr(errors.New("unavailable"))
+
+	breaker.Record("tier2", infraErr)
+	breaker.Record("tier2", nil)
+	breaker.Record("tier2", infraErr)
+	assert.False(t, breaker.IsOpen("tier2"), "successes reset the count")
+	breaker.Record("tier2", &ModuleExecutionErr{ModuleName: "map_a", Reason: "panic"})
+	breaker.Record("tier2", context.Canceled)
+	assert.False(t, breaker.IsOpen("tier2"), "module failures are not counted")
+	breaker.Record("tier2", infraErr)
+	assert.True(t, breaker.IsOpen("tier2"))
+	assert.Equal(t, ErrCircuitOpen, breaker.Allow("tier2"))
+	assert.NoError(t, breaker.Allow("other"))
+
+	// half-open after the cooldown, a single probe at a time
+	time.Sleep(25 * time.Millisecond)
+	assert.NoError(t, breaker.Allow("tier2"))
+	assert.Equal(t, ErrCircuitOpen, breaker.Allow("tier2"))
+	breaker.Record("tier2", infraErr)
+	assert.Equal(t, ErrCircuitOpen, breaker.Allow("tier2"), "failed probe opens the circuit again")
+
+	time.Sleep(25 * time.Millisecond)
+	assert.NoError(t, breaker.Allow("tier2"))
+	breaker.Record("tier2", nil)
+	assert.False(t, breaker.IsOpen("tier2"))
+	assert.NoError(t, breaker.Allow("tier2"))
+
+	var disabled *CircuitBreaker
+	assert.NoError(t, disabled.Allow("tier2"))
+	disabled.Record("tier2", infraErr)
+}
@@ -0,0 +1,92 @@
+package work
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/streamingfast/substreams/block"
+)
+
+// SimulationResult is the outcome of replaying a plan with synthetic job durations
+type SimulationResult struct {
+	// Makespan is the time from the start of the first job to the end of the last one
+	Makespan time.Duration
+	Jobs     int
+
+	// Busy is the summed duration of all the jobs, Busy / (workers * Makespan) being
+	// the utilization of the workers
+	Busy time.Duration
+}
+
+type simulatedJob struct {
+	job *Job
+	end time.Duration
+}
+
+// Simulate replays `plan` on `workers` workers, each job lasting `duration(job)`,
+// so that prioritizers can be compared offline. As the squasher merges partials
+// in block order, the jobs of a module make its dependents ready only once all
+// its jobs before them are completed. The plan is consumed by the simulation.
+func Simulate(plan *Plan, workers int, duration func(job *Job) time.Duration) (*SimulationResult, error) {
+	if workers <= 0 {
+		return nil, fmt.Errorf("at least one worker is needed, got %d", workers)
+	}
+
+	completed := map[string]block.Ranges{}
+	readyUpTo := map[string]uint64{}
+	for module, upTo := range plan.modulesReadyUpToBlock {
+		readyUpTo[module] = upTo
+	}
+
+	result := &SimulationResult{}
+	var now time.Duration
+	var running []*simulatedJob
+	for {
+		for len(running) < workers {
+			job, _ := plan.NextJob()
+			if job == nil {
+				break
+			}
+
+			jobDuration := duration(job)
+			running = append(running, &simulatedJob{job: job, end: now + jobDuration})
+			result.Jobs++
+			result.Busy += jobDuration
+		}
+
+		if len(running) == 0 {
+			plan.mu.Lock()
+			remaining := plan.hasMore()
+			plan.mu.Unlock()
+			if remaining {
+				return nil, fmt.Errorf("plan stuck after %s: remaining jobs never become ready", now)
+			}
+			break
+		}
+
+		// Jobs completing at the same time complete in the order they started
+		sort.SliceStable(running, func(i, j int) bool { return running[i].end < running[j].end })
+		done := running[0]
+		running = running[1:]
+		now = done.end
+
+		module := done.job.ModuleName
+		completed[module] = append(completed[module], done.job.RequestRange)
+		sort.Sort(completed[module])
+
+		upTo := readyUpTo[module]
+		for _, rng := range completed[module] {
+			if rng.StartBlock <= upTo && rng.ExclusiveEndBlock > upTo {
+				upTo = rng.ExclusiveEndBlock
+			}
+		}
+		if upTo != readyUpTo[module] {
+			readyUpTo[module] = upTo
+			plan.MarkDependencyComplete(module, upTo)
+		}
+	}
+
+	result.Makespan = now
+	return result, nil
+}
@@ -0,0 +1,138 @@
+package work
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/streamingfast/substreams/manifest"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/pipeline/outputmodules"
+)
+
+func simulationPlan(t *testing.T, outputModule string, prioritizer Prioritizer, modules ...string) *Plan {
+	t.Helper()
+
+	mods := manifest.NewTestModules()
+	outputGraph, err := outputmodules.NewOutputModuleGraph(outputModule, nil, false, &pbsubstreams.Modules{Modules: mods, Binaries: []*pbsubstreams.Binary{{}}})
+	require.NoError(t, err)
+
+	var states []string
+	for start := 0; start < 100; start += 10 {
+		states = append(states, fmt.Sprintf("%d-%d", start, start+10))
+	}
+	stateMap := TestModStateMap()
+	for _, module := range modules {
+		stateMap[module] = TestStoreState(module, strings.Join(states, ","))
+	}
+
+	plan, err := BuildNewPlan(context.Background(), stateMap, 10, 100, 0, outputGraph, nil, prioritizer)
+	require.NoError(t, err)
+	return plan
+}
+
+func TestSimulate(t *testing.T) {
+	plan := simulationPlan(t, "As", nil, "As")
+	result, err := Simulate(plan, 3, func(job *Job) time.Duration { return time.Second })
+	require.NoError(t, err)
+
+	// 10 independent jobs on 3 workers
+	assert.Equal(t, 10, result.Jobs)
+	assert.Equal(t, 4*time.Second, result.Makespan)
+	assert.Equal(t, 10*time.Second, result.Busy)
+}
+
+func TestSimulate_Prioritizers(t *testing.T) {
+	// As -> C -> E -> G -> K and Am -> B -> D -> G, G's jobs being the slowest
+	duration := func(job *Job) time.Duration {
+		if job.ModuleName == "G" {
+			return 3 * time.Second
+		}
+		return time.Second
+	}
+
+	makespans := map[string]time.Duration{}
+	for _, name := range PrioritizerNames() {
+		prioritizer, err := NewPrioritizer(name)
+		require.NoError(t, err)
+
+		plan := simulationPlan(t, "K", prioritizer, "As", "B", "E", "G", "K")
+		result, err := Simulate(plan, 4, duration)
+		require.NoError(t, err, name)
+		assert.Equal(t, 50, result.Jobs, name)
+
+		assert.Equal(t, 70*time.Second, result.Busy, name)
+		assert.GreaterOrEqual(t, result.Makespan, result.Busy/4, name)
+		makespans[name] = result.Makespan
+	}
+
+	assert.Len(t, makespans, 4)
+	assert.LessOrEqual(t, makespans[PrioritizerCriticalPath], makespans[PrioritizerRoundRobin])
+}
+
+func TestNewPrioritizer(t *testing.T) {
+	prioritizer, err := NewPrioritizer("")
+	require.NoError(t, err)
+	assert.Equal(t, PriorityPrioritizer{}, prioritizer)
+
+	_, err = NewPrioritizer("unknown")
+	assert.EqualError(t, err, `unknown prioritizer "unknown", valid ones are critical-path, lowest-start-block, priority, round-robin`)
+}
+
+func TestPrioritizers_Sort(t *testing.T) {
+	jobs := func() []*Job {
+		a1 := TestJob("A", "30-40", 1)
+		a1.dependentsDepth = 2
+		a2 := TestJob("A", "40-50", 3)
+		a2.dependentsDepth = 2
+		b1 := TestJob("B", "0-10", 2)
+		b2 := TestJob("B", "10-20", 4)
+		b3 := TestJob("B", "20-30", 0)
+		return []*Job{b3, a2, b2, a1, b1}
+	}
+
+	tests := []struct {
+		prioritizer Prioritizer
+		expected    string
+	}{
+		{PriorityPrioritizer{}, "B:10-20 A:40-50 B:0-10 A:30-40 B:20-30"},
+		{CriticalPathPrioritizer{}, "A:30-40 A:40-50 B:0-10 B:10-20 B:20-30"},
+		{LowestStartBlockPrioritizer{}, "B:0-10 B:10-20 B:20-30 A:30-40 A:40-50"},
+		{RoundRobinPrioritizer{}, "A:30-40 B:0-10 A:40-50 B:10-20 B:20-30"},
+	}
+	for _, test := range tests {
+		var out []string
+		for _, job := range test.prioritizer.Sort(jobs(), "") {
+			out = append(out, fmt.Sprintf("%s:%d-%d", job.ModuleName, job.RequestRange.StartBlock, job.RequestRange.ExclusiveEndBlock))
+		}
+		assert.Equal(t, test.expected, strings.Join(out, " "), "%T", test.prioritizer)
+	}
+}
+
+func TestRoundRobinPrioritizer_Rotation(t *testing.T) {
+	// As -> C -> E, B being ready from the start
+	plan := simulationPlan(t, "G", RoundRobinPrioritizer{}, "As", "B", "E")
+
+	var served []string
+	next := func() {
+		job, _ := plan.NextJob()
+		require.NotNil(t, job)
+		served = append(served, fmt.Sprintf("%s:%d-%d", job.ModuleName, job.RequestRange.StartBlock, job.RequestRange.ExclusiveEndBlock))
+	}
+
+	next()
+	plan.MarkDependencyComplete("As", 10)
+	next()
+	next()
+	next()
+	plan.MarkDependencyComplete("As", 20)
+	next()
+
+	// ready jobs are sorted again on each completion, the rotation going on from the last module served
+	assert.Equal(t, "As:0-10 B:0-10 E:0-10 As:10-20 B:10-20", strings.Join(served, " "))
+}
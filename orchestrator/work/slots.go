@@ -0,0 +1,196 @@
+package work
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/streamingfast/substreams/metrics"
+)
+
+// UserQuota bounds the worker slots given to a user. When many users wait
+// for slots, they are shared in proportion of their Weight.
+type UserQuota struct {
+	MaxSlots uint64
+	Weight   uint64
+}
+
+// WorkerSlots bounds the number of tier2 workers running at once across all
+// the requests served by the process, sharing them fairly between users.
+//
+// A freed slot goes to the waiting request with, in order:
+//   - a start block close to the chain head, as these users are waiting for live data,
+//   - the user holding the fewest slots relative to its weight,
+//   - the longest wait.
+//
+// A user never holds more than the MaxSlots of its quota, even when slots are free.
+type WorkerSlots struct {
+	capacity       uint64
+	defaultQuota   UserQuota
+	nearHeadBlocks uint64
+
+	mu      sync.Mutex
+	inUse   uint64
+	quotas  map[string]UserQuota
+	running map[string]uint64
+	waiters []*slotWaiter
+}
+
+type slotWaiter struct {
+	userID   string
+	nearHead bool
+	granted  chan struct{}
+}
+
+// NewWorkerSlots shares `capacity` worker slots between users, each getting
+// at most `defaultUserMaxSlots` of them unless overridden with SetUserQuota.
+// Requests starting at most `nearHeadBlocks` before the recent final block
+// of the chain get their slots first.
+func NewWorkerSlots(capacity uint64, defaultUserMaxSlots uint64, nearHeadBlocks uint64) *WorkerSlots {
+	return &WorkerSlots{
+		capacity:       capacity,
+		defaultQuota:   UserQuota{MaxSlots: defaultUserMaxSlots, Weight: 1},
+		nearHeadBlocks: nearHeadBlocks,
+		quotas:         make(map[string]UserQuota),
+		running:        make(map[string]uint64),
+	}
+}
+
+func (s *WorkerSlots) SetUserQuota(userID string, quota UserQuota) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.quotas[userID] = quota
+	s.dispatch()
+}
+
+// ValidateUser checks that `userID` can be given slots
+func (s *WorkerSlots) ValidateUser(userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.validateUserLocked(userID)
+}
+
+func (s *WorkerSlots) validateUserLocked(userID string) error {
+	quota := s.quotaOf(userID)
+	if quota.MaxSlots == 0 || quota.Weight == 0 {
+		return fmt.Errorf("user %q has no worker slot quota", userID)
+	}
+	return nil
+}
+
+// IsNearHead tells if a request starting at `startBlock` is close to the
+// chain head, `recentFinalBlock` being 0 when it is unknown.
+func (s *WorkerSlots) IsNearHead(startBlock, recentFinalBlock uint64) bool {
+	return recentFinalBlock != 0 && startBlock+s.nearHeadBlocks >= recentFinalBlock
+}
+
+// Acquire blocks until a slot is given to `userID` or `ctx` is done. The
+// returned function must be called to give the slot back, calling it again
+// having no effect.
+func (s *WorkerSlots) Acquire(ctx context.Context, userID string, nearHead bool) (release func(), err error) {
+	start := time.Now()
+	var once sync.Once
+	release = func() { once.Do(func() { s.release(userID) }) }
+
+	s.mu.Lock()
+	if err := s.validateUserLocked(userID); err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+
+	// Waiters are kept in arrival order
+	waiter := &slotWaiter{userID: userID, nearHead: nearHead, granted: make(chan struct{})}
+	s.waiters = append(s.waiters, waiter)
+	metrics.WorkerSlotsQueueDepth.Inc()
+	s.dispatch()
+	s.mu.Unlock()
+
+	select {
+	case <-waiter.granted:
+		metrics.WorkerSlotsWaitTime.ObserveSince(start)
+		return release, nil
+	case <-ctx.Done():
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case <-waiter.granted:
+		// Granted while we were acquiring the lock, give it back
+		s.releaseLocked(userID)
+	default:
+		s.removeWaiter(waiter)
+		metrics.WorkerSlotsQueueDepth.Dec()
+	}
+	return nil, ctx.Err()
+}
+
+func (s *WorkerSlots) release(userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.releaseLocked(userID)
+}
+
+func (s *WorkerSlots) releaseLocked(userID string) {
+	s.inUse--
+	s.running[userID]--
+	if s.running[userID] == 0 {
+		delete(s.running, userID)
+	}
+	metrics.WorkerSlotsInUse.SetUint64(s.inUse)
+	s.dispatch()
+}
+
+func (s *WorkerSlots) quotaOf(userID string) UserQuota {
+	if quota, found := s.quotas[userID]; found {
+		return quota
+	}
+	return s.defaultQuota
+}
+
+// dispatch gives the free slots to the waiters, must be called with `s.mu` held
+func (s *WorkerSlots) dispatch() {
+	for s.inUse < s.capacity {
+		next := s.nextWaiter()
+		if next == nil {
+			return
+		}
+
+		s.removeWaiter(next)
+		s.inUse++
+		s.running[next.userID]++
+		metrics.WorkerSlotsQueueDepth.Dec()
+		metrics.WorkerSlotsInUse.SetUint64(s.inUse)
+		close(next.granted)
+	}
+}
+
+func (s *WorkerSlots) nextWaiter() (best *slotWaiter) {
+	var bestShare float64
+	for _, waiter := range s.waiters {
+		quota := s.quotaOf(waiter.userID)
+		running := s.running[waiter.userID]
+		if running >= quota.MaxSlots {
+			continue
+		}
+
+		share := float64(running) / float64(quota.Weight)
+		if best == nil ||
+			(waiter.nearHead && !best.nearHead) ||
+			(waiter.nearHead == best.nearHead && share < bestShare) {
+			best, bestShare = waiter, share
+		}
+	}
+	return best
+}
+
+func (s *WorkerSlots) removeWaiter(waiter *slotWaiter) {
+	for i, w := range s.waiters {
+		if w == waiter {
+			s.waiters = append(s.waiters[:i], s.waiters[i+1:]...)
+			return
+		}
+	}
+}
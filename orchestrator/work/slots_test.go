@@ -0,0 +1,111 @@
+package work
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type acquired struct {
+	name    string
+	release func()
+}
+
+func acquireAsync(t *testing.T, slots *WorkerSlots, grants chan acquired, name, userID string, nearHead bool) {
+	t.Helper()
+	go func() {
+		release, err := slots.Acquire(context.Background(), userID, nearHead)
+		require.NoError(t, err)
+		grants <- acquired{name, release}
+	}()
+	// Waiters are served in arrival order, make sure this one is queued before the next
+	require.Eventually(t, func() bool {
+		slots.mu.Lock()
+		defer slots.mu.Unlock()
+		for _, w := range slots.waiters {
+			if w.userID == userID {
+				return true
+			}
+		}
+		return false
+	}, time.Second, time.Millisecond)
+}
+
+func TestWorkerSlots_FairShare(t *testing.T) {
+	slots := NewWorkerSlots(2, 10, 100)
+
+	// heavy takes all the slots
+	first, err := slots.Acquire(context.Background(), "heavy", false)
+	require.NoError(t, err)
+	_, err = slots.Acquire(context.Background(), "heavy", false)
+	require.NoError(t, err)
+
+	grants := make(chan acquired, 10)
+	acquireAsync(t, slots, grants, "heavy-1", "heavy", false)
+	acquireAsync(t, slots, grants, "light-1", "light", false)
+	acquireAsync(t, slots, grants, "live-1", "live", true)
+
+	// near head requests first, then the user with the fewest slots, before heavy's earlier request
+	first()
+	live := <-grants
+	assert.Equal(t, "live-1", live.name)
+	live.release()
+	light := <-grants
+	assert.Equal(t, "light-1", light.name)
+}
+
+func TestWorkerSlots_Weights(t *testing.T) {
+	slots := NewWorkerSlots(3, 10, 100)
+	slots.SetUserQuota("weighted", UserQuota{MaxSlots: 10, Weight: 3})
+
+	for _, userID := range []string{"light", "weighted"} {
+		_, err := slots.Acquire(context.Background(), userID, false)
+		require.NoError(t, err)
+	}
+	release, err := slots.Acquire(context.Background(), "other", false)
+	require.NoError(t, err)
+
+	grants := make(chan acquired, 10)
+	acquireAsync(t, slots, grants, "light-2", "light", false)
+	acquireAsync(t, slots, grants, "weighted-2", "weighted", false)
+
+	// weighted holds 1 slot for a weight of 3, less than light's 1 slot for a weight of 1
+	release()
+	assert.Equal(t, "weighted-2", (<-grants).name)
+}
+
+func TestWorkerSlots_Quota(t *testing.T) {
+	slots := NewWorkerSlots(3, 1, 100)
+
+	release, err := slots.Acquire(context.Background(), "user", false)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = slots.Acquire(ctx, "user", false)
+	assert.Equal(t, context.DeadlineExceeded, err, "over quota while slots are free")
+	assert.Empty(t, slots.waiters)
+
+	release()
+	release, err = slots.Acquire(context.Background(), "user", false)
+	require.NoError(t, err)
+	release()
+	release()
+	assert.Equal(t, uint64(0), slots.inUse, "releasing twice gives the slot back once")
+	assert.Empty(t, slots.running)
+
+	slots.SetUserQuota("blocked", UserQuota{})
+	assert.EqualError(t, slots.ValidateUser("blocked"), `user "blocked" has no worker slot quota`)
+	assert.NoError(t, slots.ValidateUser("other"))
+}
+
+func TestWorkerSlots_IsNearHead(t *testing.T) {
+	slots := NewWorkerSlots(1, 1, 100)
+	assert.True(t, slots.IsNearHead(950, 1000))
+	assert.True(t, slots.IsNearHead(1200, 1000))
+	assert.False(t, slots.IsNearHead(800, 1000))
+	assert.False(t, slots.IsNearHead(800, 0), "unknown final block")
+}
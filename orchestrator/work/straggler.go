@@ -0,0 +1,90 @@
+package work
+
+import (
+	"sync"
+	"time"
+
+	"github.com/streamingfast/substreams/storage/jobcost"
+)
+
+// SpeculationPolicy tells when a job is straggling, for the scheduler to run a
+// duplicate of it on a free worker
+type SpeculationPolicy struct {
+	// Factor of its expected duration a job must have been running for to be straggling
+	Factor float64
+	// MinDuration a job must have been running for to be straggling, whatever its expected duration
+	MinDuration time.Duration
+}
+
+// StragglerDetector estimates the duration of the jobs from the cost recorded for
+// their block range by previous requests, or else from the jobs of the same module
+// completed by this request.
+type StragglerDetector struct {
+	policy       SpeculationPolicy
+	costProfiles map[string]*jobcost.Profile
+
+	mu       sync.Mutex
+	observed map[string]*observedCost
+}
+
+type observedCost struct {
+	duration time.Duration
+	blocks   uint64
+}
+
+func NewStragglerDetector(policy SpeculationPolicy, jobCosts map[string]jobcost.Observations) *StragglerDetector {
+	costProfiles := make(map[string]*jobcost.Profile)
+	for moduleName, observations := range jobCosts {
+		if len(observations) != 0 {
+			costProfiles[moduleName] = observations.Profile()
+		}
+	}
+
+	return &StragglerDetector{
+		policy:       policy,
+		costProfiles: costProfiles,
+		observed:     make(map[string]*observedCost),
+	}
+}
+
+// Observe records the duration of a completed job
+func (d *StragglerDetector) Observe(job *Job, duration time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	cost, found := d.observed[job.ModuleName]
+	if !found {
+		cost = &observedCost{}
+		d.observed[job.ModuleName] = cost
+	}
+	cost.duration += duration
+	cost.blocks += job.RequestRange.Size()
+}
+
+// Expected returns the expected duration of `job`, false when nothing is known about its module yet
+func (d *StragglerDetector) Expected(job *Job) (time.Duration, bool) {
+	if profile, found := d.costProfiles[job.ModuleName]; found {
+		return profile.Estimate(job.RequestRange), true
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	cost, found := d.observed[job.ModuleName]
+	if !found || cost.blocks == 0 {
+		return 0, false
+	}
+	return cost.duration / time.Duration(cost.blocks) * time.Duration(job.RequestRange.Size()), true
+}
+
+// IsStraggling tells if `job`, running for `runningFor`, is taking too long
+func (d *StragglerDetector) IsStraggling(job *Job, runningFor time.Duration) bool {
+	if runningFor < d.policy.MinDuration {
+		return false
+	}
+	expected, known := d.Expected(job)
+	if !known {
+		return false
+	}
+	return runningFor > time.Duration(float64(expected)*d.policy.Factor)
+}
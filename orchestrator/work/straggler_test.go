@@ -0,0 +1,36 @@
+package work
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/streamingfast/substreams/block"
+	"github.com/streamingfast/substreams/storage/jobcost"
+)
+
+func TestStragglerDetector(t *testing.T) {
+	detector := NewStragglerDetector(SpeculationPolicy{Factor: 2, MinDuration: 5 * time.Second}, map[string]jobcost.Observations{
+		"A": {{Range: block.NewRange(0, 100), Duration: 10 * time.Second}},
+	})
+
+	// from the recorded costs
+	expected, known := detector.Expected(TestJob("A", "0-50", 0))
+	assert.True(t, known)
+	assert.Equal(t, 5*time.Second, expected)
+	assert.False(t, detector.IsStraggling(TestJob("A", "0-50", 0), 10*time.Second))
+	assert.True(t, detector.IsStraggling(TestJob("A", "0-50", 0), 11*time.Second))
+
+	// from the jobs completed by the request
+	_, known = detector.Expected(TestJob("B", "0-10", 0))
+	assert.False(t, known)
+	assert.False(t, detector.IsStraggling(TestJob("B", "0-10", 0), time.Hour))
+	detector.Observe(TestJob("B", "0-10", 0), time.Second)
+	detector.Observe(TestJob("B", "10-30", 0), 5*time.Second)
+	expected, _ = detector.Expected(TestJob("B", "30-40", 0))
+	assert.Equal(t, 2*time.Second, expected)
+
+	assert.False(t, detector.IsStraggling(TestJob("B", "30-31", 0), 4*time.Second), "below the minimum duration")
+	assert.True(t, detector.IsStraggling(TestJob("B", "30-31", 0), 5*time.Second))
+}
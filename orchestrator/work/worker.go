@@ -26,6 +26,10 @@ var lastWorkerID uint64
 type Result struct {
 	PartialsWritten []*block.Range
 	Error           error
+
+	// BytesRead and BytesWritten are the totals reported by the tier2 executing the job, if any
+	BytesRead    uint64
+	BytesWritten uint64
 }
 
 type Worker interface {
@@ -33,6 +37,14 @@ type Worker interface {
 	Work(ctx context.Context, request *pbssinternal.ProcessRangeRequest, respFunc substreams.ResponseFunc) *Result
 }
 
+// EndpointOf returns the tier2 endpoint `worker` sends its jobs to, empty if unknown
+func EndpointOf(worker Worker) string {
+	if w, ok := worker.(interface{ Endpoint() string }); ok {
+		return w.Endpoint()
+	}
+	return ""
+}
+
 func NewWorkerFactoryFromFunc(f func(ctx context.Context, request *pbssinternal.ProcessRangeRequest, respFunc substreams.ResponseFunc) *Result) *SimpleWorkerFactory {
 	return &SimpleWorkerFactory{
 		f:  f,
@@ -58,14 +70,16 @@ type WorkerFactory = func(logger *zap.Logger) Worker
 
 type RemoteWorker struct {
 	clientFactory client.InternalClientFactory
+	endpoint      string
 	tracer        ttrace.Tracer
 	logger        *zap.Logger
 	id            uint64
 }
 
-func NewRemoteWorker(clientFactory client.InternalClientFactory, logger *zap.Logger) *RemoteWorker {
+func NewRemoteWorker(clientFactory client.InternalClientFactory, endpoint string, logger *zap.Logger) *RemoteWorker {
 	return &RemoteWorker{
 		clientFactory: clientFactory,
+		endpoint:      endpoint,
 		tracer:        otel.GetTracerProvider().Tracer("worker"),
 		logger:        logger,
 		id:            atomic.AddUint64(&lastWorkerID, 1),
@@ -76,6 +90,10 @@ func (w *RemoteWorker) ID() string {
 	return fmt.Sprintf("%d", w.id)
 }
 
+func (w *RemoteWorker) Endpoint() string {
+	return w.endpoint
+}
+
 func (w *RemoteWorker) Work(ctx context.Context, request *pbssinternal.ProcessRangeRequest, respFunc substreams.ResponseFunc) *Result {
 	var err error
 	ctx, span := reqctx.WithSpan(ctx, "running_job")
@@ -138,6 +156,7 @@ func (w *RemoteWorker) Work(ctx context.Context, request *pbssinternal.ProcessRa
 
 	span.SetAttributes(attribute.String("remote_hostname", remoteHostname))
 
+	var bytesRead, bytesWritten uint64
 	for {
 		select {
 		case <-ctx.Done():
@@ -161,6 +180,7 @@ func (w *RemoteWorker) Work(ctx context.Context, request *pbssinternal.ProcessRa
 				}
 
 			case *pbssinternal.ProcessRangeResponse_ProcessedBytes:
+				bytesRead, bytesWritten = r.ProcessedBytes.TotalBytesRead, r.ProcessedBytes.TotalBytesWritten
 				// commented out while these message are causing issues
 				//bm := tracking.GetBytesMeter(ctx)
 				//bm.AddBytesWritten(int(r.ProcessedBytes.BytesWrittenDelta))
@@ -168,12 +188,10 @@ func (w *RemoteWorker) Work(ctx context.Context, request *pbssinternal.ProcessRa
 				//respFunc(toRPCProcessedBytes(resp.ModuleName, bm.BytesReadDelta(), bm.BytesWrittenDelta(), bm.BytesRead(), bm.BytesWritten(), 0))
 
 			case *pbssinternal.ProcessRangeResponse_Failed:
-				// FIXME(abourget): we do NOT emit those Failed objects anymore. There was a flow
-				// for that that would pick up the errors, and pack the remaining logs
-				// and reasons into a message. This is nowhere to be found now.
+				// Sent by tier2 when a module fails on its input
 				forwardResponse := toRPCFailedProgressResponse(resp.ModuleName, r.Failed.Reason, r.Failed.Logs, r.Failed.LogsTruncated)
 				respFunc(forwardResponse)
-				err := fmt.Errorf("module %s failed on host: %s", resp.ModuleName, r.Failed.Reason)
+				err := &ModuleExecutionErr{ModuleName: resp.ModuleName, Reason: r.Failed.Reason}
 				span.SetStatus(codes.Error, err.Error())
 				return &Result{
 					Error: err,
@@ -183,6 +201,8 @@ func (w *RemoteWorker) Work(ctx context.Context, request *pbssinternal.ProcessRa
 				logger.Info("worker done")
 				return &Result{
 					PartialsWritten: toRPCBlockRanges(r.Completed.AllProcessedRanges),
+					BytesRead:       bytesRead,
+					BytesWritten:    bytesWritten,
 				}
 			}
 		}
@@ -2,6 +2,7 @@ package work
 
 import (
 	"context"
+	"sync"
 
 	"github.com/streamingfast/substreams/reqctx"
 	"go.uber.org/zap"
@@ -45,3 +46,56 @@ func (p *workerPool) Borrow(ctx context.Context) Worker {
 func (p *workerPool) Return(worker Worker) {
 	p.workers <- worker
 }
+
+// NewFairShareWorkerPool is a worker pool whose workers also take one of the
+// process-wide `slots` for `userID` while borrowed.
+func NewFairShareWorkerPool(ctx context.Context, workerCount uint64, workerFactory WorkerFactory, slots *WorkerSlots, userID string, nearHead bool) WorkerPool {
+	return &fairShareWorkerPool{
+		workerPool: NewWorkerPool(ctx, workerCount, workerFactory).(*workerPool),
+		slots:      slots,
+		userID:     userID,
+		nearHead:   nearHead,
+		releases:   make(map[string]func()),
+	}
+}
+
+type fairShareWorkerPool struct {
+	*workerPool
+	slots    *WorkerSlots
+	userID   string
+	nearHead bool
+
+	mu       sync.Mutex
+	releases map[string]func()
+}
+
+func (p *fairShareWorkerPool) Borrow(ctx context.Context) Worker {
+	worker := p.workerPool.Borrow(ctx)
+	if worker == nil {
+		return nil
+	}
+
+	release, err := p.slots.Acquire(ctx, p.userID, p.nearHead)
+	if err != nil {
+		reqctx.Logger(ctx).Info("unable to get a worker slot", zap.String("user_id", p.userID), zap.Error(err))
+		p.workerPool.Return(worker)
+		return nil
+	}
+
+	p.mu.Lock()
+	p.releases[worker.ID()] = release
+	p.mu.Unlock()
+	return worker
+}
+
+func (p *fairShareWorkerPool) Return(worker Worker) {
+	p.mu.Lock()
+	release := p.releases[worker.ID()]
+	delete(p.releases, worker.ID())
+	p.mu.Unlock()
+
+	if release != nil {
+		release()
+	}
+	p.workerPool.Return(worker)
+}
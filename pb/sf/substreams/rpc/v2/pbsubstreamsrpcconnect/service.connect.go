@@ -28,6 +28,11 @@ const (
 // StreamClient is a client for the sf.substreams.rpc.v2.Stream service.
 type StreamClient interface {
 	Blocks(context.Context, *connect_go.Request[v2.Request]) (*connect_go.ServerStreamForClient[v2.Response], error)
+	// SinkBlocks is a variant of `Blocks` where the client acknowledges the cursors it processed.
+	// The server persists the last acknowledged cursor under the session's key and resumes from
+	// it when the same session reconnects, it also stops streaming data when too many messages
+	// are awaiting acknowledgement.
+	SinkBlocks(context.Context) *connect_go.BidiStreamForClient[v2.SinkRequest, v2.Response]
 }
 
 // NewStreamClient constructs a client for the sf.substreams.rpc.v2.Stream service. By default, it
@@ -45,12 +50,18 @@ func NewStreamClient(httpClient connect_go.HTTPClient, baseURL string, opts ...c
 			baseURL+"/sf.substreams.rpc.v2.Stream/Blocks",
 			opts...,
 		),
+		sinkBlocks: connect_go.NewClient[v2.SinkRequest, v2.Response](
+			httpClient,
+			baseURL+"/sf.substreams.rpc.v2.Stream/SinkBlocks",
+			opts...,
+		),
 	}
 }
 
 // streamClient implements StreamClient.
 type streamClient struct {
-	blocks *connect_go.Client[v2.Request, v2.Response]
+	blocks     *connect_go.Client[v2.Request, v2.Response]
+	sinkBlocks *connect_go.Client[v2.SinkRequest, v2.Response]
 }
 
 // Blocks calls sf.substreams.rpc.v2.Stream.Blocks.
@@ -58,9 +69,19 @@ func (c *streamClient) Blocks(ctx context.Context, req *connect_go.Request[v2.Re
 	return c.blocks.CallServerStream(ctx, req)
 }
 
+// SinkBlocks calls sf.substreams.rpc.v2.Stream.SinkBlocks.
+func (c *streamClient) SinkBlocks(ctx context.Context) *connect_go.BidiStreamForClient[v2.SinkRequest, v2.Response] {
+	return c.sinkBlocks.CallBidiStream(ctx)
+}
+
 // StreamHandler is an implementation of the sf.substreams.rpc.v2.Stream service.
 type StreamHandler interface {
 	Blocks(context.Context, *connect_go.Request[v2.Request], *connect_go.ServerStream[v2.Response]) error
+	// SinkBlocks is a variant of `Blocks` where the client acknowledges the cursors it processed.
+	// The server persists the last acknowledged cursor under the session's key and resumes from
+	// it when the same session reconnects, it also stops streaming data when too many messages
+	// are awaiting acknowledgement.
+	SinkBlocks(context.Context, *connect_go.BidiStream[v2.SinkRequest, v2.Response]) error
 }
 
 // NewStreamHandler builds an HTTP handler from the service implementation. It returns the path on
@@ -75,6 +96,11 @@ func NewStreamHandler(svc StreamHandler, opts ...connect_go.HandlerOption) (stri
 		svc.Blocks,
 		opts...,
 	))
+	mux.Handle("/sf.substreams.rpc.v2.Stream/SinkBlocks", connect_go.NewBidiStreamHandler(
+		"/sf.substreams.rpc.v2.Stream/SinkBlocks",
+		svc.SinkBlocks,
+		opts...,
+	))
 	return "/sf.substreams.rpc.v2.Stream/", mux
 }
 
@@ -84,3 +110,7 @@ type UnimplementedStreamHandler struct{}
 func (UnimplementedStreamHandler) Blocks(context.Context, *connect_go.Request[v2.Request], *connect_go.ServerStream[v2.Response]) error {
 	return connect_go.NewError(connect_go.CodeUnimplemented, errors.New("sf.substreams.rpc.v2.Stream.Blocks is not implemented"))
 }
+
+func (UnimplementedStreamHandler) SinkBlocks(context.Context, *connect_go.BidiStream[v2.SinkRequest, v2.Response]) error {
+	return connect_go.NewError(connect_go.CodeUnimplemented, errors.New("sf.substreams.rpc.v2.Stream.SinkBlocks is not implemented"))
+}
@@ -23,6 +23,11 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type StreamClient interface {
 	Blocks(ctx context.Context, in *Request, opts ...grpc.CallOption) (Stream_BlocksClient, error)
+	// SinkBlocks is a variant of `Blocks` where the client acknowledges the cursors it processed.
+	// The server persists the last acknowledged cursor under the session's key and resumes from
+	// it when the same session reconnects, it also stops streaming data when too many messages
+	// are awaiting acknowledgement.
+	SinkBlocks(ctx context.Context, opts ...grpc.CallOption) (Stream_SinkBlocksClient, error)
 }
 
 type streamClient struct {
@@ -65,11 +70,47 @@ func (x *streamBlocksClient) Recv() (*Response, error) {
 	return m, nil
 }
 
+func (c *streamClient) SinkBlocks(ctx context.Context, opts ...grpc.CallOption) (Stream_SinkBlocksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Stream_ServiceDesc.Streams[1], "/sf.substreams.rpc.v2.Stream/SinkBlocks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &streamSinkBlocksClient{stream}
+	return x, nil
+}
+
+type Stream_SinkBlocksClient interface {
+	Send(*SinkRequest) error
+	Recv() (*Response, error)
+	grpc.ClientStream
+}
+
+type streamSinkBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *streamSinkBlocksClient) Send(m *SinkRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *streamSinkBlocksClient) Recv() (*Response, error) {
+	m := new(Response)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // StreamServer is the server API for Stream service.
 // All implementations should embed UnimplementedStreamServer
 // for forward compatibility
 type StreamServer interface {
 	Blocks(*Request, Stream_BlocksServer) error
+	// SinkBlocks is a variant of `Blocks` where the client acknowledges the cursors it processed.
+	// The server persists the last acknowledged cursor under the session's key and resumes from
+	// it when the same session reconnects, it also stops streaming data when too many messages
+	// are awaiting acknowledgement.
+	SinkBlocks(Stream_SinkBlocksServer) error
 }
 
 // UnimplementedStreamServer should be embedded to have forward compatible implementations.
@@ -79,6 +120,9 @@ type UnimplementedStreamServer struct {
 func (UnimplementedStreamServer) Blocks(*Request, Stream_BlocksServer) error {
 	return status.Errorf(codes.Unimplemented, "method Blocks not implemented")
 }
+func (UnimplementedStreamServer) SinkBlocks(Stream_SinkBlocksServer) error {
+	return status.Errorf(codes.Unimplemented, "method SinkBlocks not implemented")
+}
 
 // UnsafeStreamServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to StreamServer will
@@ -112,6 +156,32 @@ func (x *streamBlocksServer) Send(m *Response) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Stream_SinkBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(StreamServer).SinkBlocks(&streamSinkBlocksServer{stream})
+}
+
+type Stream_SinkBlocksServer interface {
+	Send(*Response) error
+	Recv() (*SinkRequest, error)
+	grpc.ServerStream
+}
+
+type streamSinkBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *streamSinkBlocksServer) Send(m *Response) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *streamSinkBlocksServer) Recv() (*SinkRequest, error) {
+	m := new(SinkRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Stream_ServiceDesc is the grpc.ServiceDesc for Stream service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -125,6 +195,12 @@ var Stream_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Stream_Blocks_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SinkBlocks",
+			Handler:       _Stream_SinkBlocks_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "sf/substreams/rpc/v2/service.proto",
 }
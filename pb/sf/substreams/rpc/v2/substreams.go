@@ -2,6 +2,7 @@ package pbsubstreamsrpc
 
 import (
 	"fmt"
+	"time"
 
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 )
@@ -54,6 +55,9 @@ func (s *StoreModuleOutput) ToAny() *AnyModuleOutput {
 
 func (bd *BlockScopedData) AllModuleOutputs() (out []*AnyModuleOutput) {
 	out = append(out, bd.Output.ToAny())
+	for _, mapOut := range bd.ExtraOutputs {
+		out = append(out, mapOut.ToAny())
+	}
 	for _, mapOut := range bd.DebugMapOutputs {
 		out = append(out, mapOut.ToAny())
 	}
@@ -63,6 +67,23 @@ func (bd *BlockScopedData) AllModuleOutputs() (out []*AnyModuleOutput) {
 	return
 }
 
+// Summary renders the estimate on one line, for progress displays
+func (e *ProgressEstimate) Summary() string {
+	eta := "unknown"
+	switch {
+	case e.TotalBlocks != 0 && e.ProcessedBlocks >= e.TotalBlocks:
+		eta = "done"
+	case e.EtaNanoSeconds != 0:
+		eta = time.Duration(e.EtaNanoSeconds).Round(time.Second).String()
+	}
+
+	out := fmt.Sprintf("ETA %s, %.0f blocks/sec, %d/%d blocks, %d jobs running, %d queued", eta, e.BlocksPerSecond, e.ProcessedBlocks, e.TotalBlocks, e.JobsRunning, e.JobsQueued)
+	if e.SquashedUpToBlock != 0 {
+		out += fmt.Sprintf(", squashed up to #%d", e.SquashedUpToBlock)
+	}
+	return out
+}
+
 func (req *Request) Validate() error {
 	seenStores := map[string]bool{}
 
@@ -78,8 +99,18 @@ func (req *Request) Validate() error {
 		return fmt.Errorf("cannot set 'debug-modules-initial-snapshot' in 'production-mode'")
 	}
 
+	extraOutputModules := map[string]bool{}
+	for _, name := range req.ExtraOutputModules {
+		if name == req.OutputModule || extraOutputModules[name] {
+			return fmt.Errorf("extra output module %q is requested more than once", name)
+		}
+		extraOutputModules[name] = true
+	}
+
 	outputModuleFound := false
+	seenModules := map[string]bool{}
 	for _, mod := range req.Modules.Modules {
+		seenModules[mod.Name] = true
 		if _, ok := mod.Kind.(*pbsubstreams.Module_KindStore_); ok {
 			seenStores[mod.Name] = true
 		}
@@ -89,10 +120,20 @@ func (req *Request) Validate() error {
 			}
 			outputModuleFound = true
 		}
+		if extraOutputModules[mod.Name] {
+			if _, ok := mod.Kind.(*pbsubstreams.Module_KindStore_); ok {
+				return fmt.Errorf("extra output module %q must be of kind 'map'", mod.Name)
+			}
+		}
 	}
 	if !outputModuleFound {
 		return fmt.Errorf("output module %q not found in modules", req.OutputModule)
 	}
+	for _, name := range req.ExtraOutputModules {
+		if !seenModules[name] {
+			return fmt.Errorf("extra output module %q not found in modules", name)
+		}
+	}
 
 	for _, storeSnapshot := range req.DebugInitialStoreSnapshotForModules {
 		if !seenStores[storeSnapshot] {
@@ -3,6 +3,7 @@ package pbsubstreamsrpc
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,6 +21,10 @@ func Test_ValidateRequest(t *testing.T) {
 		{"no modules found in request", &Request{StartBlockNum: 1}, fmt.Errorf("no modules found in request")},
 		{"store output module is accepted for sub-request", TestNewRequest(1, withTestOutputModule("output_mod_1"), withTestStoreModule("output_mod_1")), fmt.Errorf("output module must be of kind 'map'")},
 		{"production mode should fail with debug flag", TestNewRequest(1, withTestOutputModule("output_mod_1"), withTestMapModule("output_mod_1"), withProductionMode(), withDebugSnapshotsModule("output_mod_1")), fmt.Errorf("cannot set 'debug-modules-initial-snapshot' in 'production-mode'")},
+		{"extra output modules", TestNewRequest(1, withTestOutputModule("output_mod_1"), withTestExtraOutputModule("output_mod_2"), withTestMapModule("output_mod_1"), withTestMapModule("output_mod_2"), withProductionMode()), nil},
+		{"extra output module not found", TestNewRequest(1, withTestOutputModule("output_mod_1"), withTestExtraOutputModule("output_mod_2"), withTestMapModule("output_mod_1")), fmt.Errorf("extra output module \"output_mod_2\" not found in modules")},
+		{"extra output module is the output module", TestNewRequest(1, withTestOutputModule("output_mod_1"), withTestExtraOutputModule("output_mod_1"), withTestMapModule("output_mod_1")), fmt.Errorf("extra output module \"output_mod_1\" is requested more than once")},
+		{"extra output module of kind store", TestNewRequest(1, withTestOutputModule("output_mod_1"), withTestExtraOutputModule("store_mod"), withTestMapModule("output_mod_1"), withTestStoreModule("store_mod")), fmt.Errorf("extra output module \"store_mod\" must be of kind 'map'")},
 	}
 
 	for _, test := range tests {
@@ -34,3 +39,18 @@ func Test_ValidateRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestProgressEstimate_Summary(t *testing.T) {
+	tests := []struct {
+		estimate *ProgressEstimate
+		expect   string
+	}{
+		{&ProgressEstimate{TotalBlocks: 100, JobsQueued: 2}, "ETA unknown, 0 blocks/sec, 0/100 blocks, 0 jobs running, 2 queued"},
+		{&ProgressEstimate{ProcessedBlocks: 40, TotalBlocks: 100, BlocksPerSecond: 20, EtaNanoSeconds: uint64(3 * time.Second), JobsRunning: 1, SquashedUpToBlock: 40}, "ETA 3s, 20 blocks/sec, 40/100 blocks, 1 jobs running, 0 queued, squashed up to #40"},
+		{&ProgressEstimate{ProcessedBlocks: 100, TotalBlocks: 100, BlocksPerSecond: 25}, "ETA done, 25 blocks/sec, 100/100 blocks, 0 jobs running, 0 queued"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expect, test.estimate.Summary())
+	}
+}
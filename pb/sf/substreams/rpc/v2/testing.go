@@ -36,6 +36,13 @@ func withTestOutputModule(module string) testNewRequestOption {
 	}
 }
 
+func withTestExtraOutputModule(module string) testNewRequestOption {
+	return func(req *Request) *Request {
+		req.ExtraOutputModules = append(req.ExtraOutputModules, module)
+		return req
+	}
+}
+
 func withTestStoreModule(name string) testNewRequestOption {
 	return func(req *Request) *Request {
 		req.Modules.Modules = append(req.Modules.Modules, TestNewStoreModule(name))
@@ -64,11 +64,14 @@ func (e *BaseExecutor) wasmCall(outputGetter execout.ExecutionOutputGetter) (ins
 		}
 
 		if err = instance.Execute(); err != nil {
-			errExecutor := ErrorExecutor{
-				message:    err.Error(),
-				stackTrace: instance.ExecutionStack,
+			errExecutor := &ErrorExecutor{
+				moduleName:    e.moduleName,
+				message:       err.Error(),
+				stackTrace:    instance.ExecutionStack,
+				logs:          instance.Logs,
+				logsTruncated: instance.ReachedLogsMaxByteCount(),
 			}
-			return nil, fmt.Errorf("block %d: module %q: wasm execution failed: %v", clock.Number, e.moduleName, errExecutor.Error())
+			return nil, fmt.Errorf("block %d: module %q: wasm execution failed: %w", clock.Number, e.moduleName, errExecutor)
 		}
 		err = instance.Cleanup()
 
@@ -0,0 +1,219 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	pbssinternal "github.com/streamingfast/substreams/pb/sf/substreams/intern/v2"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/pipeline/jq"
+	"github.com/streamingfast/substreams/reqctx"
+	"github.com/streamingfast/substreams/storage/execout"
+)
+
+// BlocksModuleExecutor runs modules of kind 'blocks'. Instead of calling into WASM, it applies
+// the module's field mask and filter directly on the source block.
+type BlocksModuleExecutor struct {
+	moduleName  string
+	sourceType  string
+	messageType protoreflect.MessageType
+	mask        fieldMask
+	filter      *jq.Predicate
+}
+
+var _ ModuleExecutor = (*BlocksModuleExecutor)(nil)
+
+func NewBlocksModuleExecutor(moduleName string, sourceType string, kind *pbsubstreams.Module_KindBlocks) (*BlocksModuleExecutor, error) {
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(sourceType))
+	if err != nil {
+		return nil, fmt.Errorf("source type %q is not known by this server: %w", sourceType, err)
+	}
+
+	mask, err := newFieldMask(messageType.Descriptor(), kind.FieldMask)
+	if err != nil {
+		return nil, fmt.Errorf("field mask: %w", err)
+	}
+
+	executor := &BlocksModuleExecutor{
+		moduleName:  moduleName,
+		sourceType:  sourceType,
+		messageType: messageType,
+		mask:        mask,
+	}
+
+	if kind.Filter != "" {
+		executor.filter, err = jq.NewPredicate(kind.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("filter: %w", err)
+		}
+	}
+
+	return executor, nil
+}
+
+func (e *BlocksModuleExecutor) Name() string   { return e.moduleName }
+func (e *BlocksModuleExecutor) String() string { return e.Name() }
+func (e *BlocksModuleExecutor) ResetWASMCall() {}
+func (e *BlocksModuleExecutor) FreeMem()       {}
+
+func (e *BlocksModuleExecutor) applyCachedOutput([]byte) error { return nil }
+
+func (e *BlocksModuleExecutor) run(ctx context.Context, reader execout.ExecutionOutputGetter) (out []byte, moduleOutputData *pbssinternal.ModuleOutput, err error) {
+	_, span := reqctx.WithSpan(ctx, "exec_blocks")
+	defer span.EndWithErr(&err)
+
+	blockBytes, _, err := reader.Get(e.sourceType)
+	if err != nil {
+		return nil, nil, fmt.Errorf("input data for %q: %w", e.sourceType, err)
+	}
+
+	out, err = e.transform(blockBytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("block %d: module %q: %w", reader.Clock().Number, e.moduleName, err)
+	}
+
+	modOut, err := e.toModuleOutput(out)
+	if err != nil {
+		return nil, nil, fmt.Errorf("converting back to module output: %w", err)
+	}
+	return out, modOut, nil
+}
+
+// transform returns the masked block bytes, or nil if the block was rejected by the filter.
+func (e *BlocksModuleExecutor) transform(blockBytes []byte) ([]byte, error) {
+	if e.mask == nil && e.filter == nil {
+		return blockBytes, nil
+	}
+
+	msg := e.messageType.New()
+	if err := proto.Unmarshal(blockBytes, msg.Interface()); err != nil {
+		return nil, fmt.Errorf("unmarshalling source block: %w", err)
+	}
+
+	if e.mask != nil {
+		e.mask.apply(msg)
+	}
+
+	if e.filter != nil {
+		matches, err := e.filter.Matches(msg.Interface())
+		if err != nil {
+			return nil, fmt.Errorf("evaluating filter: %w", err)
+		}
+		if !matches {
+			return nil, nil
+		}
+	}
+
+	if e.mask == nil {
+		return blockBytes, nil
+	}
+
+	out, err := proto.Marshal(msg.Interface())
+	if err != nil {
+		return nil, fmt.Errorf("marshalling transformed block: %w", err)
+	}
+	return out, nil
+}
+
+func (e *BlocksModuleExecutor) toModuleOutput(data []byte) (*pbssinternal.ModuleOutput, error) {
+	return &pbssinternal.ModuleOutput{
+		Data: &pbssinternal.ModuleOutput_MapOutput{
+			MapOutput: &anypb.Any{TypeUrl: "type.googleapis.com/" + e.sourceType, Value: data},
+		},
+	}, nil
+}
+
+func (e *BlocksModuleExecutor) HasValidOutput() bool { return true }
+
+func (e *BlocksModuleExecutor) moduleLogs() (logs []string, truncated bool) { return nil, false }
+func (e *BlocksModuleExecutor) currentExecutionStack() []string             { return nil }
+
+// fieldMask is a tree of field names to keep, a nil sub-tree means the whole field is kept.
+type fieldMask map[protoreflect.Name]fieldMask
+
+func newFieldMask(descriptor protoreflect.MessageDescriptor, paths []string) (fieldMask, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	root := fieldMask{}
+	for _, path := range paths {
+		node := root
+		msgDesc := descriptor
+		segments := strings.Split(path, ".")
+		for i, segment := range segments {
+			if msgDesc == nil {
+				return nil, fmt.Errorf("path %q: field %q is not a message", path, segments[i-1])
+			}
+
+			field := msgDesc.Fields().ByName(protoreflect.Name(segment))
+			if field == nil {
+				return nil, fmt.Errorf("path %q: field %q not found in message %q", path, segment, msgDesc.FullName())
+			}
+
+			isLast := i == len(segments)-1
+			child, seen := node[field.Name()]
+			if seen && child == nil {
+				// A parent path already keeps this whole field
+				break
+			}
+			if isLast {
+				node[field.Name()] = nil
+				break
+			}
+			if child == nil {
+				child = fieldMask{}
+				node[field.Name()] = child
+			}
+
+			node = child
+			msgDesc = field.Message()
+			if field.IsMap() {
+				msgDesc = field.MapValue().Message()
+			}
+		}
+	}
+
+	return root, nil
+}
+
+func (m fieldMask) apply(msg protoreflect.Message) {
+	var toClear []protoreflect.FieldDescriptor
+	msg.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		child, found := m[field.Name()]
+		if !found {
+			toClear = append(toClear, field)
+			return true
+		}
+		if child == nil {
+			return true
+		}
+
+		switch {
+		case field.IsList():
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				child.apply(list.Get(i).Message())
+			}
+		case field.IsMap():
+			value.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+				child.apply(v.Message())
+				return true
+			})
+		default:
+			child.apply(value.Message())
+		}
+		return true
+	})
+
+	for _, field := range toClear {
+		msg.Clear(field)
+	}
+	msg.SetUnknown(nil)
+}
@@ -0,0 +1,132 @@
+package exec
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+// The `sf.substreams.v1.Module` message is used as a stand-in for a chain's block, it is
+// registered in the global registry and has nested, repeated and oneof fields.
+const testSourceType = "sf.substreams.v1.Module"
+
+func testSourceBlock(name string) []byte {
+	cnt, err := proto.Marshal(&pbsubstreams.Module{
+		Name:         name,
+		InitialBlock: 10,
+		Kind:         &pbsubstreams.Module_KindMap_{KindMap: &pbsubstreams.Module_KindMap{OutputType: "proto:test"}},
+		Inputs: []*pbsubstreams.Module_Input{
+			{Input: &pbsubstreams.Module_Input_Source_{Source: &pbsubstreams.Module_Input_Source{Type: "a"}}},
+			{Input: &pbsubstreams.Module_Input_Map_{Map: &pbsubstreams.Module_Input_Map{ModuleName: "b"}}},
+		},
+		Output: &pbsubstreams.Module_Output{Type: "proto:test"},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return cnt
+}
+
+func TestBlocksModuleExecutor_Run(t *testing.T) {
+	tests := []struct {
+		name        string
+		kind        *pbsubstreams.Module_KindBlocks
+		block       []byte
+		expected    *pbsubstreams.Module
+		filteredOut bool
+	}{
+		{
+			name:  "passthrough",
+			kind:  &pbsubstreams.Module_KindBlocks{},
+			block: testSourceBlock("mod"),
+			expected: &pbsubstreams.Module{
+				Name:         "mod",
+				InitialBlock: 10,
+				Kind:         &pbsubstreams.Module_KindMap_{KindMap: &pbsubstreams.Module_KindMap{OutputType: "proto:test"}},
+				Inputs: []*pbsubstreams.Module_Input{
+					{Input: &pbsubstreams.Module_Input_Source_{Source: &pbsubstreams.Module_Input_Source{Type: "a"}}},
+					{Input: &pbsubstreams.Module_Input_Map_{Map: &pbsubstreams.Module_Input_Map{ModuleName: "b"}}},
+				},
+				Output: &pbsubstreams.Module_Output{Type: "proto:test"},
+			},
+		},
+		{
+			name:  "field mask",
+			kind:  &pbsubstreams.Module_KindBlocks{FieldMask: []string{"name", "inputs.source"}},
+			block: testSourceBlock("mod"),
+			expected: &pbsubstreams.Module{
+				Name: "mod",
+				Inputs: []*pbsubstreams.Module_Input{
+					{Input: &pbsubstreams.Module_Input_Source_{Source: &pbsubstreams.Module_Input_Source{Type: "a"}}},
+					{},
+				},
+			},
+		},
+		{
+			name:  "field mask with parent path",
+			kind:  &pbsubstreams.Module_KindBlocks{FieldMask: []string{"output.type", "output"}},
+			block: testSourceBlock("mod"),
+			expected: &pbsubstreams.Module{
+				Output: &pbsubstreams.Module_Output{Type: "proto:test"},
+			},
+		},
+		{
+			name:  "filter matches",
+			kind:  &pbsubstreams.Module_KindBlocks{FieldMask: []string{"name"}, Filter: `.name == "mod"`},
+			block: testSourceBlock("mod"),
+			expected: &pbsubstreams.Module{
+				Name: "mod",
+			},
+		},
+		{
+			name:        "filter does not match",
+			kind:        &pbsubstreams.Module_KindBlocks{Filter: `.name == "other"`},
+			block:       testSourceBlock("mod"),
+			filteredOut: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			executor, err := NewBlocksModuleExecutor("blocks", testSourceType, test.kind)
+			require.NoError(t, err)
+
+			output := &MockExecOutput{
+				clockFunc: func() *pbsubstreams.Clock { return &pbsubstreams.Clock{Number: 1} },
+				cacheMap:  map[string][]byte{testSourceType: test.block},
+			}
+
+			out, moduleOutput, err := executor.run(context.Background(), output)
+			require.NoError(t, err)
+			assert.Equal(t, "type.googleapis.com/"+testSourceType, moduleOutput.GetMapOutput().TypeUrl)
+
+			if test.filteredOut {
+				assert.Len(t, out, 0)
+				return
+			}
+
+			actual := &pbsubstreams.Module{}
+			require.NoError(t, proto.Unmarshal(out, actual))
+			assert.True(t, proto.Equal(test.expected, actual), "expected %s, got %s", test.expected, actual)
+		})
+	}
+}
+
+func TestNewBlocksModuleExecutor_Errors(t *testing.T) {
+	_, err := NewBlocksModuleExecutor("blocks", "unknown.Block", &pbsubstreams.Module_KindBlocks{})
+	assert.Error(t, err)
+
+	_, err = NewBlocksModuleExecutor("blocks", testSourceType, &pbsubstreams.Module_KindBlocks{FieldMask: []string{"unknown"}})
+	assert.Error(t, err)
+
+	_, err = NewBlocksModuleExecutor("blocks", testSourceType, &pbsubstreams.Module_KindBlocks{FieldMask: []string{"name.sub"}})
+	assert.Error(t, err)
+
+	_, err = NewBlocksModuleExecutor("blocks", testSourceType, &pbsubstreams.Module_KindBlocks{Filter: `.name ==`})
+	assert.Error(t, err)
+}
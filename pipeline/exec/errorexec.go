@@ -2,9 +2,13 @@ package exec
 
 import "bytes"
 
+// ErrorExecutor is the failure of a module on its input, running it again fails the same way
 type ErrorExecutor struct {
-	message    string
-	stackTrace []string
+	moduleName    string
+	message       string
+	stackTrace    []string
+	logs          []string
+	logsTruncated bool
 }
 
 func (e *ErrorExecutor) Error() string {
@@ -23,3 +27,10 @@ func (e *ErrorExecutor) Error() string {
 
 	return b.String()
 }
+
+func (e *ErrorExecutor) ModuleName() string { return e.moduleName }
+
+func (e *ErrorExecutor) Reason() string { return e.message }
+
+// Logs returns the logs of the failed execution, and if they were truncated
+func (e *ErrorExecutor) Logs() ([]string, bool) { return e.logs, e.logsTruncated }
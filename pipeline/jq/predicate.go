@@ -0,0 +1,69 @@
+// Package jq evaluates `jq` expressions against Protobuf messages, it is used to filter
+// blocks and module outputs on the server side.
+package jq
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/itchyny/gojq"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Predicate is a compiled `jq` expression, a message matches when the first result
+// of the expression is neither `null` nor `false`.
+type Predicate struct {
+	expression string
+	code       *gojq.Code
+}
+
+func NewPredicate(expression string) (*Predicate, error) {
+	query, err := gojq.Parse(expression)
+	if err != nil {
+		return nil, fmt.Errorf("parsing expression %q: %w", expression, err)
+	}
+
+	code, err := gojq.Compile(query)
+	if err != nil {
+		return nil, fmt.Errorf("compiling expression %q: %w", expression, err)
+	}
+
+	return &Predicate{expression: expression, code: code}, nil
+}
+
+func (p *Predicate) String() string { return p.expression }
+
+func (p *Predicate) Matches(msg proto.Message) (bool, error) {
+	input, err := protoMessageToInput(msg)
+	if err != nil {
+		return false, err
+	}
+
+	iter := p.code.Run(input)
+	v, ok := iter.Next()
+	if !ok {
+		return false, nil
+	}
+	if err, ok := v.(error); ok {
+		return false, err
+	}
+
+	return v != nil && v != false, nil
+}
+
+// protoMessageToInput turns a message into the generic JSON structure expected by `gojq`, fields
+// are named after their Protobuf field names. Following the Protobuf JSON mapping, 64 bits integers
+// are represented as strings.
+func protoMessageToInput(msg proto.Message) (interface{}, error) {
+	cnt, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling to json: %w", err)
+	}
+
+	var out interface{}
+	if err := json.Unmarshal(cnt, &out); err != nil {
+		return nil, fmt.Errorf("unmarshalling json: %w", err)
+	}
+	return out, nil
+}
@@ -13,7 +13,8 @@ type Graph struct {
 	moduleHashes   *manifest.ModuleHashes
 	stores         []*pbsubstreams.Module // subset of allModules: only the stores
 
-	outputModule *pbsubstreams.Module
+	outputModule       *pbsubstreams.Module
+	extraOutputModules []*pbsubstreams.Module // streamed in lock-step with the `outputModule`
 
 	schedulableModules      []*pbsubstreams.Module // stores and output mappers needed to execute to produce output for all `output_modules`.
 	schedulableAncestorsMap map[string][]string    // modules that are ancestors (therefore dependencies) of a given module
@@ -25,25 +26,35 @@ func (g *Graph) UsedModules() []*pbsubstreams.Module  { return g.usedModules }
 func (g *Graph) IsOutputModule(name string) bool      { return g.outputModule.Name == name }
 func (g *Graph) ModuleHashes() *manifest.ModuleHashes { return g.moduleHashes }
 
-func NewOutputModuleGraph(outputModule string, productionMode bool, modules *pbsubstreams.Modules) (out *Graph, err error) {
+func (g *Graph) ExtraOutputModules() []*pbsubstreams.Module { return g.extraOutputModules }
+func (g *Graph) IsExtraOutputModule(name string) bool {
+	for _, module := range g.extraOutputModules {
+		if module.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func NewOutputModuleGraph(outputModule string, extraOutputModules []string, productionMode bool, modules *pbsubstreams.Modules) (out *Graph, err error) {
 	out = &Graph{
 		requestModules: modules,
 	}
-	if err := out.computeGraph(outputModule, productionMode, modules); err != nil {
+	if err := out.computeGraph(outputModule, extraOutputModules, productionMode, modules); err != nil {
 		return nil, fmt.Errorf("module graph: %w", err)
 	}
 
 	return out, nil
 }
 
-func (g *Graph) computeGraph(outputModule string, productionMode bool, modules *pbsubstreams.Modules) error {
+func (g *Graph) computeGraph(outputModule string, extraOutputModules []string, productionMode bool, modules *pbsubstreams.Modules) error {
 	graph, err := manifest.NewModuleGraph(modules.Modules)
 	if err != nil {
 		return fmt.Errorf("compute graph: %w", err)
 	}
-	outputModuleName := outputModule
+	outputModuleNames := append([]string{outputModule}, extraOutputModules...)
 
-	processModules, err := graph.ModulesDownTo(outputModuleName)
+	processModules, err := modulesDownTo(graph, outputModuleNames, graph.ModulesDownTo)
 	if err != nil {
 		return fmt.Errorf("building execution moduleGraph: %w", err)
 	}
@@ -52,15 +63,22 @@ func (g *Graph) computeGraph(outputModule string, productionMode bool, modules *
 		return fmt.Errorf("cannot hash module: %w", err)
 	}
 
-	g.outputModule = computeOutputModule(g.usedModules, outputModuleName)
+	g.outputModule = computeOutputModule(g.usedModules, outputModule)
+	for _, name := range extraOutputModules {
+		g.extraOutputModules = append(g.extraOutputModules, computeOutputModule(g.usedModules, name))
+	}
 
-	storeModules, err := graph.StoresDownTo(g.outputModule.Name)
+	storeModules, err := modulesDownTo(graph, outputModuleNames, graph.StoresDownTo)
 	if err != nil {
 		return fmt.Errorf("stores down: %w", err)
 	}
 	g.stores = storeModules
 
 	g.schedulableModules = computeSchedulableModules(storeModules, g.outputModule, productionMode)
+	if productionMode {
+		// extra output modules are always mappers, produced in parallel like the output module
+		g.schedulableModules = append(g.schedulableModules, g.extraOutputModules...)
+	}
 
 	ancestorsMap, err := computeSchedulableAncestors(graph, g.schedulableModules)
 	if err != nil {
@@ -71,6 +89,38 @@ func (g *Graph) computeGraph(outputModule string, productionMode bool, modules *
 	return nil
 }
 
+// modulesDownTo merges the modules returned by `downTo` for each of the `moduleNames`,
+// keeping the execution order of the graph.
+func modulesDownTo(graph *manifest.ModuleGraph, moduleNames []string, downTo func(string) ([]*pbsubstreams.Module, error)) ([]*pbsubstreams.Module, error) {
+	if len(moduleNames) == 1 {
+		return downTo(moduleNames[0])
+	}
+
+	wanted := map[string]bool{}
+	for _, name := range moduleNames {
+		modules, err := downTo(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, module := range modules {
+			wanted[module.Name] = true
+		}
+	}
+
+	sortedModules, ok := graph.TopologicalSort()
+	if !ok {
+		return nil, fmt.Errorf("could not get topological sort of module graph")
+	}
+
+	var out []*pbsubstreams.Module
+	for i := len(sortedModules) - 1; i >= 0; i-- {
+		if wanted[sortedModules[i].Name] {
+			out = append(out, sortedModules[i])
+		}
+	}
+	return out, nil
+}
+
 func computeOutputModule(mods []*pbsubstreams.Module, outputModule string) *pbsubstreams.Module {
 	for _, module := range mods {
 		if module.Name == outputModule {
@@ -131,8 +181,10 @@ func (g *Graph) hashModules(graph *manifest.ModuleGraph) error {
 }
 
 func (g *Graph) ValidateRequestStartBlock(requestStartBlockNum uint64) error {
-	if requestStartBlockNum < g.outputModule.InitialBlock {
-		return fmt.Errorf("start block %d smaller than request outputs for module %q with start block %d", requestStartBlockNum, g.outputModule.Name, g.outputModule.InitialBlock)
+	for _, module := range append([]*pbsubstreams.Module{g.outputModule}, g.extraOutputModules...) {
+		if requestStartBlockNum < module.InitialBlock {
+			return fmt.Errorf("start block %d smaller than request outputs for module %q with start block %d", requestStartBlockNum, module.Name, module.InitialBlock)
+		}
 	}
 	return nil
 }
@@ -3,9 +3,11 @@ package outputmodules
 import (
 	"testing"
 
+	"github.com/streamingfast/substreams/manifest"
 	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGraph_computeSchedulableModules(t *testing.T) {
@@ -54,3 +56,37 @@ func TestGraph_computeSchedulableModules(t *testing.T) {
 	}
 
 }
+
+func TestNewOutputModuleGraph_ExtraOutputModules(t *testing.T) {
+	modules := &pbsubstreams.Modules{Modules: manifest.NewTestModules(), Binaries: []*pbsubstreams.Binary{{}}}
+	for _, module := range modules.Modules {
+		if module.Name == "D" {
+			module.InitialBlock = 10
+		}
+	}
+
+	graph, err := NewOutputModuleGraph("C", []string{"D"}, true, modules)
+	require.NoError(t, err)
+
+	assert.Equal(t, "C", graph.OutputModule().Name)
+	assert.Equal(t, []string{"D"}, moduleNames(graph.ExtraOutputModules()))
+	assert.True(t, graph.IsExtraOutputModule("D"))
+	assert.False(t, graph.IsExtraOutputModule("C"))
+
+	assert.ElementsMatch(t, []string{"As", "Am", "B", "C", "D"}, moduleNames(graph.UsedModules()))
+	assert.ElementsMatch(t, []string{"As", "B"}, moduleNames(graph.Stores()))
+	assert.ElementsMatch(t, []string{"As", "B", "C", "D"}, graph.SchedulableModuleNames())
+
+	executed := map[string]bool{}
+	for _, module := range graph.UsedModules() {
+		for _, input := range module.Inputs {
+			if dep := input.GetMap().GetModuleName() + input.GetStore().GetModuleName(); dep != "" {
+				assert.True(t, executed[dep], "module %q used before its input %q", module.Name, dep)
+			}
+		}
+		executed[module.Name] = true
+	}
+
+	assert.Error(t, graph.ValidateRequestStartBlock(5), "module D starts at block 10")
+	assert.NoError(t, graph.ValidateRequestStartBlock(10))
+}
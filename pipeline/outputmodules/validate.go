@@ -23,6 +23,12 @@ func ValidateTier1Request(request *pbsubstreamsrpc.Request, blockType string) er
 		return err
 	}
 
+	for _, extraOutputModule := range request.ExtraOutputModules {
+		if err := validateModuleGraph(request.Modules.Modules, extraOutputModule, blockType); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
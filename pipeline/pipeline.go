@@ -46,9 +46,10 @@ type Pipeline struct {
 	outputGraph     *outputmodules.Graph
 	moduleExecutors []exec.ModuleExecutor
 
-	mapModuleOutput         *pbsubstreamsrpc.MapModuleOutput
-	extraMapModuleOutputs   []*pbsubstreamsrpc.MapModuleOutput
-	extraStoreModuleOutputs []*pbsubstreamsrpc.StoreModuleOutput
+	mapModuleOutput          *pbsubstreamsrpc.MapModuleOutput
+	extraOutputModuleOutputs []*pbsubstreamsrpc.MapModuleOutput
+	extraMapModuleOutputs    []*pbsubstreamsrpc.MapModuleOutput
+	extraStoreModuleOutputs  []*pbsubstreamsrpc.StoreModuleOutput
 
 	respFunc         func(substreams.ResponseFromAnyTier) error
 	lastProgressSent time.Time
@@ -280,6 +281,12 @@ func (p *Pipeline) saveModuleOutput(output *pbssinternal.ModuleOutput, moduleNam
 		p.mapModuleOutput = toRPCMapModuleOutputs(output)
 		return
 	}
+	if p.outputGraph.IsExtraOutputModule(moduleName) {
+		if mapOutput := toRPCMapModuleOutputs(output); mapOutput != nil {
+			p.extraOutputModuleOutputs = append(p.extraOutputModuleOutputs, mapOutput)
+		}
+		return
+	}
 	if isProduction {
 		return
 	}
@@ -410,6 +417,9 @@ func (p *Pipeline) buildWASM(ctx context.Context, modules []*pbsubstreams.Module
 
 	loadedModules := make(map[uint32]*wasm.Module)
 	for _, module := range modules {
+		if module.GetKindBlocks() != nil {
+			continue
+		}
 		if _, exists := loadedModules[module.BinaryIndex]; exists {
 			continue
 		}
@@ -422,6 +432,15 @@ func (p *Pipeline) buildWASM(ctx context.Context, modules []*pbsubstreams.Module
 	}
 
 	for _, module := range modules {
+		if kind := module.GetKindBlocks(); kind != nil {
+			executor, err := exec.NewBlocksModuleExecutor(module.Name, module.Inputs[0].GetSource().Type, kind)
+			if err != nil {
+				return fmt.Errorf("module %q: %w", module.Name, err)
+			}
+			p.moduleExecutors = append(p.moduleExecutors, executor)
+			continue
+		}
+
 		inputs, err := p.renderWasmInputs(module)
 		if err != nil {
 			return fmt.Errorf("module %q: get wasm inputs: %w", module.Name, err)
@@ -478,6 +497,7 @@ func returnModuleDataOutputs(
 	clock *pbsubstreams.Clock,
 	cursor *bstream.Cursor,
 	mapModuleOutput *pbsubstreamsrpc.MapModuleOutput,
+	extraOutputModuleOutputs []*pbsubstreamsrpc.MapModuleOutput,
 	extraMapModuleOutputs []*pbsubstreamsrpc.MapModuleOutput,
 	extraStoreModuleOutputs []*pbsubstreamsrpc.StoreModuleOutput,
 	respFunc func(substreams.ResponseFromAnyTier) error,
@@ -485,6 +505,7 @@ func returnModuleDataOutputs(
 	out := &pbsubstreamsrpc.BlockScopedData{
 		Clock:             clock,
 		Output:            mapModuleOutput,
+		ExtraOutputs:      extraOutputModuleOutputs,
 		DebugMapOutputs:   extraMapModuleOutputs,
 		DebugStoreOutputs: extraStoreModuleOutputs,
 		Cursor:            cursor.ToOpaque(),
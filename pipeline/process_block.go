@@ -241,7 +241,7 @@ func (p *Pipeline) handleStepNew(ctx context.Context, block *bstream.Block, cloc
 			}
 		}
 		p.pendingUndoMessage = nil
-		if err = returnModuleDataOutputs(clock, cursor, p.mapModuleOutput, p.extraMapModuleOutputs, p.extraStoreModuleOutputs, p.respFunc); err != nil {
+		if err = returnModuleDataOutputs(clock, cursor, p.mapModuleOutput, p.extraOutputModuleOutputs, p.extraMapModuleOutputs, p.extraStoreModuleOutputs, p.respFunc); err != nil {
 			return fmt.Errorf("failed to return module data output: %w", err)
 		}
 	}
@@ -260,6 +260,7 @@ func (p *Pipeline) executeModules(ctx context.Context, execOutput execout.Execut
 	//  Would pave the way towards PATCH'd modules too.
 
 	p.mapModuleOutput = nil
+	p.extraOutputModuleOutputs = nil
 	p.extraMapModuleOutputs = nil
 	p.extraStoreModuleOutputs = nil
 	for _, executor := range p.moduleExecutors {
@@ -16,6 +16,11 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+const (
+	defaultOutputBatchMaxBlocks = 100
+	defaultOutputBatchMaxBytes  = 1024 * 1024
+)
+
 type getBlockFunc func() (uint64, error)
 
 func BuildRequestDetails(
@@ -27,11 +32,23 @@ func BuildRequestDetails(
 	req = &reqctx.RequestDetails{
 		Modules:                             request.Modules,
 		OutputModule:                        request.OutputModule,
+		ExtraOutputModules:                  request.ExtraOutputModules,
 		DebugInitialStoreSnapshotForModules: request.DebugInitialStoreSnapshotForModules,
 		ProductionMode:                      request.ProductionMode,
 		StopBlockNum:                        request.StopBlockNum,
 	}
 
+	if batching := request.OutputBatching; batching != nil {
+		req.OutputBatchMaxBlocks = uint64(batching.MaxBlocks)
+		if req.OutputBatchMaxBlocks == 0 {
+			req.OutputBatchMaxBlocks = defaultOutputBatchMaxBlocks
+		}
+		req.OutputBatchMaxBytes = batching.MaxBytes
+		if req.OutputBatchMaxBytes == 0 {
+			req.OutputBatchMaxBytes = defaultOutputBatchMaxBytes
+		}
+	}
+
 	req.ResolvedStartBlockNum, req.ResolvedCursor, undoSignal, err = resolveStartBlockNum(ctx, request, resolveCursor, getHeadBlock)
 
 	if err != nil {
@@ -45,6 +62,10 @@ func BuildRequestDetails(
 
 	req.LinearHandoffBlockNum = linearHandoff
 
+	if recentFinalBlock, err := getRecentFinalBlock(); err == nil {
+		req.RecentFinalBlockNum = recentFinalBlock
+	}
+
 	return
 }
 
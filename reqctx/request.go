@@ -11,6 +11,7 @@ type RequestDetails struct {
 
 	DebugInitialStoreSnapshotForModules []string
 	OutputModule                        string
+	ExtraOutputModules                  []string
 	// What the user requested, derived from either the Request.StartBlockNum or Request.Cursor
 	ResolvedStartBlockNum uint64
 	ResolvedCursor        string
@@ -18,8 +19,17 @@ type RequestDetails struct {
 	LinearHandoffBlockNum uint64
 	StopBlockNum          uint64
 
+	// RecentFinalBlockNum is the most recent final block of the chain when the
+	// request was received, 0 when unknown
+	RecentFinalBlockNum uint64
+
 	ProductionMode bool
 	IsSubRequest   bool
+
+	// When OutputBatchMaxBlocks is non-zero, the cached outputs streamed before the linear
+	// handoff are grouped in batches bounded by these limits.
+	OutputBatchMaxBlocks uint64
+	OutputBatchMaxBytes  uint64
 }
 
 func (d *RequestDetails) IsOutputModule(modName string) bool {
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/streamingfast/dstore"
 
 	"github.com/streamingfast/substreams/orchestrator/work"
@@ -19,10 +21,35 @@ type RuntimeConfig struct {
 	// and `outputs/` for execution output of both `map` and `store` module kinds
 	BaseObjectStore dstore.Store
 	WorkerFactory   work.WorkerFactory
+	JobRegistry     work.JobRegistry        // if set, shares the jobs of concurrent requests for the same module hash and range
+	WorkerSlots     *work.WorkerSlots       // if set, bounds and shares fairly between users the workers of all requests
+	Prioritizer     work.Prioritizer        // orders the jobs ready to run, the default one if nil
+	Speculation     *work.SpeculationPolicy // if set, runs a duplicate of the jobs taking too long
+	RetryPolicy     work.RetryPolicy        // how jobs failing because of the infrastructure are run again
+	CircuitBreaker  *work.CircuitBreaker    // if set, stops sending jobs to a tier2 endpoint failing repeatedly
 
 	WithRequestStats bool
+
+	// CostAwareJobSplitting records the time taken by tier2 jobs in the state store, and
+	// sizes the jobs of later requests from it to make them last the same time
+	CostAwareJobSplitting bool
+
+	// BackprocessingCheckpoints saves the progress of the backprocessing in the state
+	// store, for the requests processing the same stores to clean up what a crashed run
+	// left behind
+	BackprocessingCheckpoints bool
+
+	// SquashMemoryBudget, if not 0, squashes the partials of stores with associative merges
+	// by loading up to this many bytes of them at once and merging them in pairs concurrently
+	SquashMemoryBudget uint64
+
+	// ProgressEstimateInterval is how often the estimated completion of the backprocessing
+	// is sent to the client, 0 disables the estimates
+	ProgressEstimateInterval time.Duration
 }
 
+const DefaultProgressEstimateInterval = 5 * time.Second
+
 func NewRuntimeConfig(
 	cacheSaveInterval uint64,
 	subrequestsSplitSize uint64,
@@ -33,12 +60,14 @@ func NewRuntimeConfig(
 	workerFactory work.WorkerFactory,
 ) RuntimeConfig {
 	return RuntimeConfig{
-		CacheSaveInterval:    cacheSaveInterval,
-		SubrequestsSplitSize: subrequestsSplitSize,
-		ParallelSubrequests:  parallelSubrequests,
-		MaxJobsAhead:         maxJobsAhead,
-		MaxWasmFuel:          maxWasmFuel,
-		BaseObjectStore:      baseObjectStore,
-		WorkerFactory:        workerFactory,
+		CacheSaveInterval:        cacheSaveInterval,
+		SubrequestsSplitSize:     subrequestsSplitSize,
+		ParallelSubrequests:      parallelSubrequests,
+		RetryPolicy:              work.DefaultRetryPolicy(),
+		ProgressEstimateInterval: DefaultProgressEstimateInterval,
+		MaxJobsAhead:             maxJobsAhead,
+		MaxWasmFuel:              maxWasmFuel,
+		BaseObjectStore:          baseObjectStore,
+		WorkerFactory:            workerFactory,
 	}
 }
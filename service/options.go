@@ -1,6 +1,9 @@
 package service
 
 import (
+	"time"
+
+	"github.com/streamingfast/substreams/orchestrator/work"
 	"github.com/streamingfast/substreams/pipeline"
 	"github.com/streamingfast/substreams/wasm"
 )
@@ -64,3 +67,131 @@ func WithMaxWasmFuelPerBlockModule(maxFuel uint64) Option {
 		}
 	}
 }
+
+// WithJobRegistry replaces the in-process registry sharing the jobs of concurrent
+// requests for the same module hash and range, for example with one spanning many
+// tier1 instances. A nil registry disables the sharing of jobs.
+func WithJobRegistry(registry work.JobRegistry) Option {
+	return func(a anyTierService) {
+		switch s := a.(type) {
+		case *Tier1Service:
+			s.runtimeConfig.JobRegistry = registry
+		}
+	}
+}
+
+// WithWorkerSlots bounds the number of tier2 workers running at once across all the
+// requests of the tier1 service, sharing them fairly between users. Without it, each
+// request runs up to `parallelSubRequests` workers regardless of the others.
+func WithWorkerSlots(slots *work.WorkerSlots) Option {
+	return func(a anyTierService) {
+		switch s := a.(type) {
+		case *Tier1Service:
+			s.runtimeConfig.WorkerSlots = slots
+		}
+	}
+}
+
+// WithCostAwareJobSplitting records the time taken by each tier2 job in the state
+// store, in `costs/<module_hash>.json`, and splits the work of later requests in jobs
+// expected to last the same time, instead of jobs of a fixed number of blocks.
+func WithCostAwareJobSplitting() Option {
+	return func(a anyTierService) {
+		switch s := a.(type) {
+		case *Tier1Service:
+			s.runtimeConfig.CostAwareJobSplitting = true
+		}
+	}
+}
+
+// WithBackprocessingCheckpoints saves the progress of each backprocessing (jobs in
+// flight and partials written not squashed yet) under `checkpoints/` in the state store,
+// by store module, each run renewing the lease of its own checkpoints in the background.
+// The requests processing a store delete the partials left by the jobs of the runs whose
+// lease expired that will never be squashed, unless a live run still tracks them.
+func WithBackprocessingCheckpoints() Option {
+	return func(a anyTierService) {
+		switch s := a.(type) {
+		case *Tier1Service:
+			s.runtimeConfig.BackprocessingCheckpoints = true
+		}
+	}
+}
+
+// WithParallelSquashing squashes the partials of the stores whose merges are associative
+// (SET, SET_IF_NOT_EXISTS, APPEND, MIN, MAX and ADD of integers) in batches: the partials
+// ready to be squashed are loaded concurrently, up to about `memoryBudget` bytes, and
+// those between two full store snapshots are merged in pairs, on many cores, before
+// being merged into the full store.
+func WithParallelSquashing(memoryBudget uint64) Option {
+	return func(a anyTierService) {
+		switch s := a.(type) {
+		case *Tier1Service:
+			s.runtimeConfig.SquashMemoryBudget = memoryBudget
+		}
+	}
+}
+
+// WithSpeculativeExecution makes tier1 run, on a worker with no job ready to run, a
+// duplicate of a tier2 job that has been running for more than `factor` times its
+// expected duration, and at least `minDuration`, preferring the jobs running on another
+// endpoint than the worker's. The first of the two to complete is kept, the other one
+// is canceled. The expected duration of a job comes from the costs recorded for its
+// block range, with `WithCostAwareJobSplitting`, or else from the jobs of the same
+// module already completed by the request. The tier2 running the canceled attempt may
+// still write its partials once squashed, leaving files with the same content.
+func WithSpeculativeExecution(factor float64, minDuration time.Duration) Option {
+	return func(a anyTierService) {
+		switch s := a.(type) {
+		case *Tier1Service:
+			s.runtimeConfig.Speculation = &work.SpeculationPolicy{Factor: factor, MinDuration: minDuration}
+		}
+	}
+}
+
+// WithRetryPolicy sets how tier1 runs again the tier2 jobs failing because of the
+// infrastructure (tier2 unavailable, connection lost, etc.), `work.DefaultRetryPolicy()`
+// being used otherwise. Jobs failing because of their module are never run again.
+func WithRetryPolicy(policy work.RetryPolicy) Option {
+	return func(a anyTierService) {
+		switch s := a.(type) {
+		case *Tier1Service:
+			s.runtimeConfig.RetryPolicy = policy
+		}
+	}
+}
+
+// WithCircuitBreaker stops sending jobs to the tier2 endpoint after `failureThreshold`
+// consecutive infrastructure failures, across all the requests, until a job sent to it
+// after `cooldown` succeeds. Jobs not sent count as failed attempts of their retry policy.
+func WithCircuitBreaker(failureThreshold int, cooldown time.Duration) Option {
+	return func(a anyTierService) {
+		switch s := a.(type) {
+		case *Tier1Service:
+			s.runtimeConfig.CircuitBreaker = work.NewCircuitBreaker(failureThreshold, cooldown)
+		}
+	}
+}
+
+// WithProgressEstimateInterval sets how often tier1 sends to the client the estimated
+// completion of the backprocessing (blocks per second, time left, jobs running and
+// queued), `config.DefaultProgressEstimateInterval` otherwise. 0 disables the estimates.
+func WithProgressEstimateInterval(interval time.Duration) Option {
+	return func(a anyTierService) {
+		switch s := a.(type) {
+		case *Tier1Service:
+			s.runtimeConfig.ProgressEstimateInterval = interval
+		}
+	}
+}
+
+// WithJobPrioritizer sets the strategy ordering the tier2 jobs ready to run, for example
+// one of the built-in strategies returned by `work.NewPrioritizer`.
+func WithJobPrioritizer(prioritizer work.Prioritizer) Option {
+	return func(a anyTierService) {
+		switch s := a.(type) {
+		case *Tier1Service:
+			s.runtimeConfig.Prioritizer = prioritizer
+		}
+	}
+}
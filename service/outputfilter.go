@@ -0,0 +1,171 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/streamingfast/substreams"
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
+	"github.com/streamingfast/substreams/pipeline/jq"
+)
+
+// outputFilter evaluates the request's `output_filter` against the output module's output,
+// `BlockScopedData` responses that do not match are replaced by a `BlockScopedCursor`.
+type outputFilter struct {
+	predicate   *jq.Predicate
+	messageType protoreflect.MessageType
+}
+
+func newOutputFilter(expression string, outputType string, protoFiles []*descriptorpb.FileDescriptorProto) (*outputFilter, error) {
+	if !strings.HasPrefix(outputType, "proto:") {
+		return nil, fmt.Errorf("output type %q is not a protobuf message", outputType)
+	}
+
+	messageType, err := findOutputMessageType(protoreflect.FullName(strings.TrimPrefix(outputType, "proto:")), protoFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	predicate, err := jq.NewPredicate(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	return &outputFilter{
+		predicate:   predicate,
+		messageType: messageType,
+	}, nil
+}
+
+func findOutputMessageType(name protoreflect.FullName, protoFiles []*descriptorpb.FileDescriptorProto) (protoreflect.MessageType, error) {
+	if len(protoFiles) != 0 {
+		files, err := newProtoFiles(protoFiles)
+		if err != nil {
+			return nil, fmt.Errorf("proto files: %w", err)
+		}
+
+		if desc, err := files.FindDescriptorByName(name); err == nil {
+			msgDesc, ok := desc.(protoreflect.MessageDescriptor)
+			if !ok {
+				return nil, fmt.Errorf("output type %q is not a message", name)
+			}
+			return dynamicpb.NewMessageType(msgDesc), nil
+		}
+	}
+
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("output type %q not found in proto files: %w", name, err)
+	}
+	return messageType, nil
+}
+
+// newProtoFiles builds a registry out of the received files, imported files that were not
+// sent are resolved from the definitions known by the server.
+func newProtoFiles(protoFiles []*descriptorpb.FileDescriptorProto) (*protoregistry.Files, error) {
+	set := &descriptorpb.FileDescriptorSet{File: make([]*descriptorpb.FileDescriptorProto, len(protoFiles))}
+	copy(set.File, protoFiles)
+
+	seen := map[string]bool{}
+	for _, file := range set.File {
+		seen[file.GetName()] = true
+	}
+
+	for i := 0; i < len(set.File); i++ {
+		for _, dependency := range set.File[i].Dependency {
+			if seen[dependency] {
+				continue
+			}
+
+			file, err := protoregistry.GlobalFiles.FindFileByPath(dependency)
+			if err != nil {
+				return nil, fmt.Errorf("file %q imports %q which is not part of the request: %w", set.File[i].GetName(), dependency, err)
+			}
+
+			set.File = append(set.File, protodesc.ToFileDescriptorProto(file))
+			seen[dependency] = true
+		}
+	}
+
+	return protodesc.NewFiles(set)
+}
+
+func (f *outputFilter) matches(output *pbsubstreamsrpc.MapModuleOutput) (bool, error) {
+	msg := f.messageType.New().Interface()
+	if err := proto.Unmarshal(output.GetMapOutput().GetValue(), msg); err != nil {
+		return false, fmt.Errorf("unmarshalling output: %w", err)
+	}
+
+	return f.predicate.Matches(msg)
+}
+
+func (f *outputFilter) wrap(respFunc substreams.ResponseFunc) substreams.ResponseFunc {
+	return func(anyResp substreams.ResponseFromAnyTier) error {
+		resp, ok := anyResp.(*pbsubstreamsrpc.Response)
+		if !ok {
+			return respFunc(anyResp)
+		}
+
+		if batch := resp.GetBlockScopedDatas(); batch != nil {
+			return f.filterBatch(batch, respFunc)
+		}
+
+		data := resp.GetBlockScopedData()
+		if data == nil {
+			return respFunc(resp)
+		}
+
+		matches, err := f.matches(data.Output)
+		if err != nil {
+			return fmt.Errorf("output filter on block %d: %w", data.Clock.GetNumber(), err)
+		}
+		if matches {
+			return respFunc(resp)
+		}
+
+		return respFunc(newBlockScopedCursorResponse(data))
+	}
+}
+
+// filterBatch removes the items of `batch` that do not match, a batch left without any item
+// is replaced by the `BlockScopedCursor` of its last block.
+func (f *outputFilter) filterBatch(batch *pbsubstreamsrpc.BlockScopedDatas, respFunc substreams.ResponseFunc) error {
+	var matching []*pbsubstreamsrpc.BlockScopedData
+	for _, data := range batch.Items {
+		matches, err := f.matches(data.Output)
+		if err != nil {
+			return fmt.Errorf("output filter on block %d: %w", data.Clock.GetNumber(), err)
+		}
+		if matches {
+			matching = append(matching, data)
+		}
+	}
+
+	if len(matching) == 0 {
+		return respFunc(newBlockScopedCursorResponse(batch.Items[len(batch.Items)-1]))
+	}
+
+	return respFunc(substreams.NewBlockScopedDatasResponse(&pbsubstreamsrpc.BlockScopedDatas{
+		Items:  matching,
+		Cursor: batch.Cursor,
+	}))
+}
+
+func newBlockScopedCursorResponse(data *pbsubstreamsrpc.BlockScopedData) *pbsubstreamsrpc.Response {
+	return &pbsubstreamsrpc.Response{
+		Message: &pbsubstreamsrpc.Response_BlockScopedCursor{
+			BlockScopedCursor: &pbsubstreamsrpc.BlockScopedCursor{
+				Clock:            data.Clock,
+				Cursor:           data.Cursor,
+				FinalBlockHeight: data.FinalBlockHeight,
+			},
+		},
+	}
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/streamingfast/substreams"
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+func testOutputResponse(t *testing.T, name string) *pbsubstreamsrpc.Response {
+	t.Helper()
+
+	cnt, err := proto.Marshal(&pbsubstreams.Module{Name: name})
+	require.NoError(t, err)
+
+	return substreams.NewBlockScopedDataResponse(&pbsubstreamsrpc.BlockScopedData{
+		Output: &pbsubstreamsrpc.MapModuleOutput{
+			Name:      "map_test",
+			MapOutput: &anypb.Any{TypeUrl: "type.googleapis.com/sf.substreams.v1.Module", Value: cnt},
+		},
+		Clock:            &pbsubstreams.Clock{Id: "00a", Number: 10},
+		Cursor:           "cursor",
+		FinalBlockHeight: 8,
+	})
+}
+
+func TestOutputFilter(t *testing.T) {
+	protoFiles := []*descriptorpb.FileDescriptorProto{
+		// Imports of this file are resolved from the server's known definitions
+		protodesc.ToFileDescriptorProto(pbsubstreams.File_sf_substreams_v1_modules_proto),
+	}
+
+	tests := []struct {
+		name        string
+		protoFiles  []*descriptorpb.FileDescriptorProto
+		outputName  string
+		expectMatch bool
+	}{
+		{"matching, from request proto files", protoFiles, "mod", true},
+		{"not matching, from request proto files", protoFiles, "other", false},
+		{"matching, from server definitions", nil, "mod", true},
+		{"not matching, from server definitions", nil, "other", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter, err := newOutputFilter(`.name == "mod"`, "proto:sf.substreams.v1.Module", test.protoFiles)
+			require.NoError(t, err)
+
+			var received []*pbsubstreamsrpc.Response
+			respFunc := filter.wrap(func(resp substreams.ResponseFromAnyTier) error {
+				received = append(received, resp.(*pbsubstreamsrpc.Response))
+				return nil
+			})
+
+			progress := &pbsubstreamsrpc.Response{Message: &pbsubstreamsrpc.Response_Progress{Progress: &pbsubstreamsrpc.ModulesProgress{}}}
+			require.NoError(t, respFunc(progress))
+
+			data := testOutputResponse(t, test.outputName)
+			require.NoError(t, respFunc(data))
+
+			require.Len(t, received, 2)
+			assert.Equal(t, progress, received[0])
+
+			if test.expectMatch {
+				assert.Equal(t, data, received[1])
+				return
+			}
+
+			assert.True(t, proto.Equal(&pbsubstreamsrpc.BlockScopedCursor{
+				Clock:            &pbsubstreams.Clock{Id: "00a", Number: 10},
+				Cursor:           "cursor",
+				FinalBlockHeight: 8,
+			}, received[1].GetBlockScopedCursor()), "got %s", received[1])
+		})
+	}
+}
+
+func TestOutputFilter_Batch(t *testing.T) {
+	filter, err := newOutputFilter(`.name == "mod"`, "proto:sf.substreams.v1.Module", nil)
+	require.NoError(t, err)
+
+	var received []*pbsubstreamsrpc.Response
+	respFunc := filter.wrap(func(resp substreams.ResponseFromAnyTier) error {
+		received = append(received, resp.(*pbsubstreamsrpc.Response))
+		return nil
+	})
+
+	matching := testOutputResponse(t, "mod").GetBlockScopedData()
+	other := testOutputResponse(t, "other").GetBlockScopedData()
+
+	require.NoError(t, respFunc(substreams.NewBlockScopedDatasResponse(&pbsubstreamsrpc.BlockScopedDatas{
+		Items:  []*pbsubstreamsrpc.BlockScopedData{matching, other},
+		Cursor: "last",
+	})))
+	require.NoError(t, respFunc(substreams.NewBlockScopedDatasResponse(&pbsubstreamsrpc.BlockScopedDatas{
+		Items:  []*pbsubstreamsrpc.BlockScopedData{other, other},
+		Cursor: "cursor",
+	})))
+
+	require.Len(t, received, 2)
+	assert.True(t, proto.Equal(&pbsubstreamsrpc.BlockScopedDatas{
+		Items:  []*pbsubstreamsrpc.BlockScopedData{matching},
+		Cursor: "last",
+	}, received[0].GetBlockScopedDatas()), "got %s", received[0])
+	assert.True(t, proto.Equal(&pbsubstreamsrpc.BlockScopedCursor{
+		Clock:            &pbsubstreams.Clock{Id: "00a", Number: 10},
+		Cursor:           "cursor",
+		FinalBlockHeight: 8,
+	}, received[1].GetBlockScopedCursor()), "got %s", received[1])
+}
+
+func TestNewOutputFilter_Errors(t *testing.T) {
+	_, err := newOutputFilter(`.name`, "bytes", nil)
+	assert.Error(t, err)
+
+	_, err = newOutputFilter(`.name`, "proto:unknown.Message", nil)
+	assert.Error(t, err)
+
+	_, err = newOutputFilter(`.name ==`, "proto:sf.substreams.v1.Module", nil)
+	assert.Error(t, err)
+
+	_, err = newOutputFilter(`.name`, "proto:sf.substreams.v1.Module", []*descriptorpb.FileDescriptorProto{
+		{Name: proto.String("test.proto"), Dependency: []string{"unknown.proto"}},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,356 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/streamingfast/dauth/authenticator"
+	"github.com/streamingfast/derr"
+	"github.com/streamingfast/dstore"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/streamingfast/substreams"
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
+	"github.com/streamingfast/substreams/pipeline/outputmodules"
+	"github.com/streamingfast/substreams/reqctx"
+)
+
+const (
+	defaultSinkMaxUnacknowledged = 100
+
+	// sinkCursorSaveInterval throttles the writes of acknowledged cursors to the state store, the
+	// last acknowledged cursor is always saved when the session ends.
+	sinkCursorSaveInterval = 5 * time.Second
+)
+
+var sinkSessionKeyRegex = regexp.MustCompile(`^[a-zA-Z0-9_.\-]{1,128}$`)
+
+func (s *Tier1Service) SinkBlocks(streamSrv pbsubstreamsrpc.Stream_SinkBlocksServer) error {
+	ctx := streamSrv.Context()
+	logger := reqctx.Logger(ctx).Named("tier1")
+
+	first, err := streamSrv.Recv()
+	if err != nil {
+		return toGRPCError(fmt.Errorf("receiving init message: %w", err))
+	}
+
+	init := first.GetInit()
+	if init == nil {
+		return status.Error(codes.InvalidArgument, "first message must be 'init'")
+	}
+	if init.Request == nil {
+		return status.Error(codes.InvalidArgument, "missing request in 'init'")
+	}
+	if err := outputmodules.ValidateTier1Request(init.Request, s.blockType); err != nil {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("validate request: %s", err))
+	}
+
+	session, err := newSinkSession(ctx, s.runtimeConfig.BaseObjectStore, authenticator.GetCredentials(ctx).GetUserID(), init)
+	if err != nil {
+		return toGRPCError(err)
+	}
+
+	request := init.Request
+	if request.StartCursor == "" && session.ackedCursor != "" {
+		logger.Info("resuming sink session from its last acknowledged cursor", zap.String("session_key", init.SessionKey), zap.String("cursor", session.ackedCursor))
+		request.StartCursor = session.ackedCursor
+	}
+
+	go session.receiveAcknowledgements(ctx, streamSrv)
+
+	grpcError := s.handleBlocks(request, streamSrv, session.wrap)
+
+	// The stream's context is likely canceled at this point, the last acknowledged cursor must still be saved
+	saveCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := session.save(saveCtx); err != nil {
+		logger.Warn("unable to save sink session cursor", zap.String("session_key", init.SessionKey), zap.Error(err))
+	}
+
+	return grpcError
+}
+
+// sinkSession tracks the cursors sent to a sink and not yet acknowledged, and persists
+// the last acknowledged one in the state store.
+type sinkSession struct {
+	store             dstore.Store
+	filename          string
+	modulesHash       string
+	maxUnacknowledged int
+
+	mu             sync.Mutex
+	unacknowledged []string
+	ackedCursor    string
+	lastSave       time.Time
+
+	saveMu      sync.Mutex
+	savedCursor string
+
+	acknowledged chan struct{}
+	done         chan struct{}
+	err          error
+}
+
+// sinkSessionState is what is persisted in the state store for a session.
+type sinkSessionState struct {
+	Cursor      string `json:"cursor"`
+	ModulesHash string `json:"modules_hash"`
+}
+
+func newSinkSession(ctx context.Context, stateStore dstore.Store, userID string, init *pbsubstreamsrpc.SinkInit) (*sinkSession, error) {
+	if !sinkSessionKeyRegex.MatchString(init.SessionKey) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid session key %q, it must be made of 1 to 128 letters, digits, '-', '_' or '.'", init.SessionKey)
+	}
+
+	modulesHash, err := sinkModulesHash(init.Request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	store, err := stateStore.SubStore("sinks")
+	if err != nil {
+		return nil, fmt.Errorf("sinks store: %w", err)
+	}
+	// the session's file is rewritten on each save
+	store.SetOverwrite(true)
+
+	session := &sinkSession{
+		store:             store,
+		filename:          fmt.Sprintf("%s/%s", sinkUserDirectory(userID), init.SessionKey),
+		modulesHash:       modulesHash,
+		maxUnacknowledged: int(init.MaxUnacknowledged),
+		lastSave:          time.Now(),
+		acknowledged:      make(chan struct{}, 1),
+		done:              make(chan struct{}),
+	}
+	if session.maxUnacknowledged == 0 {
+		session.maxUnacknowledged = defaultSinkMaxUnacknowledged
+	}
+
+	state, err := session.load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading sink session %q: %w", init.SessionKey, err)
+	}
+	if state != nil {
+		if state.ModulesHash != modulesHash && init.Request.StartCursor == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "sink session %q was used with different modules, use another session key or provide a start cursor", init.SessionKey)
+		}
+		session.ackedCursor = state.Cursor
+		session.savedCursor = state.Cursor
+	}
+
+	return session, nil
+}
+
+// sinkModulesHash identifies the modules producing the outputs of the request.
+func sinkModulesHash(request *pbsubstreamsrpc.Request) (string, error) {
+	outputGraph, err := outputmodules.NewOutputModuleGraph(request.OutputModule, request.ExtraOutputModules, request.ProductionMode, request.Modules)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	for _, name := range append([]string{request.OutputModule}, request.ExtraOutputModules...) {
+		h.Write([]byte(outputGraph.ModuleHashes().Get(name)))
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func sinkUserDirectory(userID string) string {
+	if userID == "" {
+		return "anonymous"
+	}
+	if sinkSessionKeyRegex.MatchString(userID) {
+		return userID
+	}
+	sum := sha256.Sum256([]byte(userID))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *sinkSession) load(ctx context.Context) (out *sinkSessionState, err error) {
+	err = derr.RetryContext(ctx, 5, func(ctx context.Context) error {
+		r, err := s.store.OpenObject(ctx, s.filename)
+		if err == dstore.ErrNotFound {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("opening file: %w", err)
+		}
+		defer r.Close()
+
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return fmt.Errorf("reading data: %w", err)
+		}
+
+		out = &sinkSessionState{}
+		return json.Unmarshal(data, out)
+	})
+	return out, err
+}
+
+// save writes the last acknowledged cursor if it changed since the last save.
+func (s *sinkSession) save(ctx context.Context) error {
+	s.saveMu.Lock()
+	defer s.saveMu.Unlock()
+
+	s.mu.Lock()
+	cursor := s.ackedCursor
+	s.lastSave = time.Now()
+	s.mu.Unlock()
+
+	if cursor == s.savedCursor {
+		return nil
+	}
+
+	content, err := json.Marshal(&sinkSessionState{Cursor: cursor, ModulesHash: s.modulesHash})
+	if err != nil {
+		return err
+	}
+
+	err = derr.RetryContext(ctx, 5, func(ctx context.Context) error {
+		return s.store.WriteObject(ctx, s.filename, bytes.NewReader(content))
+	})
+	if err != nil {
+		return fmt.Errorf("writing %q: %w", s.filename, err)
+	}
+
+	s.savedCursor = cursor
+	return nil
+}
+
+// wrap records the cursor of the block related responses before they are sent, and waits
+// for acknowledgements when too many of them are pending.
+func (s *sinkSession) wrap(respFunc substreams.ResponseFunc) substreams.ResponseFunc {
+	return func(anyResp substreams.ResponseFromAnyTier) error {
+		resp, ok := anyResp.(*pbsubstreamsrpc.Response)
+		if !ok {
+			return respFunc(anyResp)
+		}
+
+		cursor, ok := sinkResponseCursor(resp)
+		if !ok {
+			return respFunc(resp)
+		}
+
+		if err := s.waitForRoom(); err != nil {
+			return err
+		}
+
+		s.mu.Lock()
+		s.unacknowledged = append(s.unacknowledged, cursor)
+		s.mu.Unlock()
+
+		return respFunc(resp)
+	}
+}
+
+func (s *sinkSession) waitForRoom() error {
+	for {
+		s.mu.Lock()
+		pending := len(s.unacknowledged)
+		s.mu.Unlock()
+
+		select {
+		case <-s.done:
+			if s.err != nil {
+				return s.err
+			}
+			if pending >= s.maxUnacknowledged {
+				return status.Error(codes.FailedPrecondition, "client stopped sending acknowledgements")
+			}
+			return nil
+		default:
+		}
+
+		if pending < s.maxUnacknowledged {
+			return nil
+		}
+
+		select {
+		case <-s.acknowledged:
+		case <-s.done:
+		}
+	}
+}
+
+func (s *sinkSession) receiveAcknowledgements(ctx context.Context, streamSrv pbsubstreamsrpc.Stream_SinkBlocksServer) {
+	defer close(s.done)
+
+	for {
+		req, err := streamSrv.Recv()
+		if err != nil {
+			if !errors.Is(err, io.EOF) && ctx.Err() == nil {
+				s.err = fmt.Errorf("receiving acknowledgement: %w", err)
+			}
+			return
+		}
+
+		ack := req.GetAcknowledge()
+		if ack == nil {
+			s.err = status.Error(codes.InvalidArgument, "only 'acknowledge' messages are accepted after 'init'")
+			return
+		}
+
+		if err := s.acknowledge(ctx, ack.Cursor); err != nil {
+			s.err = err
+			return
+		}
+	}
+}
+
+func (s *sinkSession) acknowledge(ctx context.Context, cursor string) error {
+	s.mu.Lock()
+	index := -1
+	for i, unacknowledged := range s.unacknowledged {
+		if unacknowledged == cursor {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		s.mu.Unlock()
+		return status.Errorf(codes.InvalidArgument, "acknowledged cursor %q was not sent or was already acknowledged", cursor)
+	}
+
+	s.unacknowledged = s.unacknowledged[index+1:]
+	s.ackedCursor = cursor
+	shouldSave := time.Since(s.lastSave) >= sinkCursorSaveInterval
+	s.mu.Unlock()
+
+	select {
+	case s.acknowledged <- struct{}{}:
+	default:
+	}
+
+	if shouldSave {
+		return s.save(ctx)
+	}
+	return nil
+}
+
+// sinkResponseCursor returns the cursor a sink acknowledges for the response, if it has to.
+func sinkResponseCursor(resp *pbsubstreamsrpc.Response) (string, bool) {
+	switch m := resp.Message.(type) {
+	case *pbsubstreamsrpc.Response_BlockScopedData:
+		return m.BlockScopedData.Cursor, true
+	case *pbsubstreamsrpc.Response_BlockScopedCursor:
+		return m.BlockScopedCursor.Cursor, true
+	case *pbsubstreamsrpc.Response_BlockScopedDatas:
+		return m.BlockScopedDatas.Cursor, true
+	case *pbsubstreamsrpc.Response_BlockUndoSignal:
+		return m.BlockUndoSignal.LastValidCursor, true
+	}
+	return "", false
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/dstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/streamingfast/substreams"
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+func testSinkInit(sessionKey string, code string) *pbsubstreamsrpc.SinkInit {
+	return &pbsubstreamsrpc.SinkInit{
+		SessionKey:        sessionKey,
+		MaxUnacknowledged: 2,
+		Request: &pbsubstreamsrpc.Request{
+			OutputModule: "map_test",
+			Modules: &pbsubstreams.Modules{
+				Modules: []*pbsubstreams.Module{{
+					Name:   "map_test",
+					Kind:   &pbsubstreams.Module_KindMap_{KindMap: &pbsubstreams.Module_KindMap{OutputType: "proto:test.Output"}},
+					Inputs: []*pbsubstreams.Module_Input{{Input: &pbsubstreams.Module_Input_Source_{Source: &pbsubstreams.Module_Input_Source{Type: "sf.substreams.v1.Clock"}}}},
+					Output: &pbsubstreams.Module_Output{Type: "proto:test.Output"},
+				}},
+				Binaries: []*pbsubstreams.Binary{{Type: "wasm/rust-v1", Content: []byte(code)}},
+			},
+		},
+	}
+}
+
+func testSinkData(cursor string) *pbsubstreamsrpc.Response {
+	return substreams.NewBlockScopedDataResponse(&pbsubstreamsrpc.BlockScopedData{Cursor: cursor})
+}
+
+func newTestStateStore(t *testing.T) dstore.Store {
+	t.Helper()
+
+	store, err := dstore.NewStore(t.TempDir(), "", "", false)
+	require.NoError(t, err)
+	return store
+}
+
+func TestSinkSession_Backpressure(t *testing.T) {
+	session, err := newSinkSession(context.Background(), newTestStateStore(t), "user", testSinkInit("session", "code"))
+	require.NoError(t, err)
+
+	sent := make(chan string, 10)
+	respFunc := session.wrap(func(resp substreams.ResponseFromAnyTier) error {
+		if cursor, ok := sinkResponseCursor(resp.(*pbsubstreamsrpc.Response)); ok {
+			sent <- cursor
+		}
+		return nil
+	})
+
+	require.NoError(t, respFunc(testSinkData("c1")))
+	require.NoError(t, respFunc(&pbsubstreamsrpc.Response{Message: &pbsubstreamsrpc.Response_Progress{Progress: &pbsubstreamsrpc.ModulesProgress{}}}))
+	require.NoError(t, respFunc(testSinkData("c2")))
+
+	thirdSent := make(chan error)
+	go func() {
+		thirdSent <- respFunc(testSinkData("c3"))
+	}()
+
+	select {
+	case <-thirdSent:
+		t.Fatal("third message should wait for an acknowledgement")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	require.NoError(t, session.acknowledge(context.Background(), "c1"))
+	require.NoError(t, <-thirdSent)
+	assert.Equal(t, []string{"c1", "c2", "c3"}, []string{<-sent, <-sent, <-sent})
+
+	// Acknowledging c3 implicitly acknowledges c2
+	require.NoError(t, session.acknowledge(context.Background(), "c3"))
+	assert.Len(t, session.unacknowledged, 0)
+	assert.Equal(t, "c3", session.ackedCursor)
+
+	assert.Error(t, session.acknowledge(context.Background(), "c2"))
+}
+
+func TestSinkSession_Resume(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStateStore(t)
+
+	session, err := newSinkSession(ctx, store, "user", testSinkInit("session", "code"))
+	require.NoError(t, err)
+	assert.Equal(t, "", session.ackedCursor)
+
+	respFunc := session.wrap(func(substreams.ResponseFromAnyTier) error { return nil })
+	require.NoError(t, respFunc(testSinkData("c1")))
+	require.NoError(t, session.acknowledge(ctx, "c1"))
+	require.NoError(t, session.save(ctx))
+
+	resumed, err := newSinkSession(ctx, store, "user", testSinkInit("session", "code"))
+	require.NoError(t, err)
+	assert.Equal(t, "c1", resumed.ackedCursor)
+
+	other, err := newSinkSession(ctx, store, "other_user", testSinkInit("session", "code"))
+	require.NoError(t, err)
+	assert.Equal(t, "", other.ackedCursor)
+
+	_, err = newSinkSession(ctx, store, "user", testSinkInit("session", "changed code"))
+	assert.Error(t, err, "modules changed")
+
+	init := testSinkInit("session", "changed code")
+	init.Request.StartCursor = "explicit"
+	_, err = newSinkSession(ctx, store, "user", init)
+	assert.NoError(t, err, "modules changed with an explicit cursor")
+}
+
+func TestNewSinkSession_InvalidKey(t *testing.T) {
+	for _, key := range []string{"", "with/slash", "with space"} {
+		_, err := newSinkSession(context.Background(), newTestStateStore(t), "user", testSinkInit(key, "code"))
+		assert.Error(t, err, "key %q", key)
+	}
+}
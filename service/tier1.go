@@ -77,9 +77,10 @@ func NewTier1(
 		0,
 		stateStore,
 		func(logger *zap.Logger) work.Worker {
-			return work.NewRemoteWorker(clientFactory, logger)
+			return work.NewRemoteWorker(clientFactory, substreamsClientConfig.Endpoint(), logger)
 		},
 	)
+	runtimeConfig.JobRegistry = work.NewJobRegistry() // overridden by Options
 	s = &Tier1Service{
 		runtimeConfig: runtimeConfig,
 		blockType:     blockType,
@@ -128,6 +129,18 @@ func (s *Tier1Service) Register(
 }
 
 func (s *Tier1Service) Blocks(request *pbsubstreamsrpc.Request, streamSrv pbsubstreamsrpc.Stream_BlocksServer) (grpcError error) {
+	return s.handleBlocks(request, streamSrv, nil)
+}
+
+// responseStream is the sending side of both `Blocks` and `SinkBlocks` streams.
+type responseStream interface {
+	Send(*pbsubstreamsrpc.Response) error
+	grpc.ServerStream
+}
+
+// handleBlocks serves the `request` on `streamSrv`, `wrapRespFunc` (optional) decorates
+// the function sending the block related responses.
+func (s *Tier1Service) handleBlocks(request *pbsubstreamsrpc.Request, streamSrv responseStream, wrapRespFunc func(substreams.ResponseFunc) substreams.ResponseFunc) (grpcError error) {
 	// We keep `err` here as the unaltered error from `blocks` call, this is used in the EndSpan to record the full error
 	// and not only the `grpcError` one which is a subset view of the full `err`.
 	var err error
@@ -175,6 +188,8 @@ func (s *Tier1Service) Blocks(request *pbsubstreamsrpc.Request, streamSrv pbsubs
 		zap.String("cursor", request.StartCursor),
 		zap.Strings("modules", moduleNames),
 		zap.String("output_module", request.OutputModule),
+		zap.Strings("extra_output_modules", request.ExtraOutputModules),
+		zap.String("output_filter", request.OutputFilter),
 	}
 	fields = append(fields, zap.Bool("production_mode", request.ProductionMode))
 	auth := authenticator.GetCredentials(ctx)
@@ -183,6 +198,10 @@ func (s *Tier1Service) Blocks(request *pbsubstreamsrpc.Request, streamSrv pbsubs
 	}
 	logger.Info("incoming Substreams Blocks request", fields...)
 
+	if wrapRespFunc != nil {
+		respFunc = wrapRespFunc(respFunc)
+	}
+
 	err = s.blocks(ctx, s.runtimeConfig, request, respFunc)
 	grpcError = toGRPCError(err)
 
@@ -200,11 +219,19 @@ func (s *Tier1Service) blocks(ctx context.Context, runtimeConfig config.RuntimeC
 		return stream.NewErrInvalidArg(fmt.Errorf("validate request: %w", err).Error())
 	}
 
-	outputGraph, err := outputmodules.NewOutputModuleGraph(request.OutputModule, request.ProductionMode, request.Modules)
+	outputGraph, err := outputmodules.NewOutputModuleGraph(request.OutputModule, request.ExtraOutputModules, request.ProductionMode, request.Modules)
 	if err != nil {
 		return stream.NewErrInvalidArg(err.Error())
 	}
 
+	if request.OutputFilter != "" {
+		filter, err := newOutputFilter(request.OutputFilter, outputGraph.OutputModule().GetOutput().GetType(), request.OutputFilterProtoFiles)
+		if err != nil {
+			return stream.NewErrInvalidArg(fmt.Errorf("output filter: %w", err).Error())
+		}
+		respFunc = filter.wrap(respFunc)
+	}
+
 	ctx, requestStats := setupRequestStats(ctx, logger, runtimeConfig.WithRequestStats, false)
 
 	//bytesMeter := tracking.NewBytesMeter(ctx)
@@ -325,7 +352,7 @@ func (s *Tier1Service) buildPipelineOptions(ctx context.Context) (opts []pipelin
 	return
 }
 
-func responseHandler(logger *zap.Logger, streamSrv pbsubstreamsrpc.Stream_BlocksServer) func(substreams.ResponseFromAnyTier) error {
+func responseHandler(logger *zap.Logger, streamSrv responseStream) func(substreams.ResponseFromAnyTier) error {
 	return func(anyResp substreams.ResponseFromAnyTier) error {
 		resp := anyResp.(*pbsubstreamsrpc.Response)
 		if err := streamSrv.Send(resp); err != nil {
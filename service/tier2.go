@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -24,6 +25,7 @@ import (
 	pbssinternal "github.com/streamingfast/substreams/pb/sf/substreams/intern/v2"
 	"github.com/streamingfast/substreams/pipeline"
 	"github.com/streamingfast/substreams/pipeline/cache"
+	"github.com/streamingfast/substreams/pipeline/exec"
 	"github.com/streamingfast/substreams/pipeline/outputmodules"
 	"github.com/streamingfast/substreams/reqctx"
 	"github.com/streamingfast/substreams/service/config"
@@ -145,6 +147,24 @@ func (s *Tier2Service) ProcessRange(request *pbssinternal.ProcessRangeRequest, s
 
 	respFunc := tier2ResponseHandler(logger, streamSrv)
 	err = s.processRange(ctx, s.runtimeConfig, request, respFunc)
+
+	// A module failing on its input is reported as such, for tier1 not to retry the job
+	var execErr *exec.ErrorExecutor
+	if errors.As(err, &execErr) {
+		logs, logsTruncated := execErr.Logs()
+		failed := &pbssinternal.ProcessRangeResponse{
+			ModuleName: execErr.ModuleName(),
+			Type: &pbssinternal.ProcessRangeResponse_Failed{Failed: &pbssinternal.Failed{
+				Reason:        err.Error(),
+				Logs:          logs,
+				LogsTruncated: logsTruncated,
+			}},
+		}
+		if sendErr := respFunc(failed); sendErr != nil {
+			logger.Info("unable to send module failure", zap.Error(sendErr))
+		}
+	}
+
 	grpcError = toGRPCError(err)
 
 	if grpcError != nil && status.Code(grpcError) == codes.Internal {
@@ -161,7 +181,7 @@ func (s *Tier2Service) processRange(ctx context.Context, runtimeConfig config.Ru
 		return stream.NewErrInvalidArg(fmt.Errorf("validate request: %w", err).Error())
 	}
 
-	outputGraph, err := outputmodules.NewOutputModuleGraph(request.OutputModule, true, request.Modules)
+	outputGraph, err := outputmodules.NewOutputModuleGraph(request.OutputModule, nil, true, request.Modules)
 	if err != nil {
 		return stream.NewErrInvalidArg(err.Error())
 	}
@@ -20,8 +20,10 @@ func BuildModuleStorageStateMap(ctx context.Context, storeConfigMap store.Config
 	}
 	// dev mode does not manage mappers states (output caches)
 	if details := reqctx.Details(ctx); details.ProductionMode {
-		if err := buildMappersStorageState(ctx, mapConfigs, cacheSaveInterval, requestStartBlock, linearHandoffBlock, details.OutputModule, out); err != nil {
-			return nil, err
+		for _, outputModule := range append([]string{details.OutputModule}, details.ExtraOutputModules...) {
+			if err := buildMappersStorageState(ctx, mapConfigs, cacheSaveInterval, requestStartBlock, linearHandoffBlock, outputModule, out); err != nil {
+				return nil, err
+			}
 		}
 	}
 	return out, nil
@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/streamingfast/bstream"
 	"github.com/streamingfast/shutter"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/streamingfast/substreams"
@@ -27,18 +29,44 @@ type LinearReader struct {
 	module             *pbsubstreams.Module
 	firstFile          *File
 	cacheItems         chan *pboutput.Item
+	extraOutputs       []*extraOutputReader
+
+	batchMaxBlocks uint64
+	batchMaxBytes  uint64
+	batch          *pbsubstreamsrpc.BlockScopedDatas
+	batchBytes     uint64
+
+	writtenLock sync.Mutex
+	written     chan struct{} // closed, then replaced, when outputs files were written
 }
 
+// extraOutputReader follows the cached outputs of one of the request's extra output
+// modules, which are downloaded in parallel of the output module ones.
+type extraOutputReader struct {
+	module     *pbsubstreams.Module
+	firstFile  *File
+	cacheItems chan *pboutput.Item
+	next       *pboutput.Item
+}
+
+// NewLinearReader streams the cached outputs of `module` starting with `firstFile`. The outputs
+// of `extraModules` are read from `extraFirstFiles` (matched by index) and sent in the same
+// `BlockScopedData`. When `batchMaxBlocks` is non-zero, contiguous blocks are sent in
+// `BlockScopedDatas` bounded by `batchMaxBlocks` and `batchMaxBytes`.
 func NewLinearReader(
 	startBlock uint64,
 	exclusiveEndBlock uint64,
 	module *pbsubstreams.Module,
 	firstFile *File,
+	extraModules []*pbsubstreams.Module,
+	extraFirstFiles []*File,
 	responseFunc substreams.ResponseFunc,
 	execOutputSaveInterval uint64,
 	pendingUndoMessage *pbsubstreamsrpc.Response,
+	batchMaxBlocks uint64,
+	batchMaxBytes uint64,
 ) *LinearReader {
-	return &LinearReader{
+	r := &LinearReader{
 		Shutter:            shutter.New(),
 		requestStartBlock:  startBlock,
 		exclusiveEndBlock:  exclusiveEndBlock,
@@ -47,7 +75,36 @@ func NewLinearReader(
 		responseFunc:       responseFunc,
 		pendingUndoMessage: pendingUndoMessage,
 		cacheItems:         make(chan *pboutput.Item, execOutputSaveInterval*2),
+		batchMaxBlocks:     batchMaxBlocks,
+		batchMaxBytes:      batchMaxBytes,
+		written:            make(chan struct{}),
+	}
+	for i, extraModule := range extraModules {
+		r.extraOutputs = append(r.extraOutputs, &extraOutputReader{
+			module:     extraModule,
+			firstFile:  extraFirstFiles[i],
+			cacheItems: make(chan *pboutput.Item, execOutputSaveInterval*2),
+		})
 	}
+	return r
+}
+
+// NotifyOutputsWritten tells the reader that outputs files were just written, by a tier2
+// job of one of the modules it streams, for it to look again right away for the files it
+// is waiting for instead of polling them.
+func (r *LinearReader) NotifyOutputsWritten() {
+	r.writtenLock.Lock()
+	defer r.writtenLock.Unlock()
+
+	close(r.written)
+	r.written = make(chan struct{})
+}
+
+func (r *LinearReader) outputsWritten() <-chan struct{} {
+	r.writtenLock.Lock()
+	defer r.writtenLock.Unlock()
+
+	return r.written
 }
 
 func (r *LinearReader) Launch(ctx context.Context) {
@@ -64,51 +121,181 @@ func (r *LinearReader) run(ctx context.Context) error {
 	logger := reqctx.Logger(ctx)
 
 	go func() {
-		if err := r.download(ctx, r.firstFile); err != nil {
+		if err := r.download(ctx, r.firstFile, r.cacheItems); err != nil {
 			r.Shutdown(err)
 		}
 		close(r.cacheItems)
 	}()
 
+	for _, extra := range r.extraOutputs {
+		extra := extra
+		go func() {
+			if err := r.download(ctx, extra.firstFile, extra.cacheItems); err != nil {
+				r.Shutdown(err)
+			}
+			close(extra.cacheItems)
+		}()
+	}
+
 	for {
-		select {
-		case <-ctx.Done():
-			return nil
-		case <-r.Terminating():
+		item, ok, err := r.receiveItem(ctx)
+		if err != nil {
+			return err
+		}
+		if !ok {
 			return nil
-		case item := <-r.cacheItems:
-			if item == nil {
+		}
+		if item == nil {
+			return r.flushBatch()
+		}
+		if item.BlockNum < r.requestStartBlock {
+			continue
+		}
+
+		blockScopedData, err := toBlockScopedData(r.module, item)
+		if err != nil {
+			return fmt.Errorf("block scoped data: %w", err)
+		}
+
+		for _, extra := range r.extraOutputs {
+			extraItem, ok := r.nextExtraItem(ctx, extra, item.BlockNum)
+			if !ok {
 				return nil
 			}
-			if item.BlockNum < r.requestStartBlock {
+			if extraItem == nil {
 				continue
 			}
 
-			if r.pendingUndoMessage != nil {
-				if err := r.responseFunc(r.pendingUndoMessage); err != nil {
-					return fmt.Errorf("sending undo message: %w", err)
-				}
-				r.pendingUndoMessage = nil
+			extraOutput, err := toModuleOutput(extra.module, extraItem)
+			if err != nil {
+				return fmt.Errorf("extra module output: %w", err)
 			}
+			blockScopedData.ExtraOutputs = append(blockScopedData.ExtraOutputs, extraOutput)
+		}
 
-			blockScopedData, err := toBlockScopedData(r.module, item)
-			err = r.responseFunc(substreams.NewBlockScopedDataResponse(blockScopedData))
-			if err != nil {
-				return fmt.Errorf("calling response func: %w", err)
+		if r.pendingUndoMessage != nil {
+			if err := r.responseFunc(r.pendingUndoMessage); err != nil {
+				return fmt.Errorf("sending undo message: %w", err)
 			}
+			r.pendingUndoMessage = nil
+		}
 
-			if blockScopedData.Clock.Number >= r.exclusiveEndBlock {
-				logger.Info("stop pulling block scoped data, end block reach",
-					zap.Uint64("exclusive_end_block_num", r.exclusiveEndBlock),
-					zap.Uint64("cache_item_block_num", blockScopedData.Clock.Number),
-				)
-				return nil
+		if err := r.send(blockScopedData); err != nil {
+			return err
+		}
+
+		if blockScopedData.Clock.Number >= r.exclusiveEndBlock {
+			logger.Info("stop pulling block scoped data, end block reach",
+				zap.Uint64("exclusive_end_block_num", r.exclusiveEndBlock),
+				zap.Uint64("cache_item_block_num", blockScopedData.Clock.Number),
+			)
+			return r.flushBatch()
+		}
+	}
+}
+
+// receiveItem returns the next cached item of the output module, or nil once they were all
+// read. The returned bool is false when the reader is terminating. A pending batch is only
+// held while items are readily available, it is flushed as soon as the download falls behind.
+func (r *LinearReader) receiveItem(ctx context.Context) (*pboutput.Item, bool, error) {
+	if r.batch != nil {
+		select {
+		case item := <-r.cacheItems:
+			return item, true, nil
+		default:
+		}
+
+		if err := r.flushBatch(); err != nil {
+			return nil, false, err
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, false, nil
+	case <-r.Terminating():
+		return nil, false, nil
+	case item := <-r.cacheItems:
+		return item, true, nil
+	}
+}
+
+// send sends `data` right away when batching is disabled, otherwise it adds it to the
+// pending batch, which is flushed when one of its limits is reached.
+func (r *LinearReader) send(data *pbsubstreamsrpc.BlockScopedData) error {
+	if r.batchMaxBlocks == 0 {
+		if err := r.responseFunc(substreams.NewBlockScopedDataResponse(data)); err != nil {
+			return fmt.Errorf("calling response func: %w", err)
+		}
+		return nil
+	}
+
+	size := uint64(proto.Size(data))
+	if r.batch != nil && r.batchBytes+size > r.batchMaxBytes {
+		if err := r.flushBatch(); err != nil {
+			return err
+		}
+	}
+
+	if r.batch == nil {
+		r.batch = &pbsubstreamsrpc.BlockScopedDatas{}
+		r.batchBytes = 0
+	}
+	r.batch.Items = append(r.batch.Items, data)
+	r.batch.Cursor = data.Cursor
+	r.batchBytes += size
+
+	if uint64(len(r.batch.Items)) >= r.batchMaxBlocks || r.batchBytes >= r.batchMaxBytes {
+		return r.flushBatch()
+	}
+	return nil
+}
+
+func (r *LinearReader) flushBatch() error {
+	if r.batch == nil {
+		return nil
+	}
+
+	batch := r.batch
+	r.batch = nil
+	if err := r.responseFunc(substreams.NewBlockScopedDatasResponse(batch)); err != nil {
+		return fmt.Errorf("calling response func: %w", err)
+	}
+	return nil
+}
+
+// nextExtraItem returns the item of `extra` at `blockNum`, or nil if it has none. The
+// returned bool is false when the reader is terminating.
+func (r *LinearReader) nextExtraItem(ctx context.Context, extra *extraOutputReader, blockNum uint64) (*pboutput.Item, bool) {
+	for {
+		if extra.next == nil {
+			select {
+			case <-ctx.Done():
+				return nil, false
+			case <-r.Terminating():
+				return nil, false
+			case item := <-extra.cacheItems:
+				if item == nil {
+					return nil, true
+				}
+				extra.next = item
 			}
 		}
+
+		switch {
+		case extra.next.BlockNum < blockNum:
+			extra.next = nil
+		case extra.next.BlockNum == blockNum:
+			item := extra.next
+			extra.next = nil
+			return item, true
+		default:
+			return nil, true
+		}
 	}
 }
 
-func (r *LinearReader) download(ctx context.Context, file *File) error {
+func (r *LinearReader) download(ctx context.Context, file *File, cacheItems chan<- *pboutput.Item) error {
 	for {
 		sortedCachedItems, err := r.downloadFile(ctx, file)
 		if err != nil {
@@ -117,7 +304,7 @@ func (r *LinearReader) download(ctx context.Context, file *File) error {
 
 		for _, cachedItem := range sortedCachedItems {
 			select {
-			case r.cacheItems <- cachedItem:
+			case cacheItems <- cachedItem:
 			case <-r.Terminating():
 				return nil
 			case <-ctx.Done():
@@ -136,6 +323,7 @@ func (r *LinearReader) downloadFile(ctx context.Context, file *File) (out []*pbo
 	logger := reqctx.Logger(ctx)
 	for {
 		logger.Debug("loading next cache", zap.Object("file", file))
+		written := r.outputsWritten()
 		loaded, err := file.Load(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("loading %s cache %q: %w", file.ModuleName, file.Filename(), err)
@@ -148,10 +336,12 @@ func (r *LinearReader) downloadFile(ctx context.Context, file *File) (out []*pbo
 		// TODO(abourget): if file.IsPartial(), we should delete it, it would mean it'd be left
 		// over, and never reused, unless an EXACT request would come and use it.
 
-		logger.Debug("cache not found, waiting 2s", zap.Object("file", file))
+		logger.Debug("cache not found, waiting 2s or for outputs to be written", zap.Object("file", file))
 		select {
 		case <-time.After(2 * time.Second):
 			continue
+		case <-written:
+			continue
 		case <-r.Terminating():
 			return nil, nil
 		case <-ctx.Done():
@@ -0,0 +1,147 @@
+package execout
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/dstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/streamingfast/substreams"
+	"github.com/streamingfast/substreams/block"
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	pboutput "github.com/streamingfast/substreams/storage/execout/pb"
+)
+
+func TestLinearReader_nextExtraItem(t *testing.T) {
+	reader := NewLinearReader(0, 100, nil, nil, nil, nil, nil, 10, nil, 0, 0)
+	extra := &extraOutputReader{cacheItems: make(chan *pboutput.Item, 10)}
+	for _, blockNum := range []uint64{10, 12, 13} {
+		extra.cacheItems <- &pboutput.Item{BlockNum: blockNum}
+	}
+	close(extra.cacheItems)
+
+	tests := []struct {
+		blockNum uint64
+		expect   uint64 // 0 means no item
+	}{
+		{9, 0},
+		{10, 10},
+		{11, 0},
+		{12, 12},
+		{13, 13},
+		{14, 0},
+	}
+
+	for _, test := range tests {
+		item, ok := reader.nextExtraItem(context.Background(), extra, test.blockNum)
+		require.True(t, ok)
+		if test.expect == 0 {
+			assert.Nil(t, item, "block %d", test.blockNum)
+			continue
+		}
+		require.NotNil(t, item, "block %d", test.blockNum)
+		assert.Equal(t, test.expect, item.BlockNum)
+	}
+
+	reader.Shutdown(nil)
+	_, ok := reader.nextExtraItem(context.Background(), &extraOutputReader{cacheItems: make(chan *pboutput.Item)}, 15)
+	assert.False(t, ok)
+}
+
+func TestLinearReader_sendBatches(t *testing.T) {
+	newData := func(blockNum uint64) *pbsubstreamsrpc.BlockScopedData {
+		return &pbsubstreamsrpc.BlockScopedData{
+			Clock:  &pbsubstreams.Clock{Number: blockNum},
+			Cursor: fmt.Sprintf("cursor-%d", blockNum),
+		}
+	}
+	dataSize := uint64(proto.Size(newData(10)))
+
+	tests := []struct {
+		name           string
+		batchMaxBlocks uint64
+		batchMaxBytes  uint64
+		expectBatches  [][]uint64 // nil batch means an individual BlockScopedData
+	}{
+		{"no batching", 0, 0, [][]uint64{nil, nil, nil}},
+		{"bounded by count", 2, 1024, [][]uint64{{10, 11}, {12}}},
+		{"bounded by size", 10, dataSize*2 + 1, [][]uint64{{10, 11}, {12}}},
+		{"larger than size", 10, 1, [][]uint64{{10}, {11}, {12}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var received []*pbsubstreamsrpc.Response
+			respFunc := func(resp substreams.ResponseFromAnyTier) error {
+				received = append(received, resp.(*pbsubstreamsrpc.Response))
+				return nil
+			}
+			reader := NewLinearReader(0, 100, nil, nil, nil, nil, respFunc, 10, nil, test.batchMaxBlocks, test.batchMaxBytes)
+
+			for _, blockNum := range []uint64{10, 11, 12} {
+				require.NoError(t, reader.send(newData(blockNum)))
+			}
+			require.NoError(t, reader.flushBatch())
+
+			require.Len(t, received, len(test.expectBatches))
+			for i, expect := range test.expectBatches {
+				if expect == nil {
+					assert.NotNil(t, received[i].GetBlockScopedData())
+					continue
+				}
+
+				batch := received[i].GetBlockScopedDatas()
+				require.NotNil(t, batch)
+				var blockNums []uint64
+				for _, item := range batch.Items {
+					blockNums = append(blockNums, item.Clock.Number)
+				}
+				assert.Equal(t, expect, blockNums)
+				assert.Equal(t, fmt.Sprintf("cursor-%d", expect[len(expect)-1]), batch.Cursor)
+			}
+		})
+	}
+}
+
+func TestLinearReader_NotifyOutputsWritten(t *testing.T) {
+	ctx := context.Background()
+	objStore, err := dstore.NewStore(t.TempDir(), "", "", false)
+	require.NoError(t, err)
+	config, err := NewConfig("A", 0, pbsubstreams.ModuleKindMap, "abc", objStore, zap.NewNop())
+	require.NoError(t, err)
+
+	reader := NewLinearReader(0, 10, nil, nil, nil, nil, nil, 10, nil, 0, 0)
+	defer reader.Shutdown(nil)
+
+	downloaded := make(chan []*pboutput.Item)
+	go func() {
+		items, err := reader.downloadFile(ctx, config.NewFile(block.NewBoundedRange(0, 10, 0, 10)))
+		require.NoError(t, err)
+		downloaded <- items
+	}()
+
+	// Let the reader find out the file is missing
+	time.Sleep(100 * time.Millisecond)
+
+	written := config.NewFile(block.NewBoundedRange(0, 10, 0, 10))
+	written.SetItem(&pbsubstreams.Clock{Number: 5, Id: "5"}, []byte("payload"))
+	save, err := written.Save(ctx)
+	require.NoError(t, err)
+	save()
+	reader.NotifyOutputsWritten()
+
+	select {
+	case items := <-downloaded:
+		require.Len(t, items, 1)
+		assert.Equal(t, uint64(5), items[0].BlockNum)
+	case <-time.After(time.Second):
+		t.Fatal("reader still waiting for the file written")
+	}
+}
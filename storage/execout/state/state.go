@@ -32,6 +32,10 @@ func (m ExecOutputStorageState) BatchRequests(subRequestSplitSize uint64) block.
 	return m.SegmentsMissing.MergedBuckets(subRequestSplitSize)
 }
 
+func (m ExecOutputStorageState) BatchRequestsByCost(maxSize uint64, maxCost float64, cost func(*block.Range) float64) block.Ranges {
+	return m.SegmentsMissing.MergedBucketsByCost(maxSize, maxCost, cost)
+}
+
 func NewExecOutputStorageState(config *execout.Config, saveInterval, requestStartBlock, linearHandoffBlock uint64, snapshots block.Ranges) (out *ExecOutputStorageState, err error) {
 	modInitBlock := config.ModuleInitialBlock()
 	out = &ExecOutputStorageState{
@@ -11,6 +11,7 @@ type ModuleStorageState interface {
 	InitialProgressRanges() block.Ranges
 	ReadyUpToBlock() uint64
 	BatchRequests(subrequestSplitSize uint64) block.Ranges
+	BatchRequestsByCost(maxSize uint64, maxCost float64, cost func(*block.Range) float64) block.Ranges
 }
 
 type ModuleStorageStateMap map[string]ModuleStorageState
@@ -0,0 +1,249 @@
+package jobcost
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/streamingfast/dstore"
+
+	"github.com/streamingfast/substreams/block"
+)
+
+// MaxObservations is the number of observations kept for each module, the oldest
+// ones being dropped first.
+const MaxObservations = 1000
+
+// Observation is the cost observed when executing a module over a block range
+type Observation struct {
+	Range        *block.Range  `json:"-"`
+	Duration     time.Duration `json:"duration"`
+	BytesRead    uint64        `json:"bytes_read,omitempty"`
+	BytesWritten uint64        `json:"bytes_written,omitempty"`
+}
+
+type storedObservation struct {
+	StartBlock        uint64 `json:"start_block"`
+	ExclusiveEndBlock uint64 `json:"exclusive_end_block"`
+	*Observation
+}
+
+type costFile struct {
+	// Observations in the order they were recorded
+	Observations []*storedObservation `json:"observations"`
+}
+
+// Store keeps the observations of a module in `costs/<module_hash>.json` in the
+// state store, up to `MaxObservations` of them. The observations recorded at the
+// same time by other requests for the same module may be lost, the last one writing
+// the file winning, which only makes the estimates less precise.
+type Store struct {
+	store dstore.Store
+
+	mu sync.Mutex
+}
+
+func NewStore(baseStore dstore.Store) (*Store, error) {
+	store, err := baseStore.SubStore("costs")
+	if err != nil {
+		return nil, fmt.Errorf("costs store: %w", err)
+	}
+	// the observations of a module are rewritten on each record
+	store.SetOverwrite(true)
+
+	return &Store{store: store}, nil
+}
+
+func costFilename(moduleHash string) string {
+	return moduleHash + ".json"
+}
+
+// Record adds `observation` to the ones of `moduleHash`, replacing the one observed
+// over the same block range if any.
+func (s *Store) Record(ctx context.Context, moduleHash string, observation *Observation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := s.read(ctx, moduleHash)
+	if err != nil {
+		return err
+	}
+
+	kept := file.Observations[:0]
+	for _, existing := range file.Observations {
+		if existing.StartBlock != observation.Range.StartBlock || existing.ExclusiveEndBlock != observation.Range.ExclusiveEndBlock {
+			kept = append(kept, existing)
+		}
+	}
+	kept = append(kept, &storedObservation{
+		StartBlock:        observation.Range.StartBlock,
+		ExclusiveEndBlock: observation.Range.ExclusiveEndBlock,
+		Observation:       observation,
+	})
+	if len(kept) > MaxObservations {
+		kept = kept[len(kept)-MaxObservations:]
+	}
+	file.Observations = kept
+
+	content, err := json.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("marshalling observations: %w", err)
+	}
+
+	filename := costFilename(moduleHash)
+	if err := s.store.WriteObject(ctx, filename, bytes.NewReader(content)); err != nil {
+		return fmt.Errorf("writing %q: %w", filename, err)
+	}
+	return nil
+}
+
+// Load returns the observations recorded for `moduleHash`, sorted by start block
+func (s *Store) Load(ctx context.Context, moduleHash string) (out Observations, err error) {
+	file, err := s.read(ctx, moduleHash)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, stored := range file.Observations {
+		observation := stored.Observation
+		observation.Range = block.NewRange(stored.StartBlock, stored.ExclusiveEndBlock)
+		out = append(out, observation)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Range.StartBlock < out[j].Range.StartBlock })
+	return out, nil
+}
+
+func (s *Store) read(ctx context.Context, moduleHash string) (*costFile, error) {
+	filename := costFilename(moduleHash)
+	file := &costFile{}
+
+	exists, err := s.store.FileExists(ctx, filename)
+	if err != nil {
+		return nil, fmt.Errorf("checking %q: %w", filename, err)
+	}
+	if !exists {
+		return file, nil
+	}
+
+	reader, err := s.store.OpenObject(ctx, filename)
+	if err != nil {
+		return nil, fmt.Errorf("opening %q: %w", filename, err)
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %w", filename, err)
+	}
+	if err := json.Unmarshal(content, file); err != nil {
+		return nil, fmt.Errorf("unmarshalling %q: %w", filename, err)
+	}
+
+	kept := file.Observations[:0]
+	for _, stored := range file.Observations {
+		if stored.Observation != nil && stored.ExclusiveEndBlock > stored.StartBlock {
+			kept = append(kept, stored)
+		}
+	}
+	file.Observations = kept
+	return file, nil
+}
+
+type Observations []*Observation
+
+// CostPerBlock is the average wall time spent per block over all the observations
+func (o Observations) CostPerBlock() time.Duration {
+	var duration time.Duration
+	var blocks uint64
+	for _, observation := range o {
+		duration += observation.Duration
+		blocks += observation.Range.Size()
+	}
+	if blocks == 0 {
+		return 0
+	}
+	return duration / time.Duration(blocks)
+}
+
+// Profile returns the cost per block of the module over the block ranges of the
+// observations, to estimate the cost of any block range.
+func (o Observations) Profile() *Profile {
+	type boundary struct {
+		block uint64
+		cost  time.Duration
+		delta int64
+	}
+
+	var boundaries []boundary
+	for _, observation := range o {
+		cost := observation.Duration / time.Duration(observation.Range.Size())
+		boundaries = append(boundaries,
+			boundary{block: observation.Range.StartBlock, cost: cost, delta: 1},
+			boundary{block: observation.Range.ExclusiveEndBlock, cost: cost, delta: -1},
+		)
+	}
+	sort.Slice(boundaries, func(i, j int) bool { return boundaries[i].block < boundaries[j].block })
+
+	profile := &Profile{defaultCost: o.CostPerBlock()}
+
+	// Sweeps the boundaries, each interval between two consecutive ones being covered by
+	// the same observations
+	var costs time.Duration
+	var covering int64
+	for i := 0; i < len(boundaries); {
+		start := boundaries[i].block
+		for ; i < len(boundaries) && boundaries[i].block == start; i++ {
+			costs += boundaries[i].cost * time.Duration(boundaries[i].delta)
+			covering += boundaries[i].delta
+		}
+		if covering != 0 && i < len(boundaries) {
+			profile.segments = append(profile.segments, costSegment{
+				rng:          block.NewRange(start, boundaries[i].block),
+				costPerBlock: costs / time.Duration(covering),
+			})
+		}
+	}
+	return profile
+}
+
+// Profile is the cost per block of a module over the block ranges observed
+type Profile struct {
+	defaultCost time.Duration
+	// segments are sorted and don't overlap
+	segments []costSegment
+}
+
+type costSegment struct {
+	rng          *block.Range
+	costPerBlock time.Duration
+}
+
+// Estimate returns the expected wall time of executing the module over `rng`.
+// Each block costs the average of the observations covering it, blocks not
+// covered by any observation cost the average of all of them.
+func (p *Profile) Estimate(rng *block.Range) time.Duration {
+	var total time.Duration
+	uncovered := rng.Size()
+
+	first := sort.Search(len(p.segments), func(i int) bool { return p.segments[i].rng.ExclusiveEndBlock > rng.StartBlock })
+	for _, segment := range p.segments[first:] {
+		if segment.rng.StartBlock >= rng.ExclusiveEndBlock {
+			break
+		}
+		start, end := segment.rng.StartBlock, segment.rng.ExclusiveEndBlock
+		if start < rng.StartBlock {
+			start = rng.StartBlock
+		}
+		if end > rng.ExclusiveEndBlock {
+			end = rng.ExclusiveEndBlock
+		}
+		total += segment.costPerBlock * time.Duration(end-start)
+		uncovered -= end - start
+	}
+	return total + p.defaultCost*time.Duration(uncovered)
+}
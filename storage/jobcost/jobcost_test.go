@@ -0,0 +1,80 @@
+package jobcost
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/dstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/streamingfast/substreams/block"
+)
+
+func TestStore_RecordLoad(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewStore(dstore.NewMockStore(nil))
+	require.NoError(t, err)
+
+	require.NoError(t, store.Record(ctx, "abc", &Observation{Range: block.ParseRange("100-200"), Duration: 2 * time.Second, BytesRead: 10}))
+	require.NoError(t, store.Record(ctx, "abc", &Observation{Range: block.ParseRange("0-100"), Duration: time.Second}))
+	require.NoError(t, store.Record(ctx, "def", &Observation{Range: block.ParseRange("0-100"), Duration: time.Minute}))
+
+	observations, err := store.Load(ctx, "abc")
+	require.NoError(t, err)
+	require.Len(t, observations, 2)
+	assert.Equal(t, block.ParseRange("0-100").String(), observations[0].Range.String())
+	assert.Equal(t, time.Second, observations[0].Duration)
+	assert.Equal(t, block.ParseRange("100-200").String(), observations[1].Range.String())
+	assert.Equal(t, uint64(10), observations[1].BytesRead)
+
+	observations, err = store.Load(ctx, "unknown")
+	require.NoError(t, err)
+	assert.Empty(t, observations)
+}
+
+func TestStore_RecordReplacesAndCaps(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewStore(dstore.NewMockStore(nil))
+	require.NoError(t, err)
+
+	for i := uint64(0); i < MaxObservations+10; i++ {
+		require.NoError(t, store.Record(ctx, "abc", &Observation{Range: block.NewRange(i*10, (i+1)*10), Duration: time.Second}))
+	}
+	require.NoError(t, store.Record(ctx, "abc", &Observation{Range: block.NewRange(200, 210), Duration: time.Minute}))
+
+	var files []string
+	require.NoError(t, store.store.Walk(ctx, "", func(filename string) error {
+		files = append(files, filename)
+		return nil
+	}))
+	assert.Equal(t, []string{"abc.json"}, files, "one file per module")
+
+	observations, err := store.Load(ctx, "abc")
+	require.NoError(t, err)
+	require.Len(t, observations, MaxObservations)
+	assert.Equal(t, uint64(100), observations[0].Range.StartBlock, "oldest observations dropped")
+	assert.Equal(t, uint64(200), observations[10].Range.StartBlock)
+	assert.Equal(t, time.Minute, observations[10].Duration, "observation over the same range replaced")
+}
+
+func TestProfile_Estimate(t *testing.T) {
+	observations := Observations{
+		{Range: block.ParseRange("0-100"), Duration: 100 * time.Second},
+		{Range: block.ParseRange("50-150"), Duration: 300 * time.Second},
+		{Range: block.ParseRange("200-300"), Duration: 500 * time.Second},
+	}
+
+	assert.Equal(t, 3*time.Second, observations.CostPerBlock())
+
+	profile := observations.Profile()
+	assert.Equal(t, 50*time.Second, profile.Estimate(block.ParseRange("0-50")))
+	assert.Equal(t, 100*time.Second, profile.Estimate(block.ParseRange("50-100")), "average of the overlapping observations")
+	assert.Equal(t, 150*time.Second, profile.Estimate(block.ParseRange("100-150")))
+	assert.Equal(t, 150*time.Second, profile.Estimate(block.ParseRange("150-200")), "not covered")
+	assert.Equal(t, 480*time.Second, profile.Estimate(block.ParseRange("140-260")))
+	assert.Equal(t, 850*time.Second, profile.Estimate(block.ParseRange("250-500")), "partly covered")
+
+	assert.Equal(t, time.Duration(0), Observations(nil).Profile().Estimate(block.ParseRange("0-10")))
+}
@@ -26,6 +26,9 @@ func (b *baseStore) Name() string { return b.name }
 
 func (b *baseStore) InitialBlock() uint64 { return b.moduleInitialBlock }
 
+// SizeBytes returns the total size of the keys and values held by the store
+func (b *baseStore) SizeBytes() uint64 { return b.totalSizeBytes }
+
 func (b *baseStore) String() string {
 	return fmt.Sprintf("%q (%q)", b.name, b.moduleHash)
 }
@@ -289,6 +289,50 @@ func (b *baseStore) Merge(kvPartialStore *PartialKV) error {
 	return nil
 }
 
+// MergeAssociative tells if merging two contiguous partials together, then their
+// result into the full store, gives the same full store as merging them into it one
+// after the other. It is not the case for floating point additions, their rounding
+// depending on the order of the operations.
+func (c *Config) MergeAssociative() bool {
+	switch c.updatePolicy {
+	case pbsubstreams.Module_KindStore_UPDATE_POLICY_SET,
+		pbsubstreams.Module_KindStore_UPDATE_POLICY_SET_IF_NOT_EXISTS,
+		pbsubstreams.Module_KindStore_UPDATE_POLICY_APPEND,
+		pbsubstreams.Module_KindStore_UPDATE_POLICY_MIN,
+		pbsubstreams.Module_KindStore_UPDATE_POLICY_MAX:
+		return true
+	case pbsubstreams.Module_KindStore_UPDATE_POLICY_ADD:
+		valueType := strings.ToLower(c.valueType)
+		return valueType == manifest.OutputValueTypeInt64 || valueType == manifest.OutputValueTypeBigInt
+	}
+	return false
+}
+
+// MergePartial merges `next`, the partial store of the range following the one of
+// `p`, into `p`, which then covers both ranges. Merging the result into a full store
+// is the same as merging both partials into it in order, provided the store's merges
+// are associative.
+func (p *PartialKV) MergePartial(next *PartialKV) error {
+	if err := p.baseStore.Merge(next); err != nil {
+		return err
+	}
+
+	// Merging into the full store deletes the prefixes of both partials
+	for _, prefix := range next.DeletedPrefixes {
+		found := false
+		for _, existing := range p.DeletedPrefixes {
+			if existing == prefix {
+				found = true
+				break
+			}
+		}
+		if !found {
+			p.DeletedPrefixes = append(p.DeletedPrefixes, prefix)
+		}
+	}
+	return nil
+}
+
 func foundOrZeroInt64(in []byte, found bool) int64 {
 	if !found {
 		return 0
@@ -378,6 +378,79 @@ func TestStore_Merge(t *testing.T) {
 	}
 }
 
+func TestPartialKV_MergePartial(t *testing.T) {
+	tests := []struct {
+		updatePolicy pbsubstreams.Module_KindStore_UpdatePolicy
+		valueType    string
+		full, p1, p2 map[string][]byte
+	}{
+		{
+			pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, manifest.OutputValueTypeString,
+			map[string][]byte{"a:1": []byte("full"), "b:1": []byte("full")},
+			map[string][]byte{"a:2": []byte("p1"), "b:1": []byte("p1")},
+			map[string][]byte{"b:1": []byte("p2"), "c:1": []byte("p2")},
+		},
+		{
+			pbsubstreams.Module_KindStore_UPDATE_POLICY_SET_IF_NOT_EXISTS, manifest.OutputValueTypeString,
+			map[string][]byte{"a:1": []byte("full")},
+			map[string][]byte{"a:1": []byte("p1"), "b:1": []byte("p1")},
+			map[string][]byte{"b:1": []byte("p2"), "c:1": []byte("p2")},
+		},
+		{
+			pbsubstreams.Module_KindStore_UPDATE_POLICY_ADD, manifest.OutputValueTypeInt64,
+			map[string][]byte{"a:1": []byte("1"), "b:1": []byte("2")},
+			map[string][]byte{"a:1": []byte("3"), "b:2": []byte("4")},
+			map[string][]byte{"a:1": []byte("5"), "b:1": []byte("6")},
+		},
+		{
+			pbsubstreams.Module_KindStore_UPDATE_POLICY_MAX, manifest.OutputValueTypeBigInt,
+			map[string][]byte{"a:1": []byte("10"), "b:1": []byte("2")},
+			map[string][]byte{"a:1": []byte("3"), "b:1": []byte("40")},
+			map[string][]byte{"a:1": []byte("50"), "c:1": []byte("6")},
+		},
+		{
+			pbsubstreams.Module_KindStore_UPDATE_POLICY_APPEND, manifest.OutputValueTypeString,
+			map[string][]byte{"a:1": []byte("full;")},
+			map[string][]byte{"a:1": []byte("p1;")},
+			map[string][]byte{"a:1": []byte("p2;"), "b:1": []byte("p2;")},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.updatePolicy.String(), func(t *testing.T) {
+			partial := func(kv map[string][]byte, deletedPrefixes ...string) *PartialKV {
+				p := newPartialStore(copyKV(kv), test.updatePolicy, test.valueType, deletedPrefixes)
+				p.logger = zap.NewNop()
+				return p
+			}
+			require.True(t, partial(nil).MergeAssociative())
+
+			// p2 deletes the "b:" keys before writing its own
+			sequential := newStore(copyKV(test.full), test.updatePolicy, test.valueType)
+			require.NoError(t, sequential.Merge(partial(test.p1)))
+			require.NoError(t, sequential.Merge(partial(test.p2, "b:")))
+
+			merged := partial(test.p1)
+			require.NoError(t, merged.MergePartial(partial(test.p2, "b:")))
+			assert.Equal(t, []string{"b:"}, merged.DeletedPrefixes)
+			tree := newStore(copyKV(test.full), test.updatePolicy, test.valueType)
+			require.NoError(t, tree.Merge(merged))
+
+			assert.Equal(t, sequential.kv, tree.kv)
+		})
+	}
+
+	assert.False(t, newPartialStore(nil, pbsubstreams.Module_KindStore_UPDATE_POLICY_ADD, manifest.OutputValueTypeFloat64, nil).MergeAssociative())
+	assert.False(t, newPartialStore(nil, pbsubstreams.Module_KindStore_UPDATE_POLICY_ADD, manifest.OutputValueTypeBigDecimal, nil).MergeAssociative())
+}
+
+func copyKV(kv map[string][]byte) map[string][]byte {
+	out := make(map[string][]byte, len(kv))
+	for k, v := range kv {
+		out[k] = v
+	}
+	return out
+}
+
 func newPartialStore(kv map[string][]byte, updatePolicy pbsubstreams.Module_KindStore_UpdatePolicy, valueType string, deletedPrefixes []string) *PartialKV {
 	b := &baseStore{
 		kv: kv,
@@ -18,6 +18,11 @@ type StoreStorageState struct {
 	InitialCompleteRange *FullStoreFile // Points to a complete .kv file, to initialize the store upon getting started.
 	PartialsMissing      PartialStoreFiles
 	PartialsPresent      PartialStoreFiles
+
+	// PartialsOrphaned are partials files that will never be squashed: covered by
+	// the complete store we start from, or not aligned on the partials we need,
+	// as left by a request that crashed or stopped at a different block.
+	PartialsOrphaned PartialStoreFiles
 }
 
 type FullStoreFile = block.Range
@@ -54,6 +59,12 @@ func NewStoreStorageState(modName string, storeSaveInterval, modInitBlock, workU
 		}
 		ptr = end
 	}
+
+	for _, partial := range snapshots.Partials {
+		if partial.ExclusiveEndBlock <= parallelProcessStartBlock || (partial.ExclusiveEndBlock <= workUpToBlockNum && !out.PartialsPresent.Contains(partial)) {
+			out.PartialsOrphaned = append(out.PartialsOrphaned, partial)
+		}
+	}
 	return
 }
 
@@ -63,6 +74,10 @@ func (s *StoreStorageState) BatchRequests(subreqSplitSize uint64) block.Ranges {
 	return s.PartialsMissing.MergedBuckets(subreqSplitSize)
 }
 
+func (s *StoreStorageState) BatchRequestsByCost(maxSize uint64, maxCost float64, cost func(*block.Range) float64) block.Ranges {
+	return s.PartialsMissing.MergedBucketsByCost(maxSize, maxCost, cost)
+}
+
 func (s *StoreStorageState) InitialProgressRanges() (out block.Ranges) {
 	if s.InitialCompleteRange != nil {
 		out = append(out, s.InitialCompleteRange)
@@ -82,5 +97,6 @@ func (w *StoreStorageState) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddString("intial_range", w.InitialCompleteRange.String())
 	enc.AddInt("partial_missing", len(w.PartialsMissing))
 	enc.AddInt("partial_present", len(w.PartialsPresent))
+	enc.AddInt("partial_orphaned", len(w.PartialsOrphaned))
 	return nil
 }
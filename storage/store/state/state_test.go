@@ -136,3 +136,23 @@ func parseSnapshotSpec(in string) *storeSnapshots {
 	out.Sort()
 	return out
 }
+
+func TestStoreStorageState_PartialsOrphaned(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		snapshots      string
+		reqStart       uint64
+		expectOrphaned string
+	}{
+		{"none", "50-60,p60-70,p70-80", 90, ""},
+		{"covered by complete", "50-80,p60-70,p70-80,p80-90", 100, "60-70,70-80"},
+		{"not aligned on the partials needed", "50-60,p60-75,p70-80", 90, "60-75"},
+		{"above the work left alone", "50-60,p90-100", 90, ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			wu, err := NewStoreStorageState("mod", 10, 50, tt.reqStart, parseSnapshotSpec(tt.snapshots))
+			require.NoError(t, err)
+			assert.Equal(t, block.ParseRanges(tt.expectOrphaned).String(), wu.PartialsOrphaned.String())
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package integration
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/bytecodealliance/wasmtime-go/v4"
+	"github.com/streamingfast/dstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/streamingfast/substreams"
+	"github.com/streamingfast/substreams/orchestrator/work"
+	pbssinternal "github.com/streamingfast/substreams/pb/sf/substreams/intern/v2"
+	pbsubstreamsrpc "github.com/streamingfast/substreams/pb/sf/substreams/rpc/v2"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/service"
+	"github.com/streamingfast/substreams/service/config"
+)
+
+// panickingModule registers a panic, the way Rust modules do, on its first block
+const panickingModule = `
+(module
+  (import "env" "register_panic" (func $register_panic (param i32 i32 i32 i32 i32 i32)))
+  (memory (export "memory") 1)
+  (data (i32.const 0) "invalid transfer")
+  (func (export "alloc") (param i32) (result i32) (i32.const 1024))
+  (func (export "dealloc") (param i32 i32))
+  (func (export "map_fail") (param i32 i32)
+    (call $register_panic (i32.const 0) (i32.const 16) (i32.const 0) (i32.const 0) (i32.const 1) (i32.const 1))
+    unreachable)
+)
+`
+
+func TestModuleFailureThroughRemoteWorker(t *testing.T) {
+	code, err := wasmtime.Wat2Wasm(panickingModule)
+	require.NoError(t, err)
+
+	baseStoreStore, err := dstore.NewStore(filepath.Join(t.TempDir(), "test.store"), "", "none", true)
+	require.NoError(t, err)
+	tr := &TestRunner{
+		t:              t,
+		baseStoreStore: baseStoreStore,
+		blockGeneratorFactory: func(startBlock uint64, inclusiveStopBlock uint64) TestBlockGenerator {
+			return &LinearBlockGenerator{startBlock: startBlock, inclusiveStopBlock: inclusiveStopBlock}
+		},
+	}
+	runtimeConfig := config.NewRuntimeConfig(10, 10, 1, 10, 0, baseStoreStore, nil)
+	tier2 := service.TestNewServiceTier2(runtimeConfig, tr.StreamFactory)
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	pbssinternal.RegisterSubstreamsServer(server, tier2)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	clientFactory := func() (pbssinternal.SubstreamsClient, func() error, []grpc.CallOption, error) {
+		conn, err := grpc.Dial("bufnet",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return pbssinternal.NewSubstreamsClient(conn), conn.Close, nil, nil
+	}
+
+	request := &pbssinternal.ProcessRangeRequest{
+		StartBlockNum: 0,
+		StopBlockNum:  10,
+		OutputModule:  "map_fail",
+		Modules: &pbsubstreams.Modules{
+			Binaries: []*pbsubstreams.Binary{{Type: "wasm/rust-v1", Content: code}},
+			Modules: []*pbsubstreams.Module{{
+				Name:             "map_fail",
+				Kind:             &pbsubstreams.Module_KindMap_{KindMap: &pbsubstreams.Module_KindMap{OutputType: "proto:sf.substreams.v1.test.MapResult"}},
+				BinaryEntrypoint: "map_fail",
+				Inputs: []*pbsubstreams.Module_Input{{
+					Input: &pbsubstreams.Module_Input_Source_{Source: &pbsubstreams.Module_Input_Source{Type: "sf.substreams.v1.test.Block"}},
+				}},
+				Output: &pbsubstreams.Module_Output{Type: "proto:sf.substreams.v1.test.MapResult"},
+			}},
+		},
+	}
+
+	var failures []*pbsubstreamsrpc.ModuleProgress_Failed
+	respFunc := func(resp substreams.ResponseFromAnyTier) error {
+		for _, module := range resp.(*pbsubstreamsrpc.Response).GetProgress().GetModules() {
+			if failed := module.GetFailed(); failed != nil {
+				failures = append(failures, failed)
+			}
+		}
+		return nil
+	}
+
+	worker := work.NewRemoteWorker(clientFactory, "bufnet", zlog)
+	result := worker.Work(context.Background(), request, respFunc)
+
+	var moduleErr *work.ModuleExecutionErr
+	require.ErrorAs(t, result.Error, &moduleErr)
+	assert.Equal(t, "map_fail", moduleErr.ModuleName)
+	assert.Contains(t, moduleErr.Reason, "invalid transfer")
+	assert.Equal(t, work.ErrorClassDeterministic, work.ClassifyError(result.Error))
+
+	require.Len(t, failures, 1, "failure forwarded to the client")
+	assert.Contains(t, failures[0].Reason, "invalid transfer")
+
+	breaker := work.NewCircuitBreaker(1, 0)
+	breaker.Record("bufnet", result.Error)
+	assert.False(t, breaker.IsOpen("bufnet"))
+}
@@ -147,7 +147,7 @@ func runDecodeStatesModuleRunE(cmd *cobra.Command, args []string) error {
 	startBlock := execout.ComputeStartBlock(blockNumber, saveInterval)
 
 	switch matchingModule.Kind.(type) {
-	case *pbsubstreams.Module_KindMap_:
+	case *pbsubstreams.Module_KindMap_, *pbsubstreams.Module_KindBlocks_:
 		return fmt.Errorf("no states are available for a mapper")
 	case *pbsubstreams.Module_KindStore_:
 		return searchStateModule(ctx, startBlock, saveInterval, moduleHash, key, matchingModule, objStore, protoFiles)
@@ -228,7 +228,7 @@ func runDecodeOutputsModuleRunE(cmd *cobra.Command, args []string) error {
 	startBlock := execout.ComputeStartBlock(blockNumber, saveInterval)
 
 	switch matchingModule.Kind.(type) {
-	case *pbsubstreams.Module_KindMap_:
+	case *pbsubstreams.Module_KindMap_, *pbsubstreams.Module_KindBlocks_:
 		return searchOutputsModule(ctx, blockNumber, startBlock, saveInterval, moduleHash, matchingModule, s, protoFiles)
 	case *pbsubstreams.Module_KindStore_:
 		return searchOutputsModule(ctx, blockNumber, startBlock, saveInterval, moduleHash, matchingModule, s, protoFiles)
@@ -308,7 +308,7 @@ func printObject(module *pbsubstreams.Module, protoFiles []*descriptorpb.FileDes
 	valuePrinted := false
 
 	switch module.Kind.(type) {
-	case *pbsubstreams.Module_KindMap_:
+	case *pbsubstreams.Module_KindMap_, *pbsubstreams.Module_KindBlocks_:
 		protoDefinition = module.Output.GetType()
 	case *pbsubstreams.Module_KindStore_:
 		protoDefinition = module.Kind.(*pbsubstreams.Module_KindStore_).KindStore.ValueType
@@ -325,7 +325,7 @@ func printObject(module *pbsubstreams.Module, protoFiles []*descriptorpb.FileDes
 		msgDesc = file.FindMessage(strings.TrimPrefix(protoDefinition, "proto:"))
 		if msgDesc != nil {
 			switch module.Kind.(type) {
-			case *pbsubstreams.Module_KindMap_:
+			case *pbsubstreams.Module_KindMap_, *pbsubstreams.Module_KindBlocks_:
 				dynMsg := dynamic.NewMessageFactoryWithDefaults().NewDynamicMessage(msgDesc)
 				val, err := unmarshalData(data, dynMsg)
 				if err != nil {
@@ -6,8 +6,9 @@ import (
 
 func newModel(ui *TUI) model {
 	return model{
-		Modules: updatedRanges{},
-		ui:      ui,
+		Modules:   updatedRanges{},
+		Estimates: map[string]*pbsubstreamsrpc.ProgressEstimate{},
+		ui:        ui,
 	}
 }
 
@@ -23,6 +24,9 @@ type model struct {
 	UpdatesPerSecond  int
 	UpdatesThisSecond int
 
+	Estimate  *pbsubstreamsrpc.ProgressEstimate
+	Estimates map[string]*pbsubstreamsrpc.ProgressEstimate
+
 	Request                       *pbsubstreamsrpc.Request
 	BackprocessingCompleteAtBlock uint64
 	Connected                     bool
@@ -216,6 +216,7 @@ func (ui *TUI) formatPostDataProgress(msg *pbsubstreamsrpc.Response_Progress) {
 		case *pbsubstreamsrpc.ModuleProgress_ProcessedRanges_:
 		case *pbsubstreamsrpc.ModuleProgress_InitialState_:
 		case *pbsubstreamsrpc.ModuleProgress_ProcessedBytes_:
+		case *pbsubstreamsrpc.ModuleProgress_Estimate:
 		case *pbsubstreamsrpc.ModuleProgress_Failed_:
 			failure := progMsg.Failed
 			if !displayedFailure {
@@ -78,6 +78,8 @@ func (ui *TUI) Init(outputMode string) error {
 					msgType = modKind.KindStore.ValueType
 				case *pbsubstreams.Module_KindMap_:
 					msgType = modKind.KindMap.OutputType
+				case *pbsubstreams.Module_KindBlocks_:
+					msgType = mod.Output.Type
 				}
 				msgType = strings.TrimPrefix(msgType, "proto:")
 
@@ -149,6 +151,16 @@ func (ui *TUI) IncomingMessage(ctx context.Context, resp *pbsubstreamsrpc.Respon
 			printUndoJSON(m.BlockUndoSignal.LastValidBlock, m.BlockUndoSignal.LastValidCursor)
 		}
 
+	case *pbsubstreamsrpc.Response_BlockScopedCursor:
+		// The block's output did not match the request's output filter, there is nothing to print
+
+	case *pbsubstreamsrpc.Response_BlockScopedDatas:
+		for _, data := range m.BlockScopedDatas.Items {
+			if err := ui.IncomingMessage(ctx, &pbsubstreamsrpc.Response{Message: &pbsubstreamsrpc.Response_BlockScopedData{BlockScopedData: data}}, testRunner); err != nil {
+				return err
+			}
+		}
+
 	case *pbsubstreamsrpc.Response_BlockScopedData:
 		if testRunner != nil {
 			if err := testRunner.Test(ctx, m.BlockScopedData.Output, m.BlockScopedData.DebugMapOutputs, m.BlockScopedData.DebugStoreOutputs, m.BlockScopedData.Clock); err != nil {
@@ -163,11 +175,12 @@ func (ui *TUI) IncomingMessage(ctx context.Context, resp *pbsubstreamsrpc.Respon
 			return nil
 		}
 		ui.seenFirstData = true
+		mapOutputs := append(append([]*pbsubstreamsrpc.MapModuleOutput{}, m.BlockScopedData.ExtraOutputs...), m.BlockScopedData.DebugMapOutputs...)
 		if ui.outputMode == OutputModeTUI {
 			ui.ensureTerminalUnlocked()
-			return ui.decoratedBlockScopedData(m.BlockScopedData.Output, m.BlockScopedData.DebugMapOutputs, m.BlockScopedData.DebugStoreOutputs, m.BlockScopedData.Clock)
+			return ui.decoratedBlockScopedData(m.BlockScopedData.Output, mapOutputs, m.BlockScopedData.DebugStoreOutputs, m.BlockScopedData.Clock)
 		} else {
-			return ui.jsonBlockScopedData(m.BlockScopedData.Output, m.BlockScopedData.DebugMapOutputs, m.BlockScopedData.DebugStoreOutputs, m.BlockScopedData.Clock)
+			return ui.jsonBlockScopedData(m.BlockScopedData.Output, mapOutputs, m.BlockScopedData.DebugStoreOutputs, m.BlockScopedData.Clock)
 		}
 	case *pbsubstreamsrpc.Response_Progress:
 		if ui.seenFirstData {
@@ -178,6 +191,9 @@ func (ui *TUI) IncomingMessage(ctx context.Context, resp *pbsubstreamsrpc.Respon
 				for _, module := range m.Progress.Modules {
 					ui.prog.Send(module)
 				}
+				if m.Progress.Estimate != nil {
+					ui.prog.Send(m.Progress.Estimate)
+				}
 			}
 		}
 	case *pbsubstreamsrpc.Response_DebugSnapshotData:
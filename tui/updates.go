@@ -51,6 +51,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case *pbsubstreamsrpc.Response_Session:
 		m.TraceID = msg.Session.TraceId
 
+	case *pbsubstreamsrpc.ProgressEstimate:
+		m.Estimate = msg
+
 	case *pbsubstreamsrpc.ModuleProgress:
 		m.Updates += 1
 		thisSec := time.Now().Unix()
@@ -78,6 +81,13 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.Modules = newModules
 		case *pbsubstreamsrpc.ModuleProgress_InitialState_:
 		case *pbsubstreamsrpc.ModuleProgress_ProcessedBytes_:
+		case *pbsubstreamsrpc.ModuleProgress_Estimate:
+			newEstimates := map[string]*pbsubstreamsrpc.ProgressEstimate{}
+			for k, v := range m.Estimates {
+				newEstimates[k] = v
+			}
+			newEstimates[msg.Name] = progMsg.Estimate
+			m.Estimates = newEstimates
 		case *pbsubstreamsrpc.ModuleProgress_Failed_:
 			m.Failures += 1
 			if progMsg.Failed.Reason != "" {
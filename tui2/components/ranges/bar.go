@@ -18,7 +18,8 @@ type Bar struct {
 	name           string
 	targetEndBlock uint64
 
-	ranges ranges
+	ranges   ranges
+	estimate *pbsubstreamsrpc.ProgressEstimate
 }
 
 func NewBar(c common.Common, name string, targetEndBlock uint64) *Bar {
@@ -38,6 +39,8 @@ func (b *Bar) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				End:   v.EndBlock,
 			})
 		}
+	case *pbsubstreamsrpc.ModuleProgress_Estimate:
+		b.estimate = msg.Estimate
 	}
 	return b, nil
 }
@@ -86,3 +89,11 @@ func (b *Bar) RangeView(long bool) string {
 	fullBar := "[" + strings.Join(out, " ") + "]"
 	return lipgloss.NewStyle().MaxWidth(b.Width).Render(fullBar)
 }
+
+// EstimateView shows when the module is expected to complete its backprocessing
+func (b *Bar) EstimateView() string {
+	if b.estimate == nil {
+		return "[no estimate]"
+	}
+	return lipgloss.NewStyle().MaxWidth(b.Width).Render(b.estimate.Summary())
+}
@@ -65,6 +65,8 @@ func (b *Bars) View() string {
 			bars = append(bars, bar.RangeView(false))
 		case 2:
 			bars = append(bars, bar.RangeView(true))
+		case 3:
+			bars = append(bars, bar.EstimateView())
 		}
 	}
 	return lipgloss.JoinVertical(0,
@@ -29,6 +29,7 @@ type Progress struct {
 
 	progressView      viewport.Model
 	progressUpdates   int
+	estimate          *pbsubstreamsrpc.ProgressEstimate
 	dataPayloads      int
 	updatedSecond     int64
 	updatesPerSecond  int
@@ -60,7 +61,7 @@ func (p *Progress) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.(tea.KeyMsg).String() {
 		case "m":
-			p.bars.Mode = (p.bars.Mode + 1) % 3
+			p.bars.Mode = (p.bars.Mode + 1) % 4
 			p.progressView.SetContent(p.bars.View())
 		}
 		var cmd tea.Cmd
@@ -70,6 +71,7 @@ func (p *Progress) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case request.NewRequestInstance:
 		targetBlock := msg.(request.NewRequestInstance).Stream.TargetParallelProcessingBlock()
 		p.dataPayloads = 0
+		p.estimate = nil
 		p.targetBlock = targetBlock
 		p.bars = ranges.NewBars(p.Common, targetBlock)
 		p.bars.Init()
@@ -84,6 +86,9 @@ func (p *Progress) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			p.updatedSecond = thisSec
 		}
 		p.updatesThisSecond += 1
+		if estimate := msg.(*pbsubstreamsrpc.ModulesProgress).Estimate; estimate != nil {
+			p.estimate = estimate
+		}
 		p.bars.Update(msg)
 		p.progressView.SetContent(p.bars.View())
 	case stream.StreamErrorMsg:
@@ -108,6 +113,7 @@ func (p *Progress) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 var labels = []string{
 	"Parallel engine progress messages: ",
 	"Target block: ",
+	"Estimated completion: ",
 	"Data payloads received: ",
 	"Status: ",
 }
@@ -126,6 +132,7 @@ func (p *Progress) View() string {
 	infos := []string{
 		fmt.Sprintf("%d (%d block/sec)", p.progressUpdates, p.updatesPerSecond),
 		fmt.Sprintf("%d", p.targetBlock),
+		p.estimateView(),
 		fmt.Sprintf("%d", p.dataPayloads),
 		p.Styles.StatusBarValue.Render(p.state + p.replayState),
 	}
@@ -139,8 +146,15 @@ func (p *Progress) View() string {
 	)
 }
 
+func (p *Progress) estimateView() string {
+	if p.estimate == nil {
+		return "-"
+	}
+	return p.estimate.Summary()
+}
+
 func (p *Progress) SetSize(w, h int) {
-	headerHeight := 7
+	headerHeight := 8
 	p.Common.SetSize(w, h)
 	if p.bars != nil {
 		p.bars.SetSize(w, h-headerHeight)
@@ -0,0 +1,787 @@
+// Package codehash computes the hash of the code of a WASM binary reachable from one of its
+// exported functions, so that modules sharing a binary keep the same hash when only code
+// they cannot call changes.
+//
+// The analysis is conservative: everything that may influence the execution of the
+// entrypoint is part of the hash. This includes the functions the host calls besides the
+// entrypoint (the allocator), all the globals, memories, tables and data segments of the
+// binary, and, as soon as a reachable function uses the table (through `call_indirect` or any
+// table instruction), all the functions referenced by element segments. Function and type
+// indices are replaced by the order in which functions are reached and by the signatures, so
+// that adding or removing unreachable functions does not change the hash.
+//
+// Data segments are hashed as is, code reaching any static data through address arithmetic:
+// changing a constant or a table of a binary changes the hash of all its modules.
+package codehash
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	sectionCustom    = 0
+	sectionType      = 1
+	sectionImport    = 2
+	sectionFunction  = 3
+	sectionTable     = 4
+	sectionMemory    = 5
+	sectionGlobal    = 6
+	sectionExport    = 7
+	sectionStart     = 8
+	sectionElement   = 9
+	sectionCode      = 10
+	sectionData      = 11
+	sectionDataCount = 12
+)
+
+// hostExports are the functions the host calls on any module, besides its entrypoint
+var hostExports = []string{"alloc", "dealloc"}
+
+// Binary is a parsed WASM binary.
+type Binary struct {
+	types [][]byte
+
+	// funcTypes holds the type index of each function, imported ones first
+	funcTypes []uint32
+	// funcImports holds the module and name of imported functions, by function index
+	funcImports [][2]string
+	// bodies holds the code of the functions defined in the binary, by function index minus
+	// the number of imported functions
+	bodies [][]byte
+
+	otherImports []byte
+	tables       []byte
+	memories     []byte
+	globals      []byte
+	elements     []byte
+	data         []byte
+	dataCount    []byte
+
+	exports map[string]uint32
+	start   *uint32
+}
+
+// Parse decodes the sections of the WASM binary `content`.
+func Parse(content []byte) (*Binary, error) {
+	r := &reader{buf: content}
+	magic, err := r.bytes(8)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(magic, []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}) {
+		return nil, fmt.Errorf("invalid wasm binary: bad magic number or version")
+	}
+
+	out := &Binary{exports: map[string]uint32{}}
+	for !r.done() {
+		id, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		size, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+		payload, err := r.bytes(int(size))
+		if err != nil {
+			return nil, fmt.Errorf("section %d: %w", id, err)
+		}
+
+		if err := out.parseSection(id, &reader{buf: payload}); err != nil {
+			return nil, fmt.Errorf("section %d: %w", id, err)
+		}
+	}
+
+	if len(out.funcTypes)-len(out.funcImports) != len(out.bodies) {
+		return nil, fmt.Errorf("invalid wasm binary: %d functions declared but %d bodies", len(out.funcTypes)-len(out.funcImports), len(out.bodies))
+	}
+	for _, typeIdx := range out.funcTypes {
+		if int(typeIdx) >= len(out.types) {
+			return nil, fmt.Errorf("invalid wasm binary: unknown type %d", typeIdx)
+		}
+	}
+	return out, nil
+}
+
+func (b *Binary) parseSection(id byte, r *reader) error {
+	switch id {
+	case sectionType:
+		return r.vec(func() error {
+			start := r.pos
+			form, err := r.byte()
+			if err != nil {
+				return err
+			}
+			if form != 0x60 {
+				return fmt.Errorf("unsupported type form 0x%x", form)
+			}
+			if err := r.skipVec(r.skipByte); err != nil {
+				return err
+			}
+			if err := r.skipVec(r.skipByte); err != nil {
+				return err
+			}
+			b.types = append(b.types, r.buf[start:r.pos])
+			return nil
+		})
+
+	case sectionImport:
+		return r.vec(func() error {
+			start := r.pos
+			module, err := r.name()
+			if err != nil {
+				return err
+			}
+			name, err := r.name()
+			if err != nil {
+				return err
+			}
+			kind, err := r.byte()
+			if err != nil {
+				return err
+			}
+			switch kind {
+			case 0x00:
+				typeIdx, err := r.u32()
+				if err != nil {
+					return err
+				}
+				b.funcTypes = append(b.funcTypes, typeIdx)
+				b.funcImports = append(b.funcImports, [2]string{module, name})
+				return nil
+			case 0x01:
+				err = r.skipTableType()
+			case 0x02:
+				err = r.skipLimits()
+			case 0x03:
+				_, err = r.bytes(2)
+			default:
+				err = fmt.Errorf("unsupported import kind 0x%x", kind)
+			}
+			if err != nil {
+				return err
+			}
+			b.otherImports = append(b.otherImports, r.buf[start:r.pos]...)
+			return nil
+		})
+
+	case sectionFunction:
+		return r.vec(func() error {
+			typeIdx, err := r.u32()
+			if err != nil {
+				return err
+			}
+			b.funcTypes = append(b.funcTypes, typeIdx)
+			return nil
+		})
+
+	case sectionTable:
+		b.tables = r.buf
+	case sectionMemory:
+		b.memories = r.buf
+	case sectionGlobal:
+		b.globals = r.buf
+	case sectionElement:
+		b.elements = r.buf
+	case sectionData:
+		b.data = r.buf
+	case sectionDataCount:
+		b.dataCount = r.buf
+
+	case sectionExport:
+		return r.vec(func() error {
+			name, err := r.name()
+			if err != nil {
+				return err
+			}
+			kind, err := r.byte()
+			if err != nil {
+				return err
+			}
+			idx, err := r.u32()
+			if err != nil {
+				return err
+			}
+			if kind == 0x00 {
+				b.exports[name] = idx
+			}
+			return nil
+		})
+
+	case sectionStart:
+		idx, err := r.u32()
+		if err != nil {
+			return err
+		}
+		b.start = &idx
+
+	case sectionCode:
+		return r.vec(func() error {
+			size, err := r.u32()
+			if err != nil {
+				return err
+			}
+			body, err := r.bytes(int(size))
+			if err != nil {
+				return err
+			}
+			b.bodies = append(b.bodies, body)
+			return nil
+		})
+
+	case sectionCustom:
+		// Names and debug information do not influence the execution
+	default:
+		return fmt.Errorf("unsupported section")
+	}
+	return nil
+}
+
+// ReachableHash returns the hash of the code reachable from the exported function
+// `entrypoint`, along with everything else that may influence its execution.
+func (b *Binary) ReachableHash(entrypoint string) ([]byte, error) {
+	entry, found := b.exports[entrypoint]
+	if !found {
+		return nil, fmt.Errorf("function %q is not exported by the wasm binary", entrypoint)
+	}
+
+	h := &hasher{
+		binary:    b,
+		canonical: map[uint32]uint32{},
+	}
+
+	h.reach(entry)
+	for _, name := range hostExports {
+		if funcIdx, found := b.exports[name]; found {
+			h.reach(funcIdx)
+		}
+	}
+	if b.start != nil {
+		h.reach(*b.start)
+	}
+	// Functions referenced by globals initializers are reached too
+	if _, err := h.canonicalConstExprs(b.globals, globalEntries); err != nil {
+		return nil, fmt.Errorf("globals: %w", err)
+	}
+
+	for i := 0; i < len(h.order); i++ {
+		if err := h.visitFunction(h.order[i]); err != nil {
+			return nil, err
+		}
+		if h.usesTable && !h.elementsReached {
+			h.elementsReached = true
+			if _, err := h.canonicalConstExprs(b.elements, elementEntries); err != nil {
+				return nil, fmt.Errorf("elements: %w", err)
+			}
+		}
+	}
+
+	// All reachable functions have a canonical index, encode them
+	out := sha1.New()
+	write := func(tag string, content []byte) {
+		out.Write([]byte(tag))
+		out.Write(binary.LittleEndian.AppendUint64(nil, uint64(len(content))))
+		out.Write(content)
+	}
+
+	for _, funcIdx := range h.order {
+		write("type", b.types[b.funcTypes[funcIdx]])
+		if int(funcIdx) < len(b.funcImports) {
+			write("import_module", []byte(b.funcImports[funcIdx][0]))
+			write("import_name", []byte(b.funcImports[funcIdx][1]))
+			continue
+		}
+
+		body, err := h.canonicalBody(funcIdx)
+		if err != nil {
+			return nil, err
+		}
+		write("body", body)
+	}
+
+	globals, err := h.canonicalConstExprs(b.globals, globalEntries)
+	if err != nil {
+		return nil, fmt.Errorf("globals: %w", err)
+	}
+	if b.start != nil {
+		write("start", binary.AppendUvarint(nil, uint64(h.canonical[*b.start])))
+	}
+	write("globals", globals)
+	write("imports", b.otherImports)
+	write("tables", b.tables)
+	write("memories", b.memories)
+	write("data", b.data)
+	write("data_count", b.dataCount)
+
+	if h.elementsReached {
+		elements, err := h.canonicalConstExprs(b.elements, elementEntries)
+		if err != nil {
+			return nil, fmt.Errorf("elements: %w", err)
+		}
+		write("elements", elements)
+	}
+
+	return out.Sum(nil), nil
+}
+
+type hasher struct {
+	binary *Binary
+
+	// order holds the reached functions, by order of discovery, which gives their canonical index
+	order     []uint32
+	canonical map[uint32]uint32
+
+	usesTable       bool
+	elementsReached bool
+}
+
+func (h *hasher) reach(funcIdx uint32) {
+	if _, found := h.canonical[funcIdx]; found {
+		return
+	}
+	h.canonical[funcIdx] = uint32(len(h.order))
+	h.order = append(h.order, funcIdx)
+}
+
+func (h *hasher) visitFunction(funcIdx uint32) error {
+	if int(funcIdx) >= len(h.binary.funcTypes) {
+		return fmt.Errorf("unknown function %d", funcIdx)
+	}
+	if int(funcIdx) < len(h.binary.funcImports) {
+		return nil
+	}
+
+	_, err := h.canonicalBody(funcIdx)
+	return err
+}
+
+// canonicalBody decodes the body of function `funcIdx`, reaching the functions it refers
+// to, and returns its encoding with canonical function indices and inlined type signatures.
+func (h *hasher) canonicalBody(funcIdx uint32) ([]byte, error) {
+	r := &reader{buf: h.binary.bodies[int(funcIdx)-len(h.binary.funcImports)]}
+
+	localsStart := r.pos
+	err := r.vec(func() error {
+		if _, err := r.u32(); err != nil {
+			return err
+		}
+		_, err := r.byte()
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("function %d: locals: %w", funcIdx, err)
+	}
+
+	out := append([]byte(nil), r.buf[localsStart:r.pos]...)
+	for !r.done() {
+		out, err = h.canonicalInstruction(r, out)
+		if err != nil {
+			return nil, fmt.Errorf("function %d: %w", funcIdx, err)
+		}
+	}
+	return out, nil
+}
+
+// canonicalInstruction decodes the instruction at the position of `r`, appending its
+// canonical encoding to `out`.
+func (h *hasher) canonicalInstruction(r *reader, out []byte) ([]byte, error) {
+	start := r.pos
+	op, err := r.byte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case op == 0x02 || op == 0x03 || op == 0x04: // block, loop, if
+		blockType, err := r.s33()
+		if err != nil {
+			return nil, err
+		}
+		if blockType >= 0 {
+			return h.appendType(append(out, op), uint32(blockType))
+		}
+
+	case op == 0x0c || op == 0x0d: // br, br_if
+		_, err = r.u32()
+
+	case op == 0x0e: // br_table
+		if err = r.skipVec(r.skipU32); err == nil {
+			_, err = r.u32()
+		}
+
+	case op == 0x10 || op == 0x12: // call, return_call
+		funcIdx, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+		return h.appendFunc(append(out, op), funcIdx)
+
+	case op == 0x11 || op == 0x13: // call_indirect, return_call_indirect
+		h.usesTable = true
+		typeIdx, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+		tableStart := r.pos
+		if _, err := r.u32(); err != nil {
+			return nil, err
+		}
+		out, err = h.appendType(append(out, op), typeIdx)
+		if err != nil {
+			return nil, err
+		}
+		return append(out, r.buf[tableStart:r.pos]...), nil
+
+	case op == 0x1c: // select t*
+		err = r.skipVec(r.skipByte)
+
+	case op >= 0x20 && op <= 0x24: // local.*, global.*
+		_, err = r.u32()
+
+	case op == 0x25 || op == 0x26: // table.get, table.set
+		h.usesTable = true
+		_, err = r.u32()
+
+	case op >= 0x28 && op <= 0x3e: // loads and stores
+		err = r.skipMemArg()
+
+	case op == 0x3f || op == 0x40: // memory.size, memory.grow
+		_, err = r.u32()
+
+	case op == 0x41: // i32.const
+		_, err = r.s64()
+	case op == 0x42: // i64.const
+		_, err = r.s64()
+	case op == 0x43: // f32.const
+		_, err = r.bytes(4)
+	case op == 0x44: // f64.const
+		_, err = r.bytes(8)
+
+	case op == 0xd0: // ref.null
+		_, err = r.byte()
+
+	case op == 0xd2: // ref.func
+		funcIdx, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+		return h.appendFunc(append(out, op), funcIdx)
+
+	case op == 0xfc:
+		var subOp uint32
+		subOp, err = r.u32()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case subOp <= 7: // trunc_sat
+		case subOp == 8 || subOp == 10 || subOp == 12 || subOp == 14: // memory.init, memory.copy, table.init, table.copy
+			if _, err = r.u32(); err == nil {
+				_, err = r.u32()
+			}
+		case subOp == 9 || subOp == 11 || subOp == 13: // data.drop, memory.fill, elem.drop
+			_, err = r.u32()
+		case subOp >= 15 && subOp <= 17: // table.grow, table.size, table.fill
+			_, err = r.u32()
+		default:
+			return nil, fmt.Errorf("unsupported instruction 0xfc %d", subOp)
+		}
+		if subOp >= 12 {
+			h.usesTable = true
+		}
+
+	case op == 0xfd: // vector instructions
+		var subOp uint32
+		subOp, err = r.u32()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case subOp <= 11 || subOp == 92 || subOp == 93: // v128.load*, v128.store, v128.load*_zero
+			err = r.skipMemArg()
+		case subOp == 12 || subOp == 13: // v128.const, i8x16.shuffle
+			_, err = r.bytes(16)
+		case subOp >= 21 && subOp <= 34: // extract_lane, replace_lane
+			_, err = r.byte()
+		case subOp >= 84 && subOp <= 91: // v128.load*_lane, v128.store*_lane
+			if err = r.skipMemArg(); err == nil {
+				_, err = r.byte()
+			}
+		case subOp <= 0x113: // numeric and relaxed vector instructions
+			// No immediates
+		default:
+			return nil, fmt.Errorf("unsupported instruction 0xfd %d", subOp)
+		}
+
+	case op == 0xfe: // atomic instructions
+		var subOp uint32
+		subOp, err = r.u32()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case subOp <= 0x02 || (subOp >= 0x10 && subOp <= 0x4e): // notify, wait, loads, stores, rmw
+			err = r.skipMemArg()
+		case subOp == 0x03: // atomic.fence
+			_, err = r.byte()
+		default:
+			return nil, fmt.Errorf("unsupported instruction 0xfe %d", subOp)
+		}
+
+	case op <= 0x01 || op == 0x05 || op == 0x0b || op == 0x0f || op == 0x1a || op == 0x1b || op == 0xd1:
+		// No immediates
+	case op >= 0x45 && op <= 0xc4: // numeric instructions
+		// No immediates
+
+	default:
+		return nil, fmt.Errorf("unsupported instruction 0x%x", op)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return append(out, r.buf[start:r.pos]...), nil
+}
+
+func (h *hasher) appendFunc(out []byte, funcIdx uint32) ([]byte, error) {
+	if int(funcIdx) >= len(h.binary.funcTypes) {
+		return nil, fmt.Errorf("unknown function %d", funcIdx)
+	}
+	h.reach(funcIdx)
+	return binary.AppendUvarint(out, uint64(h.canonical[funcIdx])), nil
+}
+
+func (h *hasher) appendType(out []byte, typeIdx uint32) ([]byte, error) {
+	if int(typeIdx) >= len(h.binary.types) {
+		return nil, fmt.Errorf("unknown type %d", typeIdx)
+	}
+	return append(out, h.binary.types[typeIdx]...), nil
+}
+
+type constExprsLayout int
+
+const (
+	globalEntries constExprsLayout = iota
+	elementEntries
+)
+
+// canonicalConstExprs decodes the entries of the globals or elements `section`, reaching
+// the functions they refer to, and returns its encoding with canonical function indices.
+func (h *hasher) canonicalConstExprs(section []byte, layout constExprsLayout) ([]byte, error) {
+	if len(section) == 0 {
+		return nil, nil
+	}
+
+	r := &reader{buf: section}
+	var out []byte
+	constExpr := func() error {
+		for {
+			op := r.peek()
+			var err error
+			if out, err = h.canonicalInstruction(r, out); err != nil {
+				return err
+			}
+			if op == 0x0b {
+				return nil
+			}
+		}
+	}
+	copyRaw := func(read func() error) error {
+		start := r.pos
+		if err := read(); err != nil {
+			return err
+		}
+		out = append(out, r.buf[start:r.pos]...)
+		return nil
+	}
+
+	err := r.vec(func() error {
+		if layout == globalEntries {
+			if err := copyRaw(func() error { _, err := r.bytes(2); return err }); err != nil {
+				return err
+			}
+			return constExpr()
+		}
+
+		flags, err := r.u32()
+		if err != nil {
+			return err
+		}
+		out = binary.AppendUvarint(out, uint64(flags))
+		if flags > 7 {
+			return fmt.Errorf("unsupported element segment flags %d", flags)
+		}
+
+		if flags&0x02 != 0 && flags&0x01 == 0 { // explicit table index
+			if err := copyRaw(r.skipU32); err != nil {
+				return err
+			}
+		}
+		if flags&0x01 == 0 { // active segment
+			if err := constExpr(); err != nil {
+				return err
+			}
+		}
+		if flags&0x03 != 0 { // element kind or reference type
+			if err := copyRaw(r.skipByte); err != nil {
+				return err
+			}
+		}
+
+		return r.vec(func() error {
+			if flags&0x04 != 0 {
+				return constExpr()
+			}
+			funcIdx, err := r.u32()
+			if err != nil {
+				return err
+			}
+			out, err = h.appendFunc(out, funcIdx)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type reader struct {
+	buf []byte
+	pos int
+}
+
+func (r *reader) done() bool {
+	return r.pos >= len(r.buf)
+}
+
+func (r *reader) peek() byte {
+	if r.done() {
+		return 0
+	}
+	return r.buf[r.pos]
+}
+
+func (r *reader) byte() (byte, error) {
+	if r.done() {
+		return 0, fmt.Errorf("unexpected end of data")
+	}
+	r.pos++
+	return r.buf[r.pos-1], nil
+}
+
+func (r *reader) bytes(n int) ([]byte, error) {
+	if n < 0 || r.pos+n > len(r.buf) {
+		return nil, fmt.Errorf("unexpected end of data")
+	}
+	r.pos += n
+	return r.buf[r.pos-n : r.pos], nil
+}
+
+func (r *reader) u32() (uint32, error) {
+	value, n := binary.Uvarint(r.buf[r.pos:])
+	if n <= 0 || value > 0xffffffff {
+		return 0, fmt.Errorf("invalid unsigned integer")
+	}
+	r.pos += n
+	return uint32(value), nil
+}
+
+func (r *reader) s64() (int64, error) {
+	var value int64
+	var shift uint
+	for {
+		b, err := r.byte()
+		if err != nil {
+			return 0, err
+		}
+		value |= int64(b&0x7f) << shift
+		shift += 7
+		if b&0x80 == 0 {
+			if shift < 64 && b&0x40 != 0 {
+				value |= -1 << shift
+			}
+			return value, nil
+		}
+		if shift >= 70 {
+			return 0, fmt.Errorf("invalid signed integer")
+		}
+	}
+}
+
+// s33 reads a block type, negative for the single byte value types and empty type
+func (r *reader) s33() (int64, error) {
+	return r.s64()
+}
+
+func (r *reader) name() (string, error) {
+	size, err := r.u32()
+	if err != nil {
+		return "", err
+	}
+	cnt, err := r.bytes(int(size))
+	if err != nil {
+		return "", err
+	}
+	return string(cnt), nil
+}
+
+func (r *reader) vec(item func() error) error {
+	count, err := r.u32()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		if err := item(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *reader) skipVec(item func() error) error {
+	return r.vec(item)
+}
+
+func (r *reader) skipByte() error {
+	_, err := r.byte()
+	return err
+}
+
+func (r *reader) skipU32() error {
+	_, err := r.u32()
+	return err
+}
+
+// skipMemArg skips the alignment and offset of a memory access
+func (r *reader) skipMemArg() error {
+	if _, err := r.u32(); err != nil {
+		return err
+	}
+	_, err := r.u32()
+	return err
+}
+
+func (r *reader) skipLimits() error {
+	flags, err := r.byte()
+	if err != nil {
+		return err
+	}
+	if _, err := r.u32(); err != nil {
+		return err
+	}
+	if flags&0x01 != 0 {
+		_, err = r.u32()
+	}
+	return err
+}
+
+func (r *reader) skipTableType() error {
+	if _, err := r.byte(); err != nil {
+		return err
+	}
+	return r.skipLimits()
+}
@@ -0,0 +1,183 @@
+package codehash
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bytecodealliance/wasmtime-go/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testModule = `
+(module
+  (import "env" "output" (func $output (param i32 i32)))
+  (memory (export "memory") 1)
+  (data (i32.const 0) "hello")
+  (global $counter (mut i32) (i32.const 0))
+  (table 2 funcref)
+  (elem (i32.const 0) $indirect_a $indirect_b)
+
+  {{helpers}}
+
+  (func (export "alloc") (param i32) (result i32) (i32.const {{alloc}}))
+
+  (func $helper_a (result i32) (i32.const {{a}}))
+  (func $helper_b (result i32) (i32.const {{b}}))
+  (func $indirect_a (result i32) (i32.const {{indirect}}))
+  (func $indirect_b (result i32) (i32.const 2))
+
+  (func (export "map_a") (param i32 i32)
+    (call $output (call $helper_a) (i32.const 5)))
+  (func (export "map_b") (param i32 i32)
+    (block (result i32) (call $helper_b))
+    (global.set $counter))
+  (func (export "map_indirect") (param i32 i32)
+    (call $output (call_indirect (result i32) (local.get 0)) (i32.const 0)))
+)
+`
+
+func compile(t *testing.T, bindings map[string]string) *Binary {
+	t.Helper()
+
+	wat := testModule
+	for k, v := range map[string]string{"helpers": "", "a": "1", "b": "1", "indirect": "1", "alloc": "1024"} {
+		if _, found := bindings[k]; !found {
+			bindings[k] = v
+		}
+	}
+	for k, v := range bindings {
+		wat = strings.ReplaceAll(wat, "{{"+k+"}}", v)
+	}
+
+	content, err := wasmtime.Wat2Wasm(wat)
+	require.NoError(t, err)
+
+	binary, err := Parse(content)
+	require.NoError(t, err)
+	return binary
+}
+
+func hashes(t *testing.T, binary *Binary) map[string][]byte {
+	t.Helper()
+
+	out := map[string][]byte{}
+	for _, entrypoint := range []string{"map_a", "map_b", "map_indirect"} {
+		hash, err := binary.ReachableHash(entrypoint)
+		require.NoError(t, err)
+		out[entrypoint] = hash
+	}
+	return out
+}
+
+func TestReachableHash(t *testing.T) {
+	base := hashes(t, compile(t, map[string]string{}))
+	assert.NotEqual(t, base["map_a"], base["map_b"])
+
+	tests := []struct {
+		name            string
+		bindings        map[string]string
+		expectedChanged []string
+	}{
+		{"unrelated helper added", map[string]string{"helpers": "(func $unused (result i32) (i32.const 3))"}, nil},
+		{"helper of map_a changed", map[string]string{"a": "2"}, []string{"map_a"}},
+		{"helper of map_b changed", map[string]string{"b": "2"}, []string{"map_b"}},
+		{"function called indirectly changed", map[string]string{"indirect": "2"}, []string{"map_indirect"}},
+		{"allocator called by the host changed", map[string]string{"alloc": "2048"}, []string{"map_a", "map_b", "map_indirect"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			changed := hashes(t, compile(t, test.bindings))
+
+			for _, entrypoint := range []string{"map_a", "map_b", "map_indirect"} {
+				expectChange := false
+				for _, expected := range test.expectedChanged {
+					expectChange = expectChange || expected == entrypoint
+				}
+				if expectChange {
+					assert.NotEqual(t, base[entrypoint], changed[entrypoint], entrypoint)
+				} else {
+					assert.Equal(t, base[entrypoint], changed[entrypoint], entrypoint)
+				}
+			}
+		})
+	}
+
+	_, err := compile(t, map[string]string{}).ReachableHash("unknown")
+	require.EqualError(t, err, `function "unknown" is not exported by the wasm binary`)
+}
+
+func TestReachableHash_DataChanges(t *testing.T) {
+	base := hashes(t, compile(t, map[string]string{}))
+
+	content, err := wasmtime.Wat2Wasm(strings.NewReplacer("{{helpers}}", "", "{{a}}", "1", "{{b}}", "1", "{{indirect}}", "1", "{{alloc}}", "1024", `"hello"`, `"world"`).Replace(testModule))
+	require.NoError(t, err)
+	binary, err := Parse(content)
+	require.NoError(t, err)
+
+	// Data segments may be read by any function, changing them changes every hash
+	for entrypoint, hash := range hashes(t, binary) {
+		assert.NotEqual(t, base[entrypoint], hash, entrypoint)
+	}
+}
+
+func TestReachableHash_RustModules(t *testing.T) {
+	load := func(version string) map[string][]byte {
+		content, err := os.ReadFile(filepath.Join("testdata", "modules_"+version+".wasm"))
+		require.NoError(t, err)
+		binary, err := Parse(content)
+		require.NoError(t, err)
+
+		out := map[string][]byte{}
+		for _, entrypoint := range []string{"map_a", "map_b"} {
+			out[entrypoint], err = binary.ReachableHash(entrypoint)
+			require.NoError(t, err)
+		}
+		return out
+	}
+
+	v1, v2, v3 := load("v1"), load("v2"), load("v3")
+
+	// Only the code of map_a changed
+	assert.NotEqual(t, v1["map_a"], v2["map_a"])
+	assert.Equal(t, v1["map_b"], v2["map_b"])
+
+	// A string of map_b changed in the data segments
+	assert.NotEqual(t, v1["map_a"], v3["map_a"])
+	assert.NotEqual(t, v1["map_b"], v3["map_b"])
+}
+
+func TestReachableHash_VectorAndAtomicInstructions(t *testing.T) {
+	content, err := wasmtime.Wat2Wasm(`
+(module
+  (memory 1 1 shared)
+  (func (export "map_vector") (param i32 i32)
+    (v128.store offset=16 (local.get 0)
+      (i8x16.shuffle 0 1 2 3 4 5 6 7 8 9 10 11 12 13 14 15
+        (v128.load (local.get 0))
+        (v128.const i32x4 1 2 3 4)))
+    (drop (i32x4.extract_lane 1 (v128.load32_zero (local.get 1))))
+    (drop (v128.load8_lane 3 (local.get 0) (v128.const i64x2 0 0))))
+  (func (export "map_atomic") (param i32 i32)
+    (drop (i32.atomic.rmw.add offset=8 (local.get 0) (local.get 1)))
+    (drop (memory.atomic.notify (local.get 0) (i32.const 1)))
+    (atomic.fence))
+)`)
+	require.NoError(t, err)
+	binary, err := Parse(content)
+	require.NoError(t, err)
+
+	vectorHash, err := binary.ReachableHash("map_vector")
+	require.NoError(t, err)
+	atomicHash, err := binary.ReachableHash("map_atomic")
+	require.NoError(t, err)
+	assert.NotEqual(t, vectorHash, atomicHash)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	_, err := Parse([]byte("not wasm"))
+	require.EqualError(t, err, "invalid wasm binary: bad magic number or version")
+}
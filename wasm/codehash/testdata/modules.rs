@@ -0,0 +1,139 @@
+// Two modules sharing a binary, built in three versions to check their code hashes:
+// `v2` changes the code of `map_a` only, `v3` changes a string output by `map_b`. Written without core, so that they build without the wasm32 standard
+// library, with:
+//
+//   for v in v1 v2 v3; do
+//     rustc +nightly --target wasm32-unknown-unknown --crate-type cdylib -C opt-level=2 -C panic=abort \
+//       --cfg "handlers=\"$v\"" --check-cfg 'cfg(handlers, values("v1","v2","v3"))' modules.rs -o modules_$v.wasm
+//   done
+#![feature(no_core, lang_items, auto_traits)]
+#![no_core]
+#![allow(internal_features)]
+
+#[lang = "pointee_sized"]
+pub trait PointeeSized {}
+#[lang = "meta_sized"]
+pub trait MetaSized: PointeeSized {}
+#[lang = "sized"]
+pub trait Sized: MetaSized {}
+#[lang = "copy"]
+pub trait Copy {}
+#[lang = "freeze"]
+pub unsafe auto trait Freeze {}
+
+#[lang = "sync"]
+pub unsafe trait Sync {}
+unsafe impl Sync for Location {}
+unsafe impl Sync for &str {}
+impl Copy for i32 {}
+impl Copy for *const u8 {}
+
+#[lang = "drop_in_place"]
+#[allow(unconditional_recursion)]
+pub unsafe fn drop_in_place<T: ?Sized>(to_drop: *mut T) {
+    drop_in_place(to_drop)
+}
+
+#[lang = "add"]
+pub trait Add<Rhs = Self> {
+    type Output;
+    fn add(self, rhs: Rhs) -> Self::Output;
+}
+impl Add for i32 {
+    type Output = i32;
+    fn add(self, rhs: i32) -> i32 {
+        self + rhs
+    }
+}
+
+#[lang = "legacy_receiver"]
+pub trait LegacyReceiver {}
+impl<T: ?Sized> LegacyReceiver for &T {}
+
+#[lang = "eq"]
+pub trait PartialEq<Rhs: ?Sized = Self> {
+    fn eq(&self, other: &Rhs) -> bool;
+}
+impl PartialEq for i32 {
+    fn eq(&self, other: &i32) -> bool {
+        *self == *other
+    }
+}
+
+mod env {
+    #[link(wasm_import_module = "env")]
+    extern "C" {
+        pub fn output(ptr: *const u8, len: i32);
+        pub fn register_panic(msg_ptr: *const u8, msg_len: i32, file_ptr: *const u8, file_len: i32, line: i32, column: i32);
+    }
+}
+
+pub struct Location {
+    file: &'static str,
+    file_len: i32,
+    line: i32,
+    column: i32,
+}
+
+static mut HEAP_TOP: i32 = 0;
+
+#[no_mangle]
+pub extern "C" fn alloc(size: i32) -> i32 {
+    unsafe {
+        if HEAP_TOP == 0 {
+            HEAP_TOP = 65536;
+        }
+        let ptr = HEAP_TOP;
+        HEAP_TOP = HEAP_TOP + size;
+        ptr
+    }
+}
+
+#[no_mangle]
+pub extern "C" fn dealloc(_ptr: i32, _size: i32) {}
+
+#[inline(never)]
+fn fail(msg: &'static str, msg_len: i32, location: &'static Location) {
+    unsafe {
+        env::register_panic(
+            msg as *const str as *const u8,
+            msg_len,
+            location.file as *const str as *const u8,
+            location.file_len,
+            location.line,
+            location.column,
+        );
+    }
+}
+
+#[inline(never)]
+fn emit(msg: &'static str, len: i32) {
+    unsafe { env::output(msg as *const str as *const u8, len) }
+}
+
+static MAP_A_LOCATION: Location = Location { file: "src/map_a.rs", file_len: 12, line: 10, column: 5 };
+
+static MAP_B_LOCATION: Location = Location { file: "src/map_b.rs", file_len: 12, line: 7, column: 5 };
+
+#[no_mangle]
+pub extern "C" fn map_a(_ptr: i32, len: i32) {
+    #[cfg(not(handlers = "v2"))]
+    let min_len = 0;
+    #[cfg(handlers = "v2")]
+    let min_len = 4;
+    if len == min_len {
+        fail("map_a: empty block", 18, &MAP_A_LOCATION);
+    }
+    emit("transfers of map_a", 18);
+}
+
+#[no_mangle]
+pub extern "C" fn map_b(_ptr: i32, len: i32) {
+    if len == 0 {
+        fail("map_b: empty block", 18, &MAP_B_LOCATION);
+    }
+    #[cfg(not(handlers = "v3"))]
+    emit("balances of map_b", 17);
+    #[cfg(handlers = "v3")]
+    emit("holdings of map_b", 17);
+}